@@ -0,0 +1,367 @@
+// Package emvco provides interoperability between NASPIP tokens and EMVCo merchant-presented
+// QR codes (MPM). It embeds a NASPIP token in the merchant account information templates of an
+// EMVCo QR string, so POS and wallets that already decode EMVCo QRs can carry NASPIP payloads,
+// and extracts and verifies the embedded token when reading.
+package emvco
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/protocol"
+)
+
+// EMVCo data object IDs used by this package.
+const (
+	IdPayloadFormatIndicator = "00" // Payload format indicator, always "01"
+	IdPointOfInitiation      = "01" // "11" for static and "12" for dynamic QR codes
+	IdMerchantAccountFirst   = "26" // First merchant account information template
+	IdMerchantAccountLast    = "51" // Last merchant account information template
+	IdMerchantCategoryCode   = "52" // Merchant category code (ISO 18245)
+	IdTransactionCurrency    = "53" // Transaction currency (ISO 4217 numeric)
+	IdTransactionAmount      = "54" // Transaction amount
+	IdCountryCode            = "58" // Merchant country code (ISO 3166-1 alpha-2)
+	IdMerchantName           = "59" // Merchant name
+	IdMerchantCity           = "60" // Merchant city
+	IdCRC                    = "63" // CRC-16/CCITT-FALSE checksum
+)
+
+// GloballyUniqueId identifies the merchant account templates that carry a NASPIP token.
+const GloballyUniqueId = "naspip"
+
+// maxChunkLength is the maximum token length that fits in one merchant account template,
+// once the globally unique identifier and the sub-field headers are accounted for.
+const maxChunkLength = 99 - 4 - len(GloballyUniqueId) - 4
+
+// maxAmountLength is the maximum length of the transaction amount.
+const maxAmountLength = 13
+
+// amountPattern matches the transaction amount format: digits with an optional "." separator.
+var amountPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]*)?$`)
+
+// currencies maps ISO 4217 alphabetic codes to their numeric codes for common currencies.
+var currencies = map[string]string{
+	"ARS": "032", "BOB": "068", "BRL": "986", "CAD": "124", "CHF": "756", "CLP": "152",
+	"CNY": "156", "COP": "170", "CRC": "188", "EUR": "978", "GBP": "826", "GTQ": "320",
+	"JPY": "392", "MXN": "484", "PAB": "590", "PEN": "604", "PYG": "600", "USD": "840",
+	"UYU": "858", "VES": "928",
+}
+
+// MerchantQr represents the content of an EMVCo merchant-presented QR carrying a NASPIP token.
+type MerchantQr struct {
+	NaspipToken  string // NASPIP token ("naspip;[key-issuer];[key-id];[paseto-token]")
+	MerchantName string // Merchant name (truncated to 25 characters)
+	MerchantCity string // Merchant city (truncated to 15 characters)
+	CountryCode  string // Merchant country code (ISO 3166-1 alpha-2)
+	Mcc          string // Merchant category code (ISO 18245), "0000" when unknown
+	Currency     string // Transaction currency, ISO 4217 alphabetic or numeric code
+	Amount       string // Optional transaction amount, up to 13 characters of digits and a single "."
+	Static       bool   // Whether the QR is static (reusable) instead of dynamic
+}
+
+// NewMerchantQr creates a MerchantQr for a NASPIP token using the order information of the
+// instruction for the merchant name, MCC, currency and amount.
+//
+// Parameters:
+//   - token: The NASPIP token to embed
+//   - order: The order information of the instruction, may be nil
+//   - countryCode: Merchant country code (ISO 3166-1 alpha-2)
+//   - city: Merchant city
+//
+// Returns:
+//   - The MerchantQr ready to be encoded
+func NewMerchantQr(token string, order *protocol.InstructionOrder, countryCode string, city string) MerchantQr {
+	qr := MerchantQr{NaspipToken: token, CountryCode: countryCode, MerchantCity: city}
+
+	if order != nil {
+		qr.Currency = order.CoinCode
+		qr.Amount = order.Total
+
+		if order.Merchant != nil {
+			qr.MerchantName = order.Merchant.Name
+			qr.Mcc = order.Merchant.Mcc
+		}
+	}
+
+	return qr
+}
+
+// Encode builds the EMVCo QR string for the given content.
+// The NASPIP token is split across consecutive merchant account information templates
+// starting at ID 26, each identified by GloballyUniqueId, and the CRC is appended last.
+//
+// EMVCo recommends payloads of at most 512 characters. Signed NASPIP tokens are usually
+// longer once split into templates, so the result is only limited by the number of
+// available templates (26 to 51) and must be rendered with a QR version large enough for it.
+//
+// Returns:
+//   - The EMVCo QR string
+//   - An error if a mandatory field is missing, the currency is unknown, the amount is not
+//     in the EMVCo format, or the token does not fit in the merchant account information
+//     templates
+func Encode(qr MerchantQr) (string, error) {
+	if qr.NaspipToken == "" {
+		return "", errors.New("naspip token is required")
+	}
+
+	if qr.MerchantName == "" || qr.MerchantCity == "" {
+		return "", errors.New("merchant name and city are required")
+	}
+
+	if len(qr.CountryCode) != 2 {
+		return "", errors.New("invalid country code")
+	}
+
+	currency, err := numericCurrency(qr.Currency)
+
+	if err != nil {
+		return "", err
+	}
+
+	mcc := qr.Mcc
+
+	if mcc == "" {
+		mcc = "0000"
+	}
+
+	if len(mcc) != 4 {
+		return "", errors.New("invalid merchant category code")
+	}
+
+	if qr.Amount != "" && (len(qr.Amount) > maxAmountLength || !amountPattern.MatchString(qr.Amount)) {
+		return "", errors.New("invalid transaction amount")
+	}
+
+	pointOfInitiation := "12"
+
+	if qr.Static {
+		pointOfInitiation = "11"
+	}
+
+	fields := []Field{
+		{ID: IdPayloadFormatIndicator, Value: "01"},
+		{ID: IdPointOfInitiation, Value: pointOfInitiation},
+	}
+
+	templates, err := tokenTemplates(qr.NaspipToken)
+
+	if err != nil {
+		return "", err
+	}
+
+	fields = append(fields, templates...)
+	fields = append(fields,
+		Field{ID: IdMerchantCategoryCode, Value: mcc},
+		Field{ID: IdTransactionCurrency, Value: currency},
+	)
+
+	if qr.Amount != "" {
+		fields = append(fields, Field{ID: IdTransactionAmount, Value: qr.Amount})
+	}
+
+	fields = append(fields,
+		Field{ID: IdCountryCode, Value: strings.ToUpper(qr.CountryCode)},
+		Field{ID: IdMerchantName, Value: truncate(qr.MerchantName, 25)},
+		Field{ID: IdMerchantCity, Value: truncate(qr.MerchantCity, 15)},
+	)
+
+	encoded, err := EncodeFields(fields)
+
+	if err != nil {
+		return "", err
+	}
+
+	encoded += IdCRC + "04"
+	encoded += fmt.Sprintf("%04X", CRC16(encoded))
+
+	return encoded, nil
+}
+
+// Decode parses an EMVCo QR string, verifies its CRC and extracts the embedded NASPIP token.
+// The token signature is not verified; use Read for that.
+//
+// Returns:
+//   - The decoded QR content
+//   - An error if the TLV structure or CRC is invalid, or no NASPIP token is present
+func Decode(payload string) (MerchantQr, error) {
+	crcIndex := len(payload) - 8
+
+	if crcIndex < 0 || payload[crcIndex:crcIndex+4] != IdCRC+"04" {
+		return MerchantQr{}, errors.New("missing crc")
+	}
+
+	if fmt.Sprintf("%04X", CRC16(payload[:crcIndex+4])) != strings.ToUpper(payload[crcIndex+4:]) {
+		return MerchantQr{}, errors.New("invalid crc")
+	}
+
+	fields, err := ParseFields(payload[:crcIndex])
+
+	if err != nil {
+		return MerchantQr{}, err
+	}
+
+	if len(fields) == 0 || fields[0].ID != IdPayloadFormatIndicator || fields[0].Value != "01" {
+		return MerchantQr{}, errors.New("invalid payload format indicator")
+	}
+
+	qr := MerchantQr{}
+	chunks := map[string]string{}
+
+	for _, field := range fields {
+		switch {
+		case field.ID == IdPointOfInitiation:
+			qr.Static = field.Value == "11"
+		case field.ID >= IdMerchantAccountFirst && field.ID <= IdMerchantAccountLast:
+			chunk, ok, err := tokenChunk(field.Value)
+
+			if err != nil {
+				return MerchantQr{}, err
+			}
+
+			if ok {
+				chunks[field.ID] = chunk
+			}
+		case field.ID == IdMerchantCategoryCode:
+			qr.Mcc = field.Value
+		case field.ID == IdTransactionCurrency:
+			qr.Currency = alphabeticCurrency(field.Value)
+		case field.ID == IdTransactionAmount:
+			qr.Amount = field.Value
+		case field.ID == IdCountryCode:
+			qr.CountryCode = field.Value
+		case field.ID == IdMerchantName:
+			qr.MerchantName = field.Value
+		case field.ID == IdMerchantCity:
+			qr.MerchantCity = field.Value
+		}
+	}
+
+	if len(chunks) == 0 {
+		return MerchantQr{}, errors.New("naspip token not found")
+	}
+
+	ids := make([]string, 0, len(chunks))
+
+	for id := range chunks {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		qr.NaspipToken += chunks[id]
+	}
+
+	return qr, nil
+}
+
+// Read decodes an EMVCo QR string and verifies the embedded NASPIP token with the builder.
+//
+// Parameters:
+//   - payload: The EMVCo QR string
+//   - builder: The builder used to verify the NASPIP token
+//   - publicKey: The public key (in raw or PASERK format) to verify the token signature
+//   - options: Options controlling verification behavior
+//
+// Returns:
+//   - The decoded QR content and the verified token content
+//   - An error if decoding or verification fails
+func Read(payload string, builder protocol.PaymentInstructionsBuilder, publicKey string, options protocol.QrCriptoReadOptions) (MerchantQr, *paseto.PasetoCompleteResult, error) {
+	qr, err := Decode(payload)
+
+	if err != nil {
+		return MerchantQr{}, nil, err
+	}
+
+	data, err := builder.Read(qr.NaspipToken, publicKey, options)
+
+	if err != nil {
+		return MerchantQr{}, nil, err
+	}
+
+	return qr, data, nil
+}
+
+// tokenTemplates splits a NASPIP token into merchant account information templates.
+func tokenTemplates(token string) ([]Field, error) {
+	templates := []Field{}
+
+	for id := 26; len(token) > 0; id++ {
+		if id > 51 {
+			return nil, errors.New("naspip token too long")
+		}
+
+		length := min(len(token), maxChunkLength)
+
+		value, _ := EncodeFields([]Field{{ID: "00", Value: GloballyUniqueId}, {ID: "01", Value: token[:length]}})
+
+		templates = append(templates, Field{ID: fmt.Sprintf("%02d", id), Value: value})
+		token = token[length:]
+	}
+
+	return templates, nil
+}
+
+// tokenChunk extracts the NASPIP token chunk from a merchant account information template.
+// The boolean result is false when the template belongs to another payment scheme.
+func tokenChunk(template string) (string, bool, error) {
+	fields, err := ParseFields(template)
+
+	if err != nil {
+		return "", false, err
+	}
+
+	if len(fields) == 0 || fields[0].ID != "00" || fields[0].Value != GloballyUniqueId {
+		return "", false, nil
+	}
+
+	for _, field := range fields[1:] {
+		if field.ID == "01" {
+			return field.Value, true, nil
+		}
+	}
+
+	return "", false, errors.New("invalid naspip merchant account template")
+}
+
+// numericCurrency converts an ISO 4217 currency code to its numeric form.
+func numericCurrency(currency string) (string, error) {
+	if len(currency) == 3 && currency[0] >= '0' && currency[0] <= '9' {
+		return currency, nil
+	}
+
+	numeric, ok := currencies[strings.ToUpper(currency)]
+
+	if !ok {
+		return "", errors.New("unsupported currency")
+	}
+
+	return numeric, nil
+}
+
+// alphabeticCurrency converts a numeric ISO 4217 currency code to its alphabetic form
+// when known, returning the numeric code otherwise.
+func alphabeticCurrency(numeric string) string {
+	for alpha, code := range currencies {
+		if code == numeric {
+			return alpha
+		}
+	}
+
+	return numeric
+}
+
+// truncate shortens value to at most length characters.
+func truncate(value string, length int) string {
+	runes := []rune(value)
+
+	if len(runes) <= length {
+		return value
+	}
+
+	return string(runes[:length])
+}
@@ -0,0 +1,176 @@
+package emvco
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/protocol"
+	"github.com/fluxisus/naspip-go/v3/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var keys = map[string]string{
+	"publicKey": "k4.public.sGVse4eAyt6ycfmkKl3Az7RxB34nklDPgKbNLvxVwlk",
+	"secretKey": "k4.secret.y4-gze54dwfLR0eyxiJL2mRicZr6SX2-xIn6kgo999iwZWx7h4DK3rJx-aQqXcDPtHEHfieSUM-Aps0u_FXCWQ",
+}
+
+// EMVCo sample payload published in the Pix (BCB) specification
+var pixSample = "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D"
+
+// Should compute CRC-16/CCITT-FALSE test vectors
+func TestCRC16(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(uint16(0x29B1), CRC16("123456789"))
+	assert.Equal(uint16(0x1D3D), CRC16(pixSample[:len(pixSample)-4]))
+}
+
+// Should parse and encode TLV fields
+func TestParseFields(t *testing.T) {
+	assert := assert.New(t)
+
+	fields, err := ParseFields(pixSample)
+
+	assert.Nil(err)
+	assert.Equal(Field{ID: "00", Value: "01"}, fields[0])
+	assert.Equal(Field{ID: "59", Value: "Fulano de Tal"}, fields[5])
+	assert.Equal(Field{ID: "63", Value: "1D3D"}, fields[len(fields)-1])
+
+	encoded, err := EncodeFields(fields)
+
+	assert.Nil(err)
+	assert.Equal(pixSample, encoded)
+
+	_, err = ParseFields("000201265")
+
+	assert.EqualError(err, "invalid tlv header")
+
+	_, err = ParseFields("0002010599abc")
+
+	assert.EqualError(err, "field 05 exceeds payload length")
+
+	_, err = EncodeFields([]Field{{ID: "59", Value: strings.Repeat("a", 100)}})
+
+	assert.EqualError(err, "field 59 exceeds maximum length")
+}
+
+// Should reject QR codes without a NASPIP token or with an invalid CRC
+func TestDecodeInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := Decode(pixSample)
+
+	assert.EqualError(err, "naspip token not found")
+
+	_, err = Decode(pixSample[:len(pixSample)-4] + "0000")
+
+	assert.EqualError(err, "invalid crc")
+
+	_, err = Decode("000201")
+
+	assert.EqualError(err, "missing crc")
+}
+
+// Should embed a NASPIP token in an EMVCo QR and read it back
+func TestEncodeAndRead(t *testing.T) {
+	assert := assert.New(t)
+
+	var builder = protocol.PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	var order = &protocol.InstructionOrder{
+		Total:    "1500.50",
+		CoinCode: "ARS",
+		Merchant: &protocol.InstructionMerchant{Name: "Panaderia La Esquina de Palermo", Mcc: "5462"},
+	}
+
+	token, err := builder.CreatePaymentInstruction(protocol.InstructionPayload{
+		Payment: protocol.PaymentInstruction{
+			Id:            "payment-id",
+			UniqueAssetId: "ntrc20_tTR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+			Address:       "TRjE1H8dxypKM1NZRdysbs9wo7huR4bdNz",
+			Amount:        "1.25",
+			ExpiresAt:     time.Now().Add(time.Hour).UnixMilli(),
+		},
+	}, keys["secretKey"], protocol.QrCriptoCreateOptions{
+		SignOptions:   paseto.PasetoSignOptions{KeyId: "key-id-one", ExpiresIn: "5m", Assertion: []byte(keys["publicKey"])},
+		KeyIssuer:     "payment-processor.com",
+		KeyExpiration: time.Now().Add(1e9).Format(utils.RFC3339Mili),
+	})
+
+	assert.Nil(err)
+
+	qr, err := Encode(NewMerchantQr(token, order, "AR", "Buenos Aires"))
+
+	if err != nil {
+		t.Errorf("TestEncodeAndRead FAIL --> %v", err)
+	}
+
+	assert.True(strings.HasPrefix(qr, "000201010212"))
+
+	decoded, data, err := Read(qr, builder, keys["publicKey"], protocol.QrCriptoReadOptions{KeyIssuer: "payment-processor.com"})
+
+	if err != nil {
+		t.Errorf("TestEncodeAndRead FAIL --> %v", err)
+	}
+
+	assert.Equal(token, decoded.NaspipToken)
+	assert.Equal("Panaderia La Esquina de P", decoded.MerchantName)
+	assert.Equal("Buenos Aires", decoded.MerchantCity)
+	assert.Equal("5462", decoded.Mcc)
+	assert.Equal("ARS", decoded.Currency)
+	assert.Equal("1500.50", decoded.Amount)
+	assert.Equal("AR", decoded.CountryCode)
+	assert.False(decoded.Static)
+	assert.Equal("payment-id", data.Payload.Data["payment"].(map[string]interface{})["id"])
+}
+
+// Should fail to encode an EMVCo QR with missing or invalid fields
+func TestEncodeInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	var qr = MerchantQr{NaspipToken: "naspip;kis;kid;v4.public.token", MerchantName: "Store", MerchantCity: "Lima", CountryCode: "PE", Currency: "PEN"}
+
+	_, err := Encode(qr)
+
+	assert.Nil(err)
+
+	invalid := qr
+	invalid.Currency = "XXX"
+
+	_, err = Encode(invalid)
+
+	assert.EqualError(err, "unsupported currency")
+
+	invalid = qr
+	invalid.CountryCode = ""
+
+	_, err = Encode(invalid)
+
+	assert.EqualError(err, "invalid country code")
+
+	for _, amount := range []string{"1500.123456789", "1.500,00", "-10", "10.0.0", ".5", "1e3"} {
+		invalid = qr
+		invalid.Amount = amount
+
+		_, err = Encode(invalid)
+
+		assert.EqualError(err, "invalid transaction amount", amount)
+	}
+
+	invalid = qr
+	invalid.Amount = "1234567890.12"
+
+	_, err = Encode(invalid)
+
+	assert.Nil(err)
+
+	invalid = qr
+	invalid.NaspipToken = strings.Repeat("a", 3000)
+
+	_, err = Encode(invalid)
+
+	assert.EqualError(err, "naspip token too long")
+}
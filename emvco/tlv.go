@@ -0,0 +1,103 @@
+package emvco
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Field is a single EMVCo TLV (tag-length-value) data object.
+// Both the ID and the length are encoded as two decimal digits.
+type Field struct {
+	ID    string // Two digit data object ID (e.g., "59" for the merchant name)
+	Value string // Data object value
+}
+
+// String encodes the field in its TLV representation.
+func (f Field) String() string {
+	return fmt.Sprintf("%s%02d%s", f.ID, len(f.Value), f.Value)
+}
+
+// EncodeFields concatenates the TLV representation of the given fields.
+//
+// Returns:
+//   - The encoded fields
+//   - An error if an ID is not two digits or a value is longer than 99 characters
+func EncodeFields(fields []Field) (string, error) {
+	var builder strings.Builder
+
+	for _, field := range fields {
+		if !isTwoDigits(field.ID) {
+			return "", errors.New("invalid field id")
+		}
+
+		if len(field.Value) > 99 {
+			return "", fmt.Errorf("field %s exceeds maximum length", field.ID)
+		}
+
+		builder.WriteString(field.String())
+	}
+
+	return builder.String(), nil
+}
+
+// ParseFields splits a TLV encoded string into its fields.
+// Nested templates are returned as a single field; call ParseFields again on their value.
+//
+// Returns:
+//   - The fields in the order they appear
+//   - An error if an ID or length is malformed or a value exceeds the remaining input
+func ParseFields(data string) ([]Field, error) {
+	fields := []Field{}
+
+	for position := 0; position < len(data); {
+		if position+4 > len(data) {
+			return nil, errors.New("invalid tlv header")
+		}
+
+		id := data[position : position+2]
+		rawLength := data[position+2 : position+4]
+
+		if !isTwoDigits(id) || !isTwoDigits(rawLength) {
+			return nil, errors.New("invalid tlv header")
+		}
+
+		length, _ := strconv.Atoi(rawLength)
+		position += 4
+
+		if position+length > len(data) {
+			return nil, fmt.Errorf("field %s exceeds payload length", id)
+		}
+
+		fields = append(fields, Field{ID: id, Value: data[position : position+length]})
+		position += length
+	}
+
+	return fields, nil
+}
+
+// CRC16 computes the CRC-16/CCITT-FALSE checksum (polynomial 0x1021, initial value 0xFFFF)
+// used by EMVCo merchant-presented QR codes.
+func CRC16(data string) uint16 {
+	crc := uint16(0xFFFF)
+
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
+
+// isTwoDigits reports whether value consists of exactly two decimal digits.
+func isTwoDigits(value string) bool {
+	return len(value) == 2 && value[0] >= '0' && value[0] <= '9' && value[1] >= '0' && value[1] <= '9'
+}
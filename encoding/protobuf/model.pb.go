@@ -212,6 +212,8 @@ type InstructionItem struct {
 	CoinCode      string                 `protobuf:"bytes,3,opt,name=coin_code,proto3" json:"coin_code,omitempty"`
 	UnitPrice     string                 `protobuf:"bytes,4,opt,name=unit_price,proto3" json:"unit_price,omitempty"`
 	Quantity      int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Sku           string                 `protobuf:"bytes,6,opt,name=sku,proto3" json:"sku,omitempty"`
+	UnitOfMeasure string                 `protobuf:"bytes,7,opt,name=unit_of_measure,proto3" json:"unit_of_measure,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -281,6 +283,140 @@ func (x *InstructionItem) GetQuantity() int32 {
 	return 0
 }
 
+func (x *InstructionItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *InstructionItem) GetUnitOfMeasure() string {
+	if x != nil {
+		return x.UnitOfMeasure
+	}
+	return ""
+}
+
+type InstructionTax struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaxType       string                 `protobuf:"bytes,1,opt,name=tax_type,proto3" json:"tax_type,omitempty"`
+	Rate          string                 `protobuf:"bytes,2,opt,name=rate,proto3" json:"rate,omitempty"`
+	Amount        string                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Included      bool                   `protobuf:"varint,4,opt,name=included,proto3" json:"included,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstructionTax) Reset() {
+	*x = InstructionTax{}
+	mi := &file_encoding_protobuf_model_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstructionTax) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstructionTax) ProtoMessage() {}
+
+func (x *InstructionTax) ProtoReflect() protoreflect.Message {
+	mi := &file_encoding_protobuf_model_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstructionTax.ProtoReflect.Descriptor instead.
+func (*InstructionTax) Descriptor() ([]byte, []int) {
+	return file_encoding_protobuf_model_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *InstructionTax) GetTaxType() string {
+	if x != nil {
+		return x.TaxType
+	}
+	return ""
+}
+
+func (x *InstructionTax) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *InstructionTax) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *InstructionTax) GetIncluded() bool {
+	if x != nil {
+		return x.Included
+	}
+	return false
+}
+
+type InstructionDiscount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Description   string                 `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	Amount        string                 `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstructionDiscount) Reset() {
+	*x = InstructionDiscount{}
+	mi := &file_encoding_protobuf_model_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstructionDiscount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstructionDiscount) ProtoMessage() {}
+
+func (x *InstructionDiscount) ProtoReflect() protoreflect.Message {
+	mi := &file_encoding_protobuf_model_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstructionDiscount.ProtoReflect.Descriptor instead.
+func (*InstructionDiscount) Descriptor() ([]byte, []int) {
+	return file_encoding_protobuf_model_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *InstructionDiscount) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *InstructionDiscount) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
 type InstructionOrder struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Total         string                 `protobuf:"bytes,1,opt,name=total,proto3" json:"total,omitempty"`
@@ -288,25 +424,840 @@ type InstructionOrder struct {
 	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
 	Merchant      *InstructionMerchant   `protobuf:"bytes,4,opt,name=merchant,proto3" json:"merchant,omitempty"`
 	Items         []*InstructionItem     `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	Taxes         []*InstructionTax      `protobuf:"bytes,6,rep,name=taxes,proto3" json:"taxes,omitempty"`
+	Discounts     []*InstructionDiscount `protobuf:"bytes,7,rep,name=discounts,proto3" json:"discounts,omitempty"`
+	Shipping      string                 `protobuf:"bytes,8,opt,name=shipping,proto3" json:"shipping,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstructionOrder) Reset() {
+	*x = InstructionOrder{}
+	mi := &file_encoding_protobuf_model_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstructionOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstructionOrder) ProtoMessage() {}
+
+func (x *InstructionOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_encoding_protobuf_model_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstructionOrder.ProtoReflect.Descriptor instead.
+func (*InstructionOrder) Descriptor() ([]byte, []int) {
+	return file_encoding_protobuf_model_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *InstructionOrder) GetTotal() string {
+	if x != nil {
+		return x.Total
+	}
+	return ""
+}
+
+func (x *InstructionOrder) GetCoinCode() string {
+	if x != nil {
+		return x.CoinCode
+	}
+	return ""
+}
+
+func (x *InstructionOrder) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *InstructionOrder) GetMerchant() *InstructionMerchant {
+	if x != nil {
+		return x.Merchant
+	}
+	return nil
+}
+
+func (x *InstructionOrder) GetItems() []*InstructionItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *InstructionOrder) GetTaxes() []*InstructionTax {
+	if x != nil {
+		return x.Taxes
+	}
+	return nil
+}
+
+func (x *InstructionOrder) GetDiscounts() []*InstructionDiscount {
+	if x != nil {
+		return x.Discounts
+	}
+	return nil
+}
+
+func (x *InstructionOrder) GetShipping() string {
+	if x != nil {
+		return x.Shipping
+	}
+	return ""
+}
+
+type ExchangeRateQuote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BaseCurrency  string                 `protobuf:"bytes,1,opt,name=base_currency,proto3" json:"base_currency,omitempty"`
+	QuoteCurrency string                 `protobuf:"bytes,2,opt,name=quote_currency,proto3" json:"quote_currency,omitempty"`
+	Rate          string                 `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	Source        string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,5,opt,name=expires_at,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExchangeRateQuote) Reset() {
+	*x = ExchangeRateQuote{}
+	mi := &file_encoding_protobuf_model_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExchangeRateQuote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangeRateQuote) ProtoMessage() {}
+
+func (x *ExchangeRateQuote) ProtoReflect() protoreflect.Message {
+	mi := &file_encoding_protobuf_model_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangeRateQuote.ProtoReflect.Descriptor instead.
+func (*ExchangeRateQuote) Descriptor() ([]byte, []int) {
+	return file_encoding_protobuf_model_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ExchangeRateQuote) GetBaseCurrency() string {
+	if x != nil {
+		return x.BaseCurrency
+	}
+	return ""
+}
+
+func (x *ExchangeRateQuote) GetQuoteCurrency() string {
+	if x != nil {
+		return x.QuoteCurrency
+	}
+	return ""
+}
+
+func (x *ExchangeRateQuote) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *ExchangeRateQuote) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ExchangeRateQuote) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type InstructionPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payment       *PaymentInstruction    `protobuf:"bytes,1,opt,name=payment,proto3" json:"payment,omitempty"`
+	Order         *InstructionOrder      `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
+	Quote         *ExchangeRateQuote     `protobuf:"bytes,3,opt,name=quote,proto3" json:"quote,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *InstructionOrder) Reset() {
-	*x = InstructionOrder{}
-	mi := &file_encoding_protobuf_model_proto_msgTypes[3]
+func (x *InstructionPayload) Reset() {
+	*x = InstructionPayload{}
+	mi := &file_encoding_protobuf_model_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstructionPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstructionPayload) ProtoMessage() {}
+
+func (x *InstructionPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_encoding_protobuf_model_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstructionPayload.ProtoReflect.Descriptor instead.
+func (*InstructionPayload) Descriptor() ([]byte, []int) {
+	return file_encoding_protobuf_model_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *InstructionPayload) GetPayment() *PaymentInstruction {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+func (x *InstructionPayload) GetOrder() *InstructionOrder {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *InstructionPayload) GetQuote() *ExchangeRateQuote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+type UrlPayload struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Url            string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	PaymentOptions []string               `protobuf:"bytes,2,rep,name=payment_options,proto3" json:"payment_options,omitempty"`
+	Order          *InstructionOrder      `protobuf:"bytes,3,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UrlPayload) Reset() {
+	*x = UrlPayload{}
+	mi := &file_encoding_protobuf_model_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UrlPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UrlPayload) ProtoMessage() {}
+
+func (x *UrlPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_encoding_protobuf_model_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UrlPayload.ProtoReflect.Descriptor instead.
+func (*UrlPayload) Descriptor() ([]byte, []int) {
+	return file_encoding_protobuf_model_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UrlPayload) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *UrlPayload) GetPaymentOptions() []string {
+	if x != nil {
+		return x.PaymentOptions
+	}
+	return nil
+}
+
+func (x *UrlPayload) GetOrder() *InstructionOrder {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type MultiAssetPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payments      []*PaymentInstruction  `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
+	Order         *InstructionOrder      `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MultiAssetPayload) Reset() {
+	*x = MultiAssetPayload{}
+	mi := &file_encoding_protobuf_model_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MultiAssetPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiAssetPayload) ProtoMessage() {}
+
+func (x *MultiAssetPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_encoding_protobuf_model_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiAssetPayload.ProtoReflect.Descriptor instead.
+func (*MultiAssetPayload) Descriptor() ([]byte, []int) {
+	return file_encoding_protobuf_model_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MultiAssetPayload) GetPayments() []*PaymentInstruction {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+func (x *MultiAssetPayload) GetOrder() *InstructionOrder {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type ReceiptPayload struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	InstructionHash string                 `protobuf:"bytes,1,opt,name=instruction_hash,proto3" json:"instruction_hash,omitempty"`
+	InstructionId   string                 `protobuf:"bytes,2,opt,name=instruction_id,proto3" json:"instruction_id,omitempty"`
+	InstructionJti  string                 `protobuf:"bytes,3,opt,name=instruction_jti,proto3" json:"instruction_jti,omitempty"`
+	TxHash          string                 `protobuf:"bytes,4,opt,name=tx_hash,proto3" json:"tx_hash,omitempty"`
+	Network         string                 `protobuf:"bytes,5,opt,name=network,proto3" json:"network,omitempty"`
+	UniqueAssetId   string                 `protobuf:"bytes,6,opt,name=unique_asset_id,proto3" json:"unique_asset_id,omitempty"`
+	Amount          string                 `protobuf:"bytes,7,opt,name=amount,proto3" json:"amount,omitempty"`
+	PaidAt          int64                  `protobuf:"varint,8,opt,name=paid_at,proto3" json:"paid_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReceiptPayload) Reset() {
+	*x = ReceiptPayload{}
+	mi := &file_encoding_protobuf_model_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiptPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiptPayload) ProtoMessage() {}
+
+func (x *ReceiptPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_encoding_protobuf_model_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiptPayload.ProtoReflect.Descriptor instead.
+func (*ReceiptPayload) Descriptor() ([]byte, []int) {
+	return file_encoding_protobuf_model_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ReceiptPayload) GetInstructionHash() string {
+	if x != nil {
+		return x.InstructionHash
+	}
+	return ""
+}
+
+func (x *ReceiptPayload) GetInstructionId() string {
+	if x != nil {
+		return x.InstructionId
+	}
+	return ""
+}
+
+func (x *ReceiptPayload) GetInstructionJti() string {
+	if x != nil {
+		return x.InstructionJti
+	}
+	return ""
+}
+
+func (x *ReceiptPayload) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *ReceiptPayload) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *ReceiptPayload) GetUniqueAssetId() string {
+	if x != nil {
+		return x.UniqueAssetId
+	}
+	return ""
+}
+
+func (x *ReceiptPayload) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *ReceiptPayload) GetPaidAt() int64 {
+	if x != nil {
+		return x.PaidAt
+	}
+	return 0
+}
+
+type RefundPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OriginalId    string                 `protobuf:"bytes,1,opt,name=original_id,proto3" json:"original_id,omitempty"`
+	OriginalJti   string                 `protobuf:"bytes,2,opt,name=original_jti,proto3" json:"original_jti,omitempty"`
+	UniqueAssetId string                 `protobuf:"bytes,3,opt,name=unique_asset_id,proto3" json:"unique_asset_id,omitempty"`
+	Amount        string                 `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Address       string                 `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+	AddressTag    string                 `protobuf:"bytes,6,opt,name=address_tag,proto3" json:"address_tag,omitempty"`
+	Reason        string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundPayload) Reset() {
+	*x = RefundPayload{}
+	mi := &file_encoding_protobuf_model_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundPayload) ProtoMessage() {}
+
+func (x *RefundPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_encoding_protobuf_model_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundPayload.ProtoReflect.Descriptor instead.
+func (*RefundPayload) Descriptor() ([]byte, []int) {
+	return file_encoding_protobuf_model_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RefundPayload) GetOriginalId() string {
+	if x != nil {
+		return x.OriginalId
+	}
+	return ""
+}
+
+func (x *RefundPayload) GetOriginalJti() string {
+	if x != nil {
+		return x.OriginalJti
+	}
+	return ""
+}
+
+func (x *RefundPayload) GetUniqueAssetId() string {
+	if x != nil {
+		return x.UniqueAssetId
+	}
+	return ""
+}
+
+func (x *RefundPayload) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *RefundPayload) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *RefundPayload) GetAddressTag() string {
+	if x != nil {
+		return x.AddressTag
+	}
+	return ""
+}
+
+func (x *RefundPayload) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type MandatePayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UniqueAssetId string                 `protobuf:"bytes,2,opt,name=unique_asset_id,proto3" json:"unique_asset_id,omitempty"`
+	MaxAmount     string                 `protobuf:"bytes,3,opt,name=max_amount,proto3" json:"max_amount,omitempty"`
+	Interval      string                 `protobuf:"bytes,4,opt,name=interval,proto3" json:"interval,omitempty"`
+	IntervalCount int32                  `protobuf:"varint,5,opt,name=interval_count,proto3" json:"interval_count,omitempty"`
+	StartAt       int64                  `protobuf:"varint,6,opt,name=start_at,proto3" json:"start_at,omitempty"`
+	Count         int32                  `protobuf:"varint,7,opt,name=count,proto3" json:"count,omitempty"`
+	EndAt         int64                  `protobuf:"varint,8,opt,name=end_at,proto3" json:"end_at,omitempty"`
+	Merchant      *InstructionMerchant   `protobuf:"bytes,9,opt,name=merchant,proto3" json:"merchant,omitempty"`
+	Description   string                 `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MandatePayload) Reset() {
+	*x = MandatePayload{}
+	mi := &file_encoding_protobuf_model_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MandatePayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MandatePayload) ProtoMessage() {}
+
+func (x *MandatePayload) ProtoReflect() protoreflect.Message {
+	mi := &file_encoding_protobuf_model_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MandatePayload.ProtoReflect.Descriptor instead.
+func (*MandatePayload) Descriptor() ([]byte, []int) {
+	return file_encoding_protobuf_model_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MandatePayload) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MandatePayload) GetUniqueAssetId() string {
+	if x != nil {
+		return x.UniqueAssetId
+	}
+	return ""
+}
+
+func (x *MandatePayload) GetMaxAmount() string {
+	if x != nil {
+		return x.MaxAmount
+	}
+	return ""
+}
+
+func (x *MandatePayload) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *MandatePayload) GetIntervalCount() int32 {
+	if x != nil {
+		return x.IntervalCount
+	}
+	return 0
+}
+
+func (x *MandatePayload) GetStartAt() int64 {
+	if x != nil {
+		return x.StartAt
+	}
+	return 0
+}
+
+func (x *MandatePayload) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *MandatePayload) GetEndAt() int64 {
+	if x != nil {
+		return x.EndAt
+	}
+	return 0
+}
+
+func (x *MandatePayload) GetMerchant() *InstructionMerchant {
+	if x != nil {
+		return x.Merchant
+	}
+	return nil
+}
+
+func (x *MandatePayload) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type MandateAcceptance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MandateHash   string                 `protobuf:"bytes,1,opt,name=mandate_hash,proto3" json:"mandate_hash,omitempty"`
+	MandateId     string                 `protobuf:"bytes,2,opt,name=mandate_id,proto3" json:"mandate_id,omitempty"`
+	Payer         string                 `protobuf:"bytes,3,opt,name=payer,proto3" json:"payer,omitempty"`
+	AcceptedAt    int64                  `protobuf:"varint,4,opt,name=accepted_at,proto3" json:"accepted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MandateAcceptance) Reset() {
+	*x = MandateAcceptance{}
+	mi := &file_encoding_protobuf_model_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MandateAcceptance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MandateAcceptance) ProtoMessage() {}
+
+func (x *MandateAcceptance) ProtoReflect() protoreflect.Message {
+	mi := &file_encoding_protobuf_model_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MandateAcceptance.ProtoReflect.Descriptor instead.
+func (*MandateAcceptance) Descriptor() ([]byte, []int) {
+	return file_encoding_protobuf_model_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MandateAcceptance) GetMandateHash() string {
+	if x != nil {
+		return x.MandateHash
+	}
+	return ""
+}
+
+func (x *MandateAcceptance) GetMandateId() string {
+	if x != nil {
+		return x.MandateId
+	}
+	return ""
+}
+
+func (x *MandateAcceptance) GetPayer() string {
+	if x != nil {
+		return x.Payer
+	}
+	return ""
+}
+
+func (x *MandateAcceptance) GetAcceptedAt() int64 {
+	if x != nil {
+		return x.AcceptedAt
+	}
+	return 0
+}
+
+type CoSignedPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MerchantToken string                 `protobuf:"bytes,1,opt,name=merchant_token,proto3" json:"merchant_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoSignedPayload) Reset() {
+	*x = CoSignedPayload{}
+	mi := &file_encoding_protobuf_model_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoSignedPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoSignedPayload) ProtoMessage() {}
+
+func (x *CoSignedPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_encoding_protobuf_model_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoSignedPayload.ProtoReflect.Descriptor instead.
+func (*CoSignedPayload) Descriptor() ([]byte, []int) {
+	return file_encoding_protobuf_model_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CoSignedPayload) GetMerchantToken() string {
+	if x != nil {
+		return x.MerchantToken
+	}
+	return ""
+}
+
+type SealedPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SignedToken   string                 `protobuf:"bytes,1,opt,name=signed_token,proto3" json:"signed_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SealedPayload) Reset() {
+	*x = SealedPayload{}
+	mi := &file_encoding_protobuf_model_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SealedPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SealedPayload) ProtoMessage() {}
+
+func (x *SealedPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_encoding_protobuf_model_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SealedPayload.ProtoReflect.Descriptor instead.
+func (*SealedPayload) Descriptor() ([]byte, []int) {
+	return file_encoding_protobuf_model_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SealedPayload) GetSignedToken() string {
+	if x != nil {
+		return x.SignedToken
+	}
+	return ""
+}
+
+type KeyCertificate struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PublicKey       string                 `protobuf:"bytes,1,opt,name=public_key,proto3" json:"public_key,omitempty"`
+	Kid             string                 `protobuf:"bytes,2,opt,name=kid,proto3" json:"kid,omitempty"`
+	Kis             string                 `protobuf:"bytes,3,opt,name=kis,proto3" json:"kis,omitempty"`
+	AllowedAssetIds []string               `protobuf:"bytes,4,rep,name=allowed_asset_ids,proto3" json:"allowed_asset_ids,omitempty"`
+	NotBefore       int64                  `protobuf:"varint,5,opt,name=not_before,proto3" json:"not_before,omitempty"`
+	NotAfter        int64                  `protobuf:"varint,6,opt,name=not_after,proto3" json:"not_after,omitempty"`
+	IsCa            bool                   `protobuf:"varint,7,opt,name=is_ca,proto3" json:"is_ca,omitempty"`
+	PathLength      int32                  `protobuf:"varint,8,opt,name=path_length,proto3" json:"path_length,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *KeyCertificate) Reset() {
+	*x = KeyCertificate{}
+	mi := &file_encoding_protobuf_model_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InstructionOrder) String() string {
+func (x *KeyCertificate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InstructionOrder) ProtoMessage() {}
+func (*KeyCertificate) ProtoMessage() {}
 
-func (x *InstructionOrder) ProtoReflect() protoreflect.Message {
-	mi := &file_encoding_protobuf_model_proto_msgTypes[3]
+func (x *KeyCertificate) ProtoReflect() protoreflect.Message {
+	mi := &file_encoding_protobuf_model_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -317,69 +1268,92 @@ func (x *InstructionOrder) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InstructionOrder.ProtoReflect.Descriptor instead.
-func (*InstructionOrder) Descriptor() ([]byte, []int) {
-	return file_encoding_protobuf_model_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use KeyCertificate.ProtoReflect.Descriptor instead.
+func (*KeyCertificate) Descriptor() ([]byte, []int) {
+	return file_encoding_protobuf_model_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *InstructionOrder) GetTotal() string {
+func (x *KeyCertificate) GetPublicKey() string {
 	if x != nil {
-		return x.Total
+		return x.PublicKey
 	}
 	return ""
 }
 
-func (x *InstructionOrder) GetCoinCode() string {
+func (x *KeyCertificate) GetKid() string {
 	if x != nil {
-		return x.CoinCode
+		return x.Kid
 	}
 	return ""
 }
 
-func (x *InstructionOrder) GetDescription() string {
+func (x *KeyCertificate) GetKis() string {
 	if x != nil {
-		return x.Description
+		return x.Kis
 	}
 	return ""
 }
 
-func (x *InstructionOrder) GetMerchant() *InstructionMerchant {
+func (x *KeyCertificate) GetAllowedAssetIds() []string {
 	if x != nil {
-		return x.Merchant
+		return x.AllowedAssetIds
 	}
 	return nil
 }
 
-func (x *InstructionOrder) GetItems() []*InstructionItem {
+func (x *KeyCertificate) GetNotBefore() int64 {
 	if x != nil {
-		return x.Items
+		return x.NotBefore
 	}
-	return nil
+	return 0
 }
 
-type InstructionPayload struct {
+func (x *KeyCertificate) GetNotAfter() int64 {
+	if x != nil {
+		return x.NotAfter
+	}
+	return 0
+}
+
+func (x *KeyCertificate) GetIsCa() bool {
+	if x != nil {
+		return x.IsCa
+	}
+	return false
+}
+
+func (x *KeyCertificate) GetPathLength() int32 {
+	if x != nil {
+		return x.PathLength
+	}
+	return 0
+}
+
+type RevokedKey struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Payment       *PaymentInstruction    `protobuf:"bytes,1,opt,name=payment,proto3" json:"payment,omitempty"`
-	Order         *InstructionOrder      `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
+	Kid           string                 `protobuf:"bytes,1,opt,name=kid,proto3" json:"kid,omitempty"`
+	Kis           string                 `protobuf:"bytes,2,opt,name=kis,proto3" json:"kis,omitempty"`
+	RevokedAt     int64                  `protobuf:"varint,3,opt,name=revoked_at,proto3" json:"revoked_at,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *InstructionPayload) Reset() {
-	*x = InstructionPayload{}
-	mi := &file_encoding_protobuf_model_proto_msgTypes[4]
+func (x *RevokedKey) Reset() {
+	*x = RevokedKey{}
+	mi := &file_encoding_protobuf_model_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InstructionPayload) String() string {
+func (x *RevokedKey) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InstructionPayload) ProtoMessage() {}
+func (*RevokedKey) ProtoMessage() {}
 
-func (x *InstructionPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_encoding_protobuf_model_proto_msgTypes[4]
+func (x *RevokedKey) ProtoReflect() protoreflect.Message {
+	mi := &file_encoding_protobuf_model_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -390,49 +1364,108 @@ func (x *InstructionPayload) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InstructionPayload.ProtoReflect.Descriptor instead.
-func (*InstructionPayload) Descriptor() ([]byte, []int) {
-	return file_encoding_protobuf_model_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use RevokedKey.ProtoReflect.Descriptor instead.
+func (*RevokedKey) Descriptor() ([]byte, []int) {
+	return file_encoding_protobuf_model_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *InstructionPayload) GetPayment() *PaymentInstruction {
+func (x *RevokedKey) GetKid() string {
 	if x != nil {
-		return x.Payment
+		return x.Kid
 	}
-	return nil
+	return ""
 }
 
-func (x *InstructionPayload) GetOrder() *InstructionOrder {
+func (x *RevokedKey) GetKis() string {
 	if x != nil {
-		return x.Order
+		return x.Kis
+	}
+	return ""
+}
+
+func (x *RevokedKey) GetRevokedAt() int64 {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return 0
+}
+
+func (x *RevokedKey) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RevocationList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revocations   []*RevokedKey          `protobuf:"bytes,1,rep,name=revocations,proto3" json:"revocations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevocationList) Reset() {
+	*x = RevocationList{}
+	mi := &file_encoding_protobuf_model_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevocationList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevocationList) ProtoMessage() {}
+
+func (x *RevocationList) ProtoReflect() protoreflect.Message {
+	mi := &file_encoding_protobuf_model_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevocationList.ProtoReflect.Descriptor instead.
+func (*RevocationList) Descriptor() ([]byte, []int) {
+	return file_encoding_protobuf_model_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RevocationList) GetRevocations() []*RevokedKey {
+	if x != nil {
+		return x.Revocations
 	}
 	return nil
 }
 
-type UrlPayload struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Url            string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
-	PaymentOptions []string               `protobuf:"bytes,2,rep,name=payment_options,proto3" json:"payment_options,omitempty"`
-	Order          *InstructionOrder      `protobuf:"bytes,3,opt,name=order,proto3" json:"order,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type WebhookEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventType     string                 `protobuf:"bytes,1,opt,name=event_type,proto3" json:"event_type,omitempty"`
+	InstructionId string                 `protobuf:"bytes,2,opt,name=instruction_id,proto3" json:"instruction_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	OccurredAt    int64                  `protobuf:"varint,4,opt,name=occurred_at,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UrlPayload) Reset() {
-	*x = UrlPayload{}
-	mi := &file_encoding_protobuf_model_proto_msgTypes[5]
+func (x *WebhookEvent) Reset() {
+	*x = WebhookEvent{}
+	mi := &file_encoding_protobuf_model_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UrlPayload) String() string {
+func (x *WebhookEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UrlPayload) ProtoMessage() {}
+func (*WebhookEvent) ProtoMessage() {}
 
-func (x *UrlPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_encoding_protobuf_model_proto_msgTypes[5]
+func (x *WebhookEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_encoding_protobuf_model_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -443,30 +1476,37 @@ func (x *UrlPayload) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UrlPayload.ProtoReflect.Descriptor instead.
-func (*UrlPayload) Descriptor() ([]byte, []int) {
-	return file_encoding_protobuf_model_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use WebhookEvent.ProtoReflect.Descriptor instead.
+func (*WebhookEvent) Descriptor() ([]byte, []int) {
+	return file_encoding_protobuf_model_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *UrlPayload) GetUrl() string {
+func (x *WebhookEvent) GetEventType() string {
 	if x != nil {
-		return x.Url
+		return x.EventType
 	}
 	return ""
 }
 
-func (x *UrlPayload) GetPaymentOptions() []string {
+func (x *WebhookEvent) GetInstructionId() string {
 	if x != nil {
-		return x.PaymentOptions
+		return x.InstructionId
 	}
-	return nil
+	return ""
 }
 
-func (x *UrlPayload) GetOrder() *InstructionOrder {
+func (x *WebhookEvent) GetStatus() string {
 	if x != nil {
-		return x.Order
+		return x.Status
 	}
-	return nil
+	return ""
+}
+
+func (x *WebhookEvent) GetOccurredAt() int64 {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return 0
 }
 
 type PasetoTokenData struct {
@@ -485,6 +1525,16 @@ type PasetoTokenData struct {
 	//
 	//	*PasetoTokenData_InstructionPayload
 	//	*PasetoTokenData_UrlPayload
+	//	*PasetoTokenData_MultiAssetPayload
+	//	*PasetoTokenData_ReceiptPayload
+	//	*PasetoTokenData_RefundPayload
+	//	*PasetoTokenData_MandatePayload
+	//	*PasetoTokenData_MandateAcceptance
+	//	*PasetoTokenData_CoSignedPayload
+	//	*PasetoTokenData_KeyCertificate
+	//	*PasetoTokenData_RevocationList
+	//	*PasetoTokenData_WebhookEvent
+	//	*PasetoTokenData_SealedPayload
 	Data          isPasetoTokenData_Data `protobuf_oneof:"data"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -492,7 +1542,7 @@ type PasetoTokenData struct {
 
 func (x *PasetoTokenData) Reset() {
 	*x = PasetoTokenData{}
-	mi := &file_encoding_protobuf_model_proto_msgTypes[6]
+	mi := &file_encoding_protobuf_model_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -504,7 +1554,7 @@ func (x *PasetoTokenData) String() string {
 func (*PasetoTokenData) ProtoMessage() {}
 
 func (x *PasetoTokenData) ProtoReflect() protoreflect.Message {
-	mi := &file_encoding_protobuf_model_proto_msgTypes[6]
+	mi := &file_encoding_protobuf_model_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -517,7 +1567,7 @@ func (x *PasetoTokenData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PasetoTokenData.ProtoReflect.Descriptor instead.
 func (*PasetoTokenData) Descriptor() ([]byte, []int) {
-	return file_encoding_protobuf_model_proto_rawDescGZIP(), []int{6}
+	return file_encoding_protobuf_model_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *PasetoTokenData) GetIss() string {
@@ -615,6 +1665,96 @@ func (x *PasetoTokenData) GetUrlPayload() *UrlPayload {
 	return nil
 }
 
+func (x *PasetoTokenData) GetMultiAssetPayload() *MultiAssetPayload {
+	if x != nil {
+		if x, ok := x.Data.(*PasetoTokenData_MultiAssetPayload); ok {
+			return x.MultiAssetPayload
+		}
+	}
+	return nil
+}
+
+func (x *PasetoTokenData) GetReceiptPayload() *ReceiptPayload {
+	if x != nil {
+		if x, ok := x.Data.(*PasetoTokenData_ReceiptPayload); ok {
+			return x.ReceiptPayload
+		}
+	}
+	return nil
+}
+
+func (x *PasetoTokenData) GetRefundPayload() *RefundPayload {
+	if x != nil {
+		if x, ok := x.Data.(*PasetoTokenData_RefundPayload); ok {
+			return x.RefundPayload
+		}
+	}
+	return nil
+}
+
+func (x *PasetoTokenData) GetMandatePayload() *MandatePayload {
+	if x != nil {
+		if x, ok := x.Data.(*PasetoTokenData_MandatePayload); ok {
+			return x.MandatePayload
+		}
+	}
+	return nil
+}
+
+func (x *PasetoTokenData) GetMandateAcceptance() *MandateAcceptance {
+	if x != nil {
+		if x, ok := x.Data.(*PasetoTokenData_MandateAcceptance); ok {
+			return x.MandateAcceptance
+		}
+	}
+	return nil
+}
+
+func (x *PasetoTokenData) GetCoSignedPayload() *CoSignedPayload {
+	if x != nil {
+		if x, ok := x.Data.(*PasetoTokenData_CoSignedPayload); ok {
+			return x.CoSignedPayload
+		}
+	}
+	return nil
+}
+
+func (x *PasetoTokenData) GetKeyCertificate() *KeyCertificate {
+	if x != nil {
+		if x, ok := x.Data.(*PasetoTokenData_KeyCertificate); ok {
+			return x.KeyCertificate
+		}
+	}
+	return nil
+}
+
+func (x *PasetoTokenData) GetRevocationList() *RevocationList {
+	if x != nil {
+		if x, ok := x.Data.(*PasetoTokenData_RevocationList); ok {
+			return x.RevocationList
+		}
+	}
+	return nil
+}
+
+func (x *PasetoTokenData) GetWebhookEvent() *WebhookEvent {
+	if x != nil {
+		if x, ok := x.Data.(*PasetoTokenData_WebhookEvent); ok {
+			return x.WebhookEvent
+		}
+	}
+	return nil
+}
+
+func (x *PasetoTokenData) GetSealedPayload() *SealedPayload {
+	if x != nil {
+		if x, ok := x.Data.(*PasetoTokenData_SealedPayload); ok {
+			return x.SealedPayload
+		}
+	}
+	return nil
+}
+
 type isPasetoTokenData_Data interface {
 	isPasetoTokenData_Data()
 }
@@ -627,10 +1767,70 @@ type PasetoTokenData_UrlPayload struct {
 	UrlPayload *UrlPayload `protobuf:"bytes,12,opt,name=url_payload,json=data,proto3,oneof"`
 }
 
+type PasetoTokenData_MultiAssetPayload struct {
+	MultiAssetPayload *MultiAssetPayload `protobuf:"bytes,13,opt,name=multi_asset_payload,json=data,proto3,oneof"`
+}
+
+type PasetoTokenData_ReceiptPayload struct {
+	ReceiptPayload *ReceiptPayload `protobuf:"bytes,14,opt,name=receipt_payload,json=data,proto3,oneof"`
+}
+
+type PasetoTokenData_RefundPayload struct {
+	RefundPayload *RefundPayload `protobuf:"bytes,15,opt,name=refund_payload,json=data,proto3,oneof"`
+}
+
+type PasetoTokenData_MandatePayload struct {
+	MandatePayload *MandatePayload `protobuf:"bytes,16,opt,name=mandate_payload,json=data,proto3,oneof"`
+}
+
+type PasetoTokenData_MandateAcceptance struct {
+	MandateAcceptance *MandateAcceptance `protobuf:"bytes,17,opt,name=mandate_acceptance,json=data,proto3,oneof"`
+}
+
+type PasetoTokenData_CoSignedPayload struct {
+	CoSignedPayload *CoSignedPayload `protobuf:"bytes,18,opt,name=co_signed_payload,json=data,proto3,oneof"`
+}
+
+type PasetoTokenData_KeyCertificate struct {
+	KeyCertificate *KeyCertificate `protobuf:"bytes,19,opt,name=key_certificate,json=data,proto3,oneof"`
+}
+
+type PasetoTokenData_RevocationList struct {
+	RevocationList *RevocationList `protobuf:"bytes,20,opt,name=revocation_list,json=data,proto3,oneof"`
+}
+
+type PasetoTokenData_WebhookEvent struct {
+	WebhookEvent *WebhookEvent `protobuf:"bytes,21,opt,name=webhook_event,json=data,proto3,oneof"`
+}
+
+type PasetoTokenData_SealedPayload struct {
+	SealedPayload *SealedPayload `protobuf:"bytes,22,opt,name=sealed_payload,json=data,proto3,oneof"`
+}
+
 func (*PasetoTokenData_InstructionPayload) isPasetoTokenData_Data() {}
 
 func (*PasetoTokenData_UrlPayload) isPasetoTokenData_Data() {}
 
+func (*PasetoTokenData_MultiAssetPayload) isPasetoTokenData_Data() {}
+
+func (*PasetoTokenData_ReceiptPayload) isPasetoTokenData_Data() {}
+
+func (*PasetoTokenData_RefundPayload) isPasetoTokenData_Data() {}
+
+func (*PasetoTokenData_MandatePayload) isPasetoTokenData_Data() {}
+
+func (*PasetoTokenData_MandateAcceptance) isPasetoTokenData_Data() {}
+
+func (*PasetoTokenData_CoSignedPayload) isPasetoTokenData_Data() {}
+
+func (*PasetoTokenData_KeyCertificate) isPasetoTokenData_Data() {}
+
+func (*PasetoTokenData_RevocationList) isPasetoTokenData_Data() {}
+
+func (*PasetoTokenData_WebhookEvent) isPasetoTokenData_Data() {}
+
+func (*PasetoTokenData_SealedPayload) isPasetoTokenData_Data() {}
+
 var File_encoding_protobuf_model_proto protoreflect.FileDescriptor
 
 var file_encoding_protobuf_model_proto_rawDesc = string([]byte{
@@ -663,7 +1863,7 @@ var file_encoding_protobuf_model_proto_rawDesc = string([]byte{
 	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x78, 0x5f, 0x69, 0x64, 0x12, 0x14,
 	0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69,
 	0x6d, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x63, 0x63, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x6d, 0x63, 0x63, 0x22, 0xa5, 0x01, 0x0a, 0x0f, 0x49, 0x6e, 0x73, 0x74, 0x72,
+	0x09, 0x52, 0x03, 0x6d, 0x63, 0x63, 0x22, 0xe1, 0x01, 0x0a, 0x0f, 0x49, 0x6e, 0x73, 0x74, 0x72,
 	0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65,
 	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06,
@@ -673,29 +1873,68 @@ var file_encoding_protobuf_model_proto_rawDesc = string([]byte{
 	0x64, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65,
 	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x70, 0x72, 0x69,
 	0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x22, 0xd4,
-	0x01, 0x0a, 0x10, 0x49, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x72,
-	0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x69,
-	0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f,
-	0x69, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
-	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
-	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x08, 0x6d, 0x65, 0x72,
-	0x63, 0x68, 0x61, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x73, 0x6b, 0x75, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x6b, 0x75,
+	0x12, 0x28, 0x0a, 0x0f, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x6f, 0x66, 0x5f, 0x6d, 0x65, 0x61, 0x73,
+	0x75, 0x72, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x75, 0x6e, 0x69, 0x74, 0x5f,
+	0x6f, 0x66, 0x5f, 0x6d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x22, 0x74, 0x0a, 0x0e, 0x49, 0x6e,
+	0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x61, 0x78, 0x12, 0x1a, 0x0a, 0x08,
+	0x74, 0x61, 0x78, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x74, 0x61, 0x78, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64,
+	0x22, 0x4f, 0x0a, 0x13, 0x49, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44,
+	0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0xdd, 0x02, 0x0a, 0x10, 0x49, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x1c, 0x0a, 0x09,
+	0x63, 0x6f, 0x69, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x63, 0x6f, 0x69, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x08,
+	0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x52, 0x08, 0x6d,
+	0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x12, 0x2f, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x74, 0x65,
+	0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x2e, 0x0a, 0x05, 0x74, 0x61, 0x78, 0x65,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x61,
+	0x78, 0x52, 0x05, 0x74, 0x61, 0x78, 0x65, 0x73, 0x12, 0x3b, 0x0a, 0x09, 0x64, 0x69, 0x73, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x4d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x52, 0x08, 0x6d, 0x65, 0x72, 0x63,
-	0x68, 0x61, 0x6e, 0x74, 0x12, 0x2f, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x05, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49,
-	0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05,
-	0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x7e, 0x0a, 0x12, 0x49, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x36, 0x0a, 0x07, 0x70,
-	0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x49,
-	0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6d,
-	0x65, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e,
-	0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x05,
-	0x6f, 0x72, 0x64, 0x65, 0x72, 0x22, 0x7a, 0x0a, 0x0a, 0x55, 0x72, 0x6c, 0x50, 0x61, 0x79, 0x6c,
+	0x6f, 0x6e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x09, 0x64, 0x69, 0x73, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x68, 0x69, 0x70, 0x70, 0x69, 0x6e,
+	0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x68, 0x69, 0x70, 0x70, 0x69, 0x6e,
+	0x67, 0x22, 0xad, 0x01, 0x0a, 0x11, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x61,
+	0x74, 0x65, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x62, 0x61, 0x73, 0x65, 0x5f,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x62, 0x61, 0x73, 0x65, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x26, 0x0a,
+	0x0e, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x5f, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61,
+	0x74, 0x22, 0xb1, 0x01, 0x0a, 0x12, 0x49, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x36, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6d,
+	0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x73, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x30, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x72,
+	0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x05, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x12, 0x31, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x78, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x61, 0x74, 0x65, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x05,
+	0x71, 0x75, 0x6f, 0x74, 0x65, 0x22, 0x7a, 0x0a, 0x0a, 0x55, 0x72, 0x6c, 0x50, 0x61, 0x79, 0x6c,
 	0x6f, 0x61, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x28, 0x0a, 0x0f, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74,
 	0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f,
@@ -703,29 +1942,181 @@ var file_encoding_protobuf_model_proto_rawDesc = string([]byte{
 	0x30, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x72, 0x75,
 	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65,
-	0x72, 0x22, 0xc3, 0x02, 0x0a, 0x0f, 0x50, 0x61, 0x73, 0x65, 0x74, 0x6f, 0x54, 0x6f, 0x6b, 0x65,
-	0x6e, 0x44, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x69, 0x73, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x75, 0x62, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x75, 0x62, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x75, 0x64,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x61, 0x75, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x65,
-	0x78, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x65, 0x78, 0x70, 0x12, 0x10, 0x0a,
-	0x03, 0x6e, 0x62, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6e, 0x62, 0x66, 0x12,
-	0x10, 0x0a, 0x03, 0x69, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x69, 0x61,
-	0x74, 0x12, 0x10, 0x0a, 0x03, 0x6a, 0x74, 0x69, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x6a, 0x74, 0x69, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x70, 0x18, 0x09, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x69, 0x73, 0x18, 0x0a,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x69, 0x73, 0x12, 0x41, 0x0a, 0x13, 0x69, 0x6e, 0x73,
-	0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
-	0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x79,
-	0x6c, 0x6f, 0x61, 0x64, 0x48, 0x00, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x31, 0x0a, 0x0b,
-	0x75, 0x72, 0x6c, 0x5f, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x55, 0x72, 0x6c,
-	0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x00, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x42,
-	0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x42, 0x13, 0x5a, 0x11, 0x65, 0x6e, 0x63, 0x6f, 0x64,
-	0x69, 0x6e, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x72, 0x22, 0x7f, 0x0a, 0x11, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x41, 0x73, 0x73, 0x65, 0x74, 0x50,
+	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x38, 0x0a, 0x08, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x73, 0x74, 0x72,
+	0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x30, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x72,
+	0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x05, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x22, 0x9e, 0x02, 0x0a, 0x0e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x50, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x2a, 0x0a, 0x10, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x10, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x61, 0x73,
+	0x68, 0x12, 0x26, 0x0a, 0x0e, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x6e, 0x73, 0x74, 0x72,
+	0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x69, 0x6e, 0x73,
+	0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6a, 0x74, 0x69, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0f, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x6a, 0x74, 0x69, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x78, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x78, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x12, 0x18, 0x0a,
+	0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x28, 0x0a, 0x0f, 0x75, 0x6e, 0x69, 0x71, 0x75,
+	0x65, 0x5f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0f, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x5f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x69,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x69,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x70, 0x61, 0x69, 0x64,
+	0x5f, 0x61, 0x74, 0x22, 0xeb, 0x01, 0x0a, 0x0d, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x50, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61,
+	0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x72, 0x69, 0x67,
+	0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x6f, 0x72, 0x69, 0x67, 0x69,
+	0x6e, 0x61, 0x6c, 0x5f, 0x6a, 0x74, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6f,
+	0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x6a, 0x74, 0x69, 0x12, 0x28, 0x0a, 0x0f, 0x75,
+	0x6e, 0x69, 0x71, 0x75, 0x65, 0x5f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x5f, 0x61, 0x73, 0x73,
+	0x65, 0x74, 0x5f, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x5f, 0x74, 0x61, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x22, 0xd5, 0x02, 0x0a, 0x0e, 0x4d, 0x61, 0x6e, 0x64, 0x61, 0x74, 0x65, 0x50, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x5f, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x75,
+	0x6e, 0x69, 0x71, 0x75, 0x65, 0x5f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x12, 0x1e,
+	0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x26, 0x0a, 0x0e, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x61, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x61, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x65, 0x6e, 0x64, 0x5f, 0x61, 0x74, 0x12, 0x39, 0x0a, 0x08,
+	0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x52, 0x08, 0x6d,
+	0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x8f, 0x01, 0x0a, 0x11, 0x4d, 0x61,
+	0x6e, 0x64, 0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12,
+	0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x6e, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x6e, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x68,
+	0x61, 0x73, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x6e, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x61, 0x6e, 0x64, 0x61, 0x74, 0x65,
+	0x5f, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x79, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x70, 0x61, 0x79, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x63, 0x63,
+	0x65, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
+	0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x22, 0x39, 0x0a, 0x0f, 0x43,
+	0x6f, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x26,
+	0x0a, 0x0e, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74,
+	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x33, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x6c, 0x65, 0x64,
+	0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x69, 0x67, 0x6e, 0x65,
+	0x64, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73,
+	0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xf8, 0x01, 0x0a, 0x0e,
+	0x4b, 0x65, 0x79, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x1e,
+	0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x69, 0x64,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x69, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x69, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x61, 0x73,
+	0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x61,
+	0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x73,
+	0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x6f, 0x74, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6e, 0x6f, 0x74, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x6f, 0x74, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x6e, 0x6f, 0x74, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x12, 0x14,
+	0x0a, 0x05, 0x69, 0x73, 0x5f, 0x63, 0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69,
+	0x73, 0x5f, 0x63, 0x61, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x6c, 0x65, 0x6e,
+	0x67, 0x74, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x70, 0x61, 0x74, 0x68, 0x5f,
+	0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0x68, 0x0a, 0x0a, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65,
+	0x64, 0x4b, 0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x69, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x69, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x76, 0x6f,
+	0x6b, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x72, 0x65,
+	0x76, 0x6f, 0x6b, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x22, 0x48, 0x0a, 0x0e, 0x52, 0x65, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69,
+	0x73, 0x74, 0x12, 0x36, 0x0a, 0x0b, 0x72, 0x65, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x52, 0x0b, 0x72,
+	0x65, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x90, 0x01, 0x0a, 0x0c, 0x57,
+	0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x69,
+	0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x6f,
+	0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0b, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x22, 0x99, 0x07,
+	0x0a, 0x0f, 0x50, 0x61, 0x73, 0x65, 0x74, 0x6f, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x44, 0x61, 0x74,
+	0x61, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x69, 0x73, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x75, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x73, 0x75, 0x62, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x75, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x61, 0x75, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x78, 0x70, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x65, 0x78, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x6e, 0x62, 0x66,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6e, 0x62, 0x66, 0x12, 0x10, 0x0a, 0x03, 0x69,
+	0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x69, 0x61, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x6a, 0x74, 0x69, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6a, 0x74, 0x69, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x69,
+	0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x69, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x69, 0x73, 0x12, 0x41, 0x0a, 0x13, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e,
+	0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x48, 0x00, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x31, 0x0a, 0x0b, 0x75, 0x72, 0x6c, 0x5f,
+	0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x55, 0x72, 0x6c, 0x50, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x48, 0x00, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x40, 0x0a, 0x13, 0x6d,
+	0x75, 0x6c, 0x74, 0x69, 0x5f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x70, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x41, 0x73, 0x73, 0x65, 0x74, 0x50, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x00, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x39, 0x0a,
+	0x0f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x5f, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x48, 0x00, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x37, 0x0a, 0x0e, 0x72, 0x65, 0x66, 0x75,
+	0x6e, 0x64, 0x5f, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x52, 0x65, 0x66, 0x75,
+	0x6e, 0x64, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x00, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x39, 0x0a, 0x0f, 0x6d, 0x61, 0x6e, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x61, 0x6e, 0x64, 0x61, 0x74, 0x65, 0x50, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x48, 0x00, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x3f, 0x0a, 0x12,
+	0x6d, 0x61, 0x6e, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x4d, 0x61, 0x6e, 0x64, 0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x65, 0x70,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x48, 0x00, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x3c, 0x0a,
+	0x11, 0x63, 0x6f, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x43, 0x6f, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x50, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x48, 0x00, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x39, 0x0a, 0x0f, 0x6b,
+	0x65, 0x79, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x13,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x4b, 0x65, 0x79, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x48, 0x00,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x39, 0x0a, 0x0f, 0x72, 0x65, 0x76, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x52, 0x65, 0x76, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x35, 0x0a, 0x0d, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x48, 0x00, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x37, 0x0a, 0x0e, 0x73, 0x65, 0x61, 0x6c,
+	0x65, 0x64, 0x5f, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x65, 0x61, 0x6c,
+	0x65, 0x64, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x00, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x42, 0x13, 0x5a, 0x11, 0x65, 0x6e, 0x63,
+	0x6f, 0x64, 0x69, 0x6e, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -740,29 +2131,60 @@ func file_encoding_protobuf_model_proto_rawDescGZIP() []byte {
 	return file_encoding_protobuf_model_proto_rawDescData
 }
 
-var file_encoding_protobuf_model_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_encoding_protobuf_model_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_encoding_protobuf_model_proto_goTypes = []any{
 	(*PaymentInstruction)(nil),  // 0: protobuf.PaymentInstruction
 	(*InstructionMerchant)(nil), // 1: protobuf.InstructionMerchant
 	(*InstructionItem)(nil),     // 2: protobuf.InstructionItem
-	(*InstructionOrder)(nil),    // 3: protobuf.InstructionOrder
-	(*InstructionPayload)(nil),  // 4: protobuf.InstructionPayload
-	(*UrlPayload)(nil),          // 5: protobuf.UrlPayload
-	(*PasetoTokenData)(nil),     // 6: protobuf.PasetoTokenData
+	(*InstructionTax)(nil),      // 3: protobuf.InstructionTax
+	(*InstructionDiscount)(nil), // 4: protobuf.InstructionDiscount
+	(*InstructionOrder)(nil),    // 5: protobuf.InstructionOrder
+	(*ExchangeRateQuote)(nil),   // 6: protobuf.ExchangeRateQuote
+	(*InstructionPayload)(nil),  // 7: protobuf.InstructionPayload
+	(*UrlPayload)(nil),          // 8: protobuf.UrlPayload
+	(*MultiAssetPayload)(nil),   // 9: protobuf.MultiAssetPayload
+	(*ReceiptPayload)(nil),      // 10: protobuf.ReceiptPayload
+	(*RefundPayload)(nil),       // 11: protobuf.RefundPayload
+	(*MandatePayload)(nil),      // 12: protobuf.MandatePayload
+	(*MandateAcceptance)(nil),   // 13: protobuf.MandateAcceptance
+	(*CoSignedPayload)(nil),     // 14: protobuf.CoSignedPayload
+	(*SealedPayload)(nil),       // 15: protobuf.SealedPayload
+	(*KeyCertificate)(nil),      // 16: protobuf.KeyCertificate
+	(*RevokedKey)(nil),          // 17: protobuf.RevokedKey
+	(*RevocationList)(nil),      // 18: protobuf.RevocationList
+	(*WebhookEvent)(nil),        // 19: protobuf.WebhookEvent
+	(*PasetoTokenData)(nil),     // 20: protobuf.PasetoTokenData
 }
 var file_encoding_protobuf_model_proto_depIdxs = []int32{
-	1, // 0: protobuf.InstructionOrder.merchant:type_name -> protobuf.InstructionMerchant
-	2, // 1: protobuf.InstructionOrder.items:type_name -> protobuf.InstructionItem
-	0, // 2: protobuf.InstructionPayload.payment:type_name -> protobuf.PaymentInstruction
-	3, // 3: protobuf.InstructionPayload.order:type_name -> protobuf.InstructionOrder
-	3, // 4: protobuf.UrlPayload.order:type_name -> protobuf.InstructionOrder
-	4, // 5: protobuf.PasetoTokenData.instruction_payload:type_name -> protobuf.InstructionPayload
-	5, // 6: protobuf.PasetoTokenData.url_payload:type_name -> protobuf.UrlPayload
-	7, // [7:7] is the sub-list for method output_type
-	7, // [7:7] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	1,  // 0: protobuf.InstructionOrder.merchant:type_name -> protobuf.InstructionMerchant
+	2,  // 1: protobuf.InstructionOrder.items:type_name -> protobuf.InstructionItem
+	3,  // 2: protobuf.InstructionOrder.taxes:type_name -> protobuf.InstructionTax
+	4,  // 3: protobuf.InstructionOrder.discounts:type_name -> protobuf.InstructionDiscount
+	0,  // 4: protobuf.InstructionPayload.payment:type_name -> protobuf.PaymentInstruction
+	5,  // 5: protobuf.InstructionPayload.order:type_name -> protobuf.InstructionOrder
+	6,  // 6: protobuf.InstructionPayload.quote:type_name -> protobuf.ExchangeRateQuote
+	5,  // 7: protobuf.UrlPayload.order:type_name -> protobuf.InstructionOrder
+	0,  // 8: protobuf.MultiAssetPayload.payments:type_name -> protobuf.PaymentInstruction
+	5,  // 9: protobuf.MultiAssetPayload.order:type_name -> protobuf.InstructionOrder
+	1,  // 10: protobuf.MandatePayload.merchant:type_name -> protobuf.InstructionMerchant
+	17, // 11: protobuf.RevocationList.revocations:type_name -> protobuf.RevokedKey
+	7,  // 12: protobuf.PasetoTokenData.instruction_payload:type_name -> protobuf.InstructionPayload
+	8,  // 13: protobuf.PasetoTokenData.url_payload:type_name -> protobuf.UrlPayload
+	9,  // 14: protobuf.PasetoTokenData.multi_asset_payload:type_name -> protobuf.MultiAssetPayload
+	10, // 15: protobuf.PasetoTokenData.receipt_payload:type_name -> protobuf.ReceiptPayload
+	11, // 16: protobuf.PasetoTokenData.refund_payload:type_name -> protobuf.RefundPayload
+	12, // 17: protobuf.PasetoTokenData.mandate_payload:type_name -> protobuf.MandatePayload
+	13, // 18: protobuf.PasetoTokenData.mandate_acceptance:type_name -> protobuf.MandateAcceptance
+	14, // 19: protobuf.PasetoTokenData.co_signed_payload:type_name -> protobuf.CoSignedPayload
+	16, // 20: protobuf.PasetoTokenData.key_certificate:type_name -> protobuf.KeyCertificate
+	18, // 21: protobuf.PasetoTokenData.revocation_list:type_name -> protobuf.RevocationList
+	19, // 22: protobuf.PasetoTokenData.webhook_event:type_name -> protobuf.WebhookEvent
+	15, // 23: protobuf.PasetoTokenData.sealed_payload:type_name -> protobuf.SealedPayload
+	24, // [24:24] is the sub-list for method output_type
+	24, // [24:24] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_encoding_protobuf_model_proto_init() }
@@ -770,9 +2192,19 @@ func file_encoding_protobuf_model_proto_init() {
 	if File_encoding_protobuf_model_proto != nil {
 		return
 	}
-	file_encoding_protobuf_model_proto_msgTypes[6].OneofWrappers = []any{
+	file_encoding_protobuf_model_proto_msgTypes[20].OneofWrappers = []any{
 		(*PasetoTokenData_InstructionPayload)(nil),
 		(*PasetoTokenData_UrlPayload)(nil),
+		(*PasetoTokenData_MultiAssetPayload)(nil),
+		(*PasetoTokenData_ReceiptPayload)(nil),
+		(*PasetoTokenData_RefundPayload)(nil),
+		(*PasetoTokenData_MandatePayload)(nil),
+		(*PasetoTokenData_MandateAcceptance)(nil),
+		(*PasetoTokenData_CoSignedPayload)(nil),
+		(*PasetoTokenData_KeyCertificate)(nil),
+		(*PasetoTokenData_RevocationList)(nil),
+		(*PasetoTokenData_WebhookEvent)(nil),
+		(*PasetoTokenData_SealedPayload)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -780,7 +2212,7 @@ func file_encoding_protobuf_model_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_encoding_protobuf_model_proto_rawDesc), len(file_encoding_protobuf_model_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
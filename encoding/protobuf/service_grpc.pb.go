@@ -0,0 +1,235 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.12.4
+// source: encoding/protobuf/service.proto
+
+package protobuf
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	NaspipService_CreateInstruction_FullMethodName = "/protobuf.NaspipService/CreateInstruction"
+	NaspipService_CreateUrlPayload_FullMethodName  = "/protobuf.NaspipService/CreateUrlPayload"
+	NaspipService_Read_FullMethodName              = "/protobuf.NaspipService/Read"
+	NaspipService_ResolveKey_FullMethodName        = "/protobuf.NaspipService/ResolveKey"
+)
+
+// NaspipServiceClient is the client API for NaspipService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NaspipServiceClient interface {
+	CreateInstruction(ctx context.Context, in *CreateInstructionRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error)
+	CreateUrlPayload(ctx context.Context, in *CreateUrlPayloadRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error)
+	Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error)
+	ResolveKey(ctx context.Context, in *ResolveKeyRequest, opts ...grpc.CallOption) (*ResolveKeyResponse, error)
+}
+
+type naspipServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNaspipServiceClient(cc grpc.ClientConnInterface) NaspipServiceClient {
+	return &naspipServiceClient{cc}
+}
+
+func (c *naspipServiceClient) CreateInstruction(ctx context.Context, in *CreateInstructionRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTokenResponse)
+	err := c.cc.Invoke(ctx, NaspipService_CreateInstruction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *naspipServiceClient) CreateUrlPayload(ctx context.Context, in *CreateUrlPayloadRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTokenResponse)
+	err := c.cc.Invoke(ctx, NaspipService_CreateUrlPayload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *naspipServiceClient) Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReadResponse)
+	err := c.cc.Invoke(ctx, NaspipService_Read_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *naspipServiceClient) ResolveKey(ctx context.Context, in *ResolveKeyRequest, opts ...grpc.CallOption) (*ResolveKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveKeyResponse)
+	err := c.cc.Invoke(ctx, NaspipService_ResolveKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NaspipServiceServer is the server API for NaspipService service.
+// All implementations must embed UnimplementedNaspipServiceServer
+// for forward compatibility.
+type NaspipServiceServer interface {
+	CreateInstruction(context.Context, *CreateInstructionRequest) (*CreateTokenResponse, error)
+	CreateUrlPayload(context.Context, *CreateUrlPayloadRequest) (*CreateTokenResponse, error)
+	Read(context.Context, *ReadRequest) (*ReadResponse, error)
+	ResolveKey(context.Context, *ResolveKeyRequest) (*ResolveKeyResponse, error)
+	mustEmbedUnimplementedNaspipServiceServer()
+}
+
+// UnimplementedNaspipServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedNaspipServiceServer struct{}
+
+func (UnimplementedNaspipServiceServer) CreateInstruction(context.Context, *CreateInstructionRequest) (*CreateTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInstruction not implemented")
+}
+func (UnimplementedNaspipServiceServer) CreateUrlPayload(context.Context, *CreateUrlPayloadRequest) (*CreateTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUrlPayload not implemented")
+}
+func (UnimplementedNaspipServiceServer) Read(context.Context, *ReadRequest) (*ReadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Read not implemented")
+}
+func (UnimplementedNaspipServiceServer) ResolveKey(context.Context, *ResolveKeyRequest) (*ResolveKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveKey not implemented")
+}
+func (UnimplementedNaspipServiceServer) mustEmbedUnimplementedNaspipServiceServer() {}
+func (UnimplementedNaspipServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeNaspipServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NaspipServiceServer will
+// result in compilation errors.
+type UnsafeNaspipServiceServer interface {
+	mustEmbedUnimplementedNaspipServiceServer()
+}
+
+func RegisterNaspipServiceServer(s grpc.ServiceRegistrar, srv NaspipServiceServer) {
+	// If the following call pancis, it indicates UnimplementedNaspipServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&NaspipService_ServiceDesc, srv)
+}
+
+func _NaspipService_CreateInstruction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInstructionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NaspipServiceServer).CreateInstruction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NaspipService_CreateInstruction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NaspipServiceServer).CreateInstruction(ctx, req.(*CreateInstructionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NaspipService_CreateUrlPayload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUrlPayloadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NaspipServiceServer).CreateUrlPayload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NaspipService_CreateUrlPayload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NaspipServiceServer).CreateUrlPayload(ctx, req.(*CreateUrlPayloadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NaspipService_Read_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NaspipServiceServer).Read(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NaspipService_Read_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NaspipServiceServer).Read(ctx, req.(*ReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NaspipService_ResolveKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NaspipServiceServer).ResolveKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NaspipService_ResolveKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NaspipServiceServer).ResolveKey(ctx, req.(*ResolveKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// NaspipService_ServiceDesc is the grpc.ServiceDesc for NaspipService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NaspipService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "protobuf.NaspipService",
+	HandlerType: (*NaspipServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateInstruction",
+			Handler:    _NaspipService_CreateInstruction_Handler,
+		},
+		{
+			MethodName: "CreateUrlPayload",
+			Handler:    _NaspipService_CreateUrlPayload_Handler,
+		},
+		{
+			MethodName: "Read",
+			Handler:    _NaspipService_Read_Handler,
+		},
+		{
+			MethodName: "ResolveKey",
+			Handler:    _NaspipService_ResolveKey_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "encoding/protobuf/service.proto",
+}
@@ -0,0 +1,33 @@
+// Package httpapi holds the JSON response helpers and the error envelope shared by the HTTP
+// handlers of the server, urlpayload and webhook packages, so that every endpoint reports
+// errors with the same body.
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ErrorResponse is the body of error responses.
+type ErrorResponse struct {
+	Error ErrorDetail `json:"error"` // Error details
+}
+
+// ErrorDetail describes an error with a stable code and a human readable message.
+type ErrorDetail struct {
+	Code    string `json:"code"`    // Stable error code, see the Error constants of the serving package
+	Message string `json:"message"` // Human readable description
+}
+
+// WriteJSON writes a JSON response with the given status code.
+func WriteJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// WriteError writes an ErrorResponse with the given status code.
+func WriteError(w http.ResponseWriter, status int, code string, message string) {
+	WriteJSON(w, status, ErrorResponse{Error: ErrorDetail{Code: code, Message: message}})
+}
@@ -0,0 +1,26 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Should write the error envelope as JSON with the status code
+func TestWriteError(t *testing.T) {
+	assert := assert.New(t)
+
+	recorder := httptest.NewRecorder()
+
+	WriteError(recorder, http.StatusConflict, "replayed_event", "event already received")
+
+	var response ErrorResponse
+
+	assert.Equal(http.StatusConflict, recorder.Code)
+	assert.Equal("application/json", recorder.Header().Get("Content-Type"))
+	assert.Nil(json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(ErrorDetail{Code: "replayed_event", Message: "event already received"}, response.Error)
+}
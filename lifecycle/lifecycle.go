@@ -0,0 +1,102 @@
+// Package lifecycle tracks issued payment instructions through their states:
+//
+//	created → presented → paid / partially_paid → expired / cancelled
+//
+// Instructions are keyed on PaymentInstruction.Id and indexed by the token identifier (jti).
+// Their expiry is the earliest of the payment ExpiresAt and the token exp claim. A Tracker
+// applies the transitions on an InstructionStore, which can be the in-memory MemoryStore or
+// the SQL backed SQLiteStore, and emits an Event for each transition.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// State is the lifecycle state of an instruction.
+type State string
+
+const (
+	StateCreated       State = "created"        // The instruction token was issued
+	StatePresented     State = "presented"      // The instruction was shown to the payer
+	StatePartiallyPaid State = "partially_paid" // Part of the requested amount was received
+	StatePaid          State = "paid"           // The requested amount was received
+	StateExpired       State = "expired"        // The instruction expired before being paid
+	StateCancelled     State = "cancelled"      // The merchant cancelled the instruction
+)
+
+// transitions lists the states reachable from each state. Paid, expired and cancelled
+// instructions are final.
+var transitions = map[State][]State{
+	StateCreated:       {StatePresented, StatePartiallyPaid, StatePaid, StateExpired, StateCancelled},
+	StatePresented:     {StatePartiallyPaid, StatePaid, StateExpired, StateCancelled},
+	StatePartiallyPaid: {StatePartiallyPaid, StatePaid, StateExpired, StateCancelled},
+}
+
+// pendingStates are the states of instructions that can still expire.
+var pendingStates = []State{StateCreated, StatePresented, StatePartiallyPaid}
+
+// Errors returned by the stores and the Tracker.
+var (
+	ErrNotFound          = errors.New("instruction not found")
+	ErrExists            = errors.New("instruction already exists")
+	ErrInvalidTransition = errors.New("invalid state transition")
+	ErrStateConflict     = errors.New("instruction state changed concurrently")
+	ErrExpired           = errors.New("instruction expired")
+)
+
+// CanTransition reports whether an instruction in state from may move to state to.
+func CanTransition(from State, to State) bool {
+	return slices.Contains(transitions[from], to)
+}
+
+// IsFinal reports whether no transition leaves the state.
+func (s State) IsFinal() bool {
+	return len(transitions[s]) == 0
+}
+
+// Instruction is the lifecycle record of an issued payment instruction.
+type Instruction struct {
+	Id        string `json:"id"`         // Payment identifier (PaymentInstruction.Id)
+	Jti       string `json:"jti"`        // Token identifier
+	KeyIssuer string `json:"kis"`        // Issuer of the key that signed the token
+	Token     string `json:"token"`      // NASPIP token of the instruction
+	State     State  `json:"state"`      // Current state
+	ExpiresAt int64  `json:"expires_at"` // Unix timestamp (milliseconds) when the instruction expires
+	CreatedAt int64  `json:"created_at"` // Unix timestamp (milliseconds) when the instruction was tracked
+	UpdatedAt int64  `json:"updated_at"` // Unix timestamp (milliseconds) of the last transition
+}
+
+// Event describes a state transition.
+type Event struct {
+	Instruction Instruction // Instruction after the transition
+	From        State       // Previous state, empty when the instruction was created
+	To          State       // New state
+	At          int64       // Unix timestamp (milliseconds) of the transition
+}
+
+// InstructionStore persists the lifecycle records. Payment and token identifiers are only
+// unique per key issuer, so records are keyed by the key issuer and the identifier.
+type InstructionStore interface {
+	// Create stores a new instruction. It returns ErrExists if the ID or jti is already stored
+	// for the key issuer.
+	Create(ctx context.Context, instruction Instruction) error
+
+	// Get returns the instruction of the key issuer with the given payment identifier, or
+	// ErrNotFound.
+	Get(ctx context.Context, keyIssuer string, id string) (*Instruction, error)
+
+	// GetByJti returns the instruction of the key issuer with the given token identifier, or
+	// ErrNotFound.
+	GetByJti(ctx context.Context, keyIssuer string, jti string) (*Instruction, error)
+
+	// Update stores the state and update time of the instruction with the key issuer and ID of
+	// instruction if its stored state is still from. It returns ErrNotFound or
+	// ErrStateConflict otherwise.
+	Update(ctx context.Context, instruction Instruction, from State) error
+
+	// ListExpiring returns the instructions that are not final and expire at or before the
+	// given Unix timestamp (milliseconds), earliest expiry first.
+	ListExpiring(ctx context.Context, before int64) ([]Instruction, error)
+}
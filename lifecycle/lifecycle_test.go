@@ -0,0 +1,35 @@
+package lifecycle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Should allow only the lifecycle transitions
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from    State
+		to      State
+		allowed bool
+	}{
+		{StateCreated, StatePresented, true},
+		{StateCreated, StatePaid, true},
+		{StatePresented, StatePartiallyPaid, true},
+		{StatePartiallyPaid, StatePartiallyPaid, true},
+		{StatePartiallyPaid, StatePaid, true},
+		{StatePresented, StateCancelled, true},
+		{StatePresented, StateCreated, false},
+		{StatePaid, StateExpired, false},
+		{StateExpired, StatePaid, false},
+		{StateCancelled, StatePresented, false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.allowed, CanTransition(test.from, test.to), "%s -> %s", test.from, test.to)
+	}
+
+	assert.True(t, StatePaid.IsFinal())
+	assert.True(t, StateExpired.IsFinal())
+	assert.False(t, StatePartiallyPaid.IsFinal())
+}
@@ -0,0 +1,141 @@
+package lifecycle
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// MemoryStore is an InstructionStore kept in memory, for tests and single-process services.
+// It is safe for concurrent use.
+type MemoryStore struct {
+	mu           sync.RWMutex
+	instructions map[string]Instruction // Instructions indexed by key issuer and ID
+	jtis         map[string]string      // Instruction keys indexed by key issuer and jti
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{instructions: map[string]Instruction{}, jtis: map[string]string{}}
+}
+
+// Create stores a new instruction.
+func (s *MemoryStore) Create(ctx context.Context, instruction Instruction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := recordKey(instruction.KeyIssuer, instruction.Id)
+	jtiKey := recordKey(instruction.KeyIssuer, instruction.Jti)
+
+	if _, ok := s.instructions[key]; ok {
+		return ErrExists
+	}
+
+	if _, ok := s.jtis[jtiKey]; ok && instruction.Jti != "" {
+		return ErrExists
+	}
+
+	s.instructions[key] = instruction
+
+	if instruction.Jti != "" {
+		s.jtis[jtiKey] = key
+	}
+
+	return nil
+}
+
+// Get returns the instruction of the key issuer with the given payment identifier.
+func (s *MemoryStore) Get(ctx context.Context, keyIssuer string, id string) (*Instruction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	instruction, ok := s.instructions[recordKey(keyIssuer, id)]
+
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return &instruction, nil
+}
+
+// GetByJti returns the instruction of the key issuer with the given token identifier.
+func (s *MemoryStore) GetByJti(ctx context.Context, keyIssuer string, jti string) (*Instruction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, ok := s.jtis[recordKey(keyIssuer, jti)]
+
+	if !ok || jti == "" {
+		return nil, ErrNotFound
+	}
+
+	instruction := s.instructions[key]
+
+	return &instruction, nil
+}
+
+// Update stores the state and update time of an instruction if its stored state is still from.
+func (s *MemoryStore) Update(ctx context.Context, instruction Instruction, from State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := recordKey(instruction.KeyIssuer, instruction.Id)
+	stored, ok := s.instructions[key]
+
+	if !ok {
+		return ErrNotFound
+	}
+
+	if stored.State != from {
+		return ErrStateConflict
+	}
+
+	stored.State = instruction.State
+	stored.UpdatedAt = instruction.UpdatedAt
+	s.instructions[key] = stored
+
+	return nil
+}
+
+// ListExpiring returns the instructions that are not final and expire at or before the timestamp.
+func (s *MemoryStore) ListExpiring(ctx context.Context, before int64) ([]Instruction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := []Instruction{}
+
+	for _, instruction := range s.instructions {
+		if instruction.ExpiresAt <= before && slices.Contains(pendingStates, instruction.State) {
+			result = append(result, instruction)
+		}
+	}
+
+	slices.SortFunc(result, compareExpiry)
+
+	return result, nil
+}
+
+// compareExpiry orders instructions by expiry, then by ID and key issuer.
+func compareExpiry(a Instruction, b Instruction) int {
+	switch {
+	case a.ExpiresAt < b.ExpiresAt:
+		return -1
+	case a.ExpiresAt > b.ExpiresAt:
+		return 1
+	case a.Id < b.Id:
+		return -1
+	case a.Id > b.Id:
+		return 1
+	case a.KeyIssuer < b.KeyIssuer:
+		return -1
+	case a.KeyIssuer > b.KeyIssuer:
+		return 1
+	}
+
+	return 0
+}
+
+// recordKey builds the map key of an identifier scoped by its key issuer.
+func recordKey(keyIssuer string, id string) string {
+	return keyIssuer + ";" + id
+}
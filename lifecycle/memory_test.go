@@ -0,0 +1,103 @@
+package lifecycle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testInstructionStore checks the InstructionStore behaviour shared by every store
+func testInstructionStore(t *testing.T, store InstructionStore) {
+	ctx := context.Background()
+
+	assert.Nil(t, store.Create(ctx, Instruction{Id: "payment-1", Jti: "jti-1", State: StateCreated, ExpiresAt: 2000}))
+	assert.Nil(t, store.Create(ctx, Instruction{Id: "payment-2", Jti: "jti-2", State: StatePaid, ExpiresAt: 1000}))
+	assert.Nil(t, store.Create(ctx, Instruction{Id: "payment-3", Jti: "jti-3", State: StatePresented, ExpiresAt: 1000}))
+	assert.Nil(t, store.Create(ctx, Instruction{Id: "payment-5", State: StatePartiallyPaid, ExpiresAt: 3000}))
+	assert.Nil(t, store.Create(ctx, Instruction{Id: "payment-6", State: StateExpired, ExpiresAt: 500}))
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T)
+	}{
+		{name: "duplicates", run: func(t *testing.T) {
+			assert.Equal(t, ErrExists, store.Create(ctx, Instruction{Id: "payment-1", Jti: "jti-4"}))
+			assert.Equal(t, ErrExists, store.Create(ctx, Instruction{Id: "payment-4", Jti: "jti-1"}))
+		}},
+		{name: "other key issuer", run: func(t *testing.T) {
+			// Identifiers are only unique per key issuer
+			assert.Nil(t, store.Create(ctx, Instruction{Id: "payment-1", Jti: "jti-1", KeyIssuer: "other.com", State: StatePaid, ExpiresAt: 2000}))
+			assert.Equal(t, ErrExists, store.Create(ctx, Instruction{Id: "payment-1", KeyIssuer: "other.com"}))
+
+			instruction, err := store.Get(ctx, "other.com", "payment-1")
+
+			assert.Nil(t, err)
+			assert.Equal(t, StatePaid, instruction.State)
+
+			instruction, err = store.GetByJti(ctx, "other.com", "jti-1")
+
+			assert.Nil(t, err)
+			assert.Equal(t, "other.com", instruction.KeyIssuer)
+
+			_, err = store.Get(ctx, "other.com", "payment-2")
+			assert.Equal(t, ErrNotFound, err)
+		}},
+		{name: "lookups", run: func(t *testing.T) {
+			instruction, err := store.GetByJti(ctx, "", "jti-3")
+
+			assert.Nil(t, err)
+			assert.Equal(t, "payment-3", instruction.Id)
+
+			_, err = store.Get(ctx, "", "payment-9")
+			assert.Equal(t, ErrNotFound, err)
+
+			_, err = store.GetByJti(ctx, "", "jti-9")
+			assert.Equal(t, ErrNotFound, err)
+
+			// Instructions without jti are not found by an empty jti
+			_, err = store.GetByJti(ctx, "", "")
+			assert.Equal(t, ErrNotFound, err)
+		}},
+		{name: "list expiring", run: func(t *testing.T) {
+			expiring, err := store.ListExpiring(ctx, 2000)
+
+			assert.Nil(t, err)
+			assert.Equal(t, 2, len(expiring))
+			assert.Equal(t, "payment-3", expiring[0].Id)
+			assert.Equal(t, "payment-1", expiring[1].Id)
+
+			expiring, err = store.ListExpiring(ctx, 3000)
+
+			assert.Nil(t, err)
+			assert.Equal(t, 3, len(expiring))
+			assert.Equal(t, "payment-5", expiring[2].Id)
+
+			expiring, err = store.ListExpiring(ctx, 999)
+
+			assert.Nil(t, err)
+			assert.Empty(t, expiring)
+		}},
+		{name: "update", run: func(t *testing.T) {
+			assert.Nil(t, store.Update(ctx, Instruction{Id: "payment-1", State: StatePresented, UpdatedAt: 1500}, StateCreated))
+			assert.Equal(t, ErrStateConflict, store.Update(ctx, Instruction{Id: "payment-1", State: StatePaid}, StateCreated))
+			assert.Equal(t, ErrNotFound, store.Update(ctx, Instruction{Id: "payment-9", State: StatePaid}, StateCreated))
+
+			instruction, _ := store.Get(ctx, "", "payment-1")
+
+			assert.Equal(t, StatePresented, instruction.State)
+			assert.Equal(t, int64(1500), instruction.UpdatedAt)
+			assert.Equal(t, "jti-1", instruction.Jti)
+			assert.Equal(t, int64(2000), instruction.ExpiresAt)
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, test.run)
+	}
+}
+
+// Should store, update and list instructions
+func TestMemoryStore(t *testing.T) {
+	testInstructionStore(t, NewMemoryStore())
+}
@@ -0,0 +1,187 @@
+package lifecycle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// sqliteSchema creates the instructions table and its indexes.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS naspip_instructions (
+	id         TEXT NOT NULL,
+	jti        TEXT NOT NULL,
+	key_issuer TEXT NOT NULL,
+	token      TEXT NOT NULL,
+	state      TEXT NOT NULL,
+	expires_at INTEGER NOT NULL,
+	created_at INTEGER NOT NULL,
+	updated_at INTEGER NOT NULL,
+	PRIMARY KEY (key_issuer, id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS naspip_instructions_jti ON naspip_instructions (key_issuer, jti) WHERE jti <> '';
+CREATE INDEX IF NOT EXISTS naspip_instructions_expiry ON naspip_instructions (state, expires_at);
+`
+
+// sqliteColumns are the selected columns, in the order read by scanInstruction.
+const sqliteColumns = "id, jti, key_issuer, token, state, expires_at, created_at, updated_at"
+
+// SQLiteStore is an InstructionStore backed by a SQLite database. The library does not link a
+// driver: open the database with a pure Go driver such as modernc.org/sqlite
+// (sql.Open("sqlite", path)), or any driver accepting the SQLite dialect.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore creates a SQLiteStore, creating the naspip_instructions table if needed.
+//
+// Parameters:
+//   - ctx: Context of the schema creation
+//   - db: An open SQLite database
+//
+// Returns:
+//   - The store
+//   - An error if the schema cannot be created
+func NewSQLiteStore(ctx context.Context, db *sql.DB) (*SQLiteStore, error) {
+	if _, err := db.ExecContext(ctx, sqliteSchema); err != nil {
+		return nil, err
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+// Create stores a new instruction.
+func (s *SQLiteStore) Create(ctx context.Context, instruction Instruction) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	var count int
+
+	err = tx.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM naspip_instructions WHERE key_issuer = ? AND (id = ? OR (jti <> '' AND jti = ?))",
+		instruction.KeyIssuer, instruction.Id, instruction.Jti,
+	).Scan(&count)
+
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return ErrExists
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO naspip_instructions ("+sqliteColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		instruction.Id, instruction.Jti, instruction.KeyIssuer, instruction.Token, string(instruction.State),
+		instruction.ExpiresAt, instruction.CreatedAt, instruction.UpdatedAt,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Get returns the instruction of the key issuer with the given payment identifier.
+func (s *SQLiteStore) Get(ctx context.Context, keyIssuer string, id string) (*Instruction, error) {
+	return scanInstruction(s.db.QueryRowContext(ctx, "SELECT "+sqliteColumns+" FROM naspip_instructions WHERE key_issuer = ? AND id = ?", keyIssuer, id))
+}
+
+// GetByJti returns the instruction of the key issuer with the given token identifier.
+func (s *SQLiteStore) GetByJti(ctx context.Context, keyIssuer string, jti string) (*Instruction, error) {
+	if jti == "" {
+		return nil, ErrNotFound
+	}
+
+	return scanInstruction(s.db.QueryRowContext(ctx, "SELECT "+sqliteColumns+" FROM naspip_instructions WHERE key_issuer = ? AND jti = ?", keyIssuer, jti))
+}
+
+// Update stores the state and update time of an instruction if its stored state is still from.
+func (s *SQLiteStore) Update(ctx context.Context, instruction Instruction, from State) error {
+	result, err := s.db.ExecContext(ctx,
+		"UPDATE naspip_instructions SET state = ?, updated_at = ? WHERE key_issuer = ? AND id = ? AND state = ?",
+		string(instruction.State), instruction.UpdatedAt, instruction.KeyIssuer, instruction.Id, string(from),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	updated, err := result.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if updated > 0 {
+		return nil
+	}
+
+	if _, err := s.Get(ctx, instruction.KeyIssuer, instruction.Id); err != nil {
+		return err
+	}
+
+	return ErrStateConflict
+}
+
+// ListExpiring returns the instructions that are not final and expire at or before the timestamp.
+func (s *SQLiteStore) ListExpiring(ctx context.Context, before int64) ([]Instruction, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT "+sqliteColumns+" FROM naspip_instructions WHERE state IN (?, ?, ?) AND expires_at <= ? ORDER BY expires_at, id, key_issuer",
+		string(pendingStates[0]), string(pendingStates[1]), string(pendingStates[2]), before,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := []Instruction{}
+
+	for rows.Next() {
+		instruction, err := scanInstruction(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, *instruction)
+	}
+
+	return result, rows.Err()
+}
+
+// scanner is implemented by sql.Row and sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanInstruction reads an instruction selected with sqliteColumns.
+func scanInstruction(row scanner) (*Instruction, error) {
+	var instruction Instruction
+	var state string
+
+	err := row.Scan(
+		&instruction.Id, &instruction.Jti, &instruction.KeyIssuer, &instruction.Token, &state,
+		&instruction.ExpiresAt, &instruction.CreatedAt, &instruction.UpdatedAt,
+	)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	instruction.State = State(state)
+
+	return &instruction, nil
+}
@@ -0,0 +1,105 @@
+package lifecycle
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	_ "modernc.org/sqlite"
+)
+
+// openSQLiteStore opens a SQLiteStore on a new in-memory database
+func openSQLiteStore(t *testing.T) (*SQLiteStore, *sql.DB) {
+	db, err := sql.Open("sqlite", ":memory:")
+
+	if err != nil {
+		t.Fatalf("openSQLiteStore FAIL --> %v", err)
+	}
+
+	// Each connection to :memory: opens a separate database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+
+	store, err := NewSQLiteStore(context.Background(), db)
+
+	if err != nil {
+		t.Fatalf("openSQLiteStore FAIL --> %v", err)
+	}
+
+	return store, db
+}
+
+// Should store, update and list instructions
+func TestSQLiteStore(t *testing.T) {
+	store, _ := openSQLiteStore(t)
+
+	testInstructionStore(t, store)
+}
+
+// Should create the schema once and keep the stored instructions
+func TestSQLiteStoreSchema(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := context.Background()
+	store, db := openSQLiteStore(t)
+
+	assert.Nil(store.Create(ctx, Instruction{Id: "payment-1", Jti: "jti-1", State: StateCreated, ExpiresAt: 2000}))
+
+	_, err := NewSQLiteStore(ctx, db)
+
+	assert.Nil(err)
+
+	var indexes []string
+
+	rows, err := db.QueryContext(ctx, "SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = 'naspip_instructions' AND sql IS NOT NULL ORDER BY name")
+
+	assert.Nil(err)
+
+	for rows.Next() {
+		var name string
+
+		assert.Nil(rows.Scan(&name))
+		indexes = append(indexes, name)
+	}
+
+	assert.Nil(rows.Close())
+	assert.Equal([]string{"naspip_instructions_expiry", "naspip_instructions_jti"}, indexes)
+
+	instruction, err := store.Get(ctx, "", "payment-1")
+
+	assert.Nil(err)
+	assert.Equal(StateCreated, instruction.State)
+}
+
+// Should only enforce unique jti values per key issuer on instructions that have one
+func TestSQLiteStoreJtiIndex(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := context.Background()
+	store, db := openSQLiteStore(t)
+
+	assert.Nil(store.Create(ctx, Instruction{Id: "payment-1", State: StateCreated}))
+	assert.Nil(store.Create(ctx, Instruction{Id: "payment-2", State: StateCreated}))
+	assert.Nil(store.Create(ctx, Instruction{Id: "payment-3", Jti: "jti-3", State: StateCreated}))
+
+	// The index rejects duplicates written without the Create check
+	_, err := db.ExecContext(ctx,
+		"INSERT INTO naspip_instructions ("+sqliteColumns+") VALUES ('payment-4', 'jti-3', '', '', 'created', 0, 0, 0)",
+	)
+
+	assert.NotNil(err)
+
+	_, err = db.ExecContext(ctx,
+		"INSERT INTO naspip_instructions ("+sqliteColumns+") VALUES ('payment-5', '', '', '', 'created', 0, 0, 0)",
+	)
+
+	assert.Nil(err)
+
+	_, err = db.ExecContext(ctx,
+		"INSERT INTO naspip_instructions ("+sqliteColumns+") VALUES ('payment-4', 'jti-3', 'other.com', '', 'created', 0, 0, 0)",
+	)
+
+	assert.Nil(err)
+}
@@ -0,0 +1,184 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/protocol"
+	"github.com/fluxisus/naspip-go/v3/utils"
+)
+
+// Tracker applies the lifecycle transitions of instructions on a store.
+type Tracker struct {
+	Store   InstructionStore // Store of the lifecycle records
+	Clock   protocol.Clock   // Clock used to timestamp transitions and check expiry, protocol.SystemClock when nil
+	OnEvent func(Event)      // Optional callback invoked after each transition
+}
+
+// Track starts tracking an issued payment instruction in the created state. The expiry is the
+// earliest of the payment ExpiresAt and the token exp claim; a payment ExpiresAt of 0 means the
+// payment has no expiry of its own, so the token exp claim is used.
+//
+// Parameters:
+//   - ctx: Context of the store operations
+//   - qrPayment: The NASPIP token of the instruction
+//   - data: The verified token content, as returned by PaymentInstructionsBuilder.Read
+//
+// Returns:
+//   - The created lifecycle record
+//   - An error if the token is not a payment instruction or the instruction is already tracked
+func (t Tracker) Track(ctx context.Context, qrPayment string, data *paseto.PasetoCompleteResult) (*Instruction, error) {
+	if _, ok := data.Payload.Data["payment"]; !ok {
+		return nil, errors.New("token does not contain a payment instruction")
+	}
+
+	var payload protocol.InstructionPayload
+
+	if err := protocol.PayloadOf(data.Payload.Data, &payload); err != nil {
+		return nil, err
+	}
+
+	expiresAt := payload.Payment.ExpiresAt
+
+	if exp, err := time.Parse(utils.RFC3339Mili, data.Payload.Exp); err == nil && (expiresAt == 0 || exp.UnixMilli() < expiresAt) {
+		expiresAt = exp.UnixMilli()
+	}
+
+	now := t.now()
+
+	instruction := Instruction{
+		Id:        payload.Payment.Id,
+		Jti:       data.Payload.Jti,
+		KeyIssuer: data.Payload.Kis,
+		Token:     qrPayment,
+		State:     StateCreated,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := t.Store.Create(ctx, instruction); err != nil {
+		return nil, err
+	}
+
+	t.emit(Event{Instruction: instruction, To: StateCreated, At: now})
+
+	return &instruction, nil
+}
+
+// Transition moves an instruction to a new state. Presenting an instruction after its
+// expiry expires it instead and returns ErrExpired; payments are still recorded after expiry
+// as long as the instruction did not move to the expired state.
+//
+// Parameters:
+//   - ctx: Context of the store operations
+//   - keyIssuer: Issuer of the key that signed the instruction
+//   - id: The payment identifier
+//   - to: The new state
+//
+// Returns:
+//   - The updated lifecycle record
+//   - An error if the instruction is unknown, the transition is not allowed or the state
+//     changed concurrently
+func (t Tracker) Transition(ctx context.Context, keyIssuer string, id string, to State) (*Instruction, error) {
+	instruction, err := t.Store.Get(ctx, keyIssuer, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if to == StatePresented && instruction.ExpiresAt <= t.now() && CanTransition(instruction.State, StateExpired) {
+		if _, err := t.apply(ctx, *instruction, StateExpired); err != nil {
+			return nil, err
+		}
+
+		return nil, ErrExpired
+	}
+
+	return t.apply(ctx, *instruction, to)
+}
+
+// TransitionByJti moves the instruction of the key issuer with the given token identifier to
+// a new state. See Transition.
+func (t Tracker) TransitionByJti(ctx context.Context, keyIssuer string, jti string, to State) (*Instruction, error) {
+	instruction, err := t.Store.GetByJti(ctx, keyIssuer, jti)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.Transition(ctx, keyIssuer, instruction.Id, to)
+}
+
+// ExpireDue moves the instructions whose expiry has passed to the expired state. It is meant
+// to be called periodically. Instructions changed concurrently are skipped.
+//
+// Parameters:
+//   - ctx: Context of the store operations
+//
+// Returns:
+//   - The expired instructions
+//   - An error if the store fails
+func (t Tracker) ExpireDue(ctx context.Context) ([]Instruction, error) {
+	due, err := t.Store.ListExpiring(ctx, t.now())
+
+	if err != nil {
+		return nil, err
+	}
+
+	expired := []Instruction{}
+
+	for _, instruction := range due {
+		updated, err := t.apply(ctx, instruction, StateExpired)
+
+		if errors.Is(err, ErrStateConflict) {
+			continue
+		}
+
+		if err != nil {
+			return expired, err
+		}
+
+		expired = append(expired, *updated)
+	}
+
+	return expired, nil
+}
+
+// apply stores a transition and emits its event.
+func (t Tracker) apply(ctx context.Context, instruction Instruction, to State) (*Instruction, error) {
+	if !CanTransition(instruction.State, to) {
+		return nil, ErrInvalidTransition
+	}
+
+	from := instruction.State
+
+	instruction.State = to
+	instruction.UpdatedAt = t.now()
+
+	if err := t.Store.Update(ctx, instruction, from); err != nil {
+		return nil, err
+	}
+
+	t.emit(Event{Instruction: instruction, From: from, To: to, At: instruction.UpdatedAt})
+
+	return &instruction, nil
+}
+
+// emit invokes the event callback.
+func (t Tracker) emit(event Event) {
+	if t.OnEvent != nil {
+		t.OnEvent(event)
+	}
+}
+
+// now returns the current Unix timestamp in milliseconds.
+func (t Tracker) now() int64 {
+	if t.Clock == nil {
+		return time.Now().UnixMilli()
+	}
+
+	return t.Clock.Now().UnixMilli()
+}
@@ -0,0 +1,169 @@
+package lifecycle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/protocol"
+	"github.com/fluxisus/naspip-go/v3/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var keys = map[string]string{
+	"publicKey": "k4.public.sGVse4eAyt6ycfmkKl3Az7RxB34nklDPgKbNLvxVwlk",
+	"secretKey": "k4.secret.y4-gze54dwfLR0eyxiJL2mRicZr6SX2-xIn6kgo999iwZWx7h4DK3rJx-aQqXcDPtHEHfieSUM-Aps0u_FXCWQ",
+}
+
+// fixedClock is a Clock returning a settable time
+type fixedClock struct {
+	now time.Time
+}
+
+func (c *fixedClock) Now() time.Time {
+	return c.now
+}
+
+// issue creates and reads an instruction token whose payment expires after paymentExpiry
+// (never when 0) and whose token expires after tokenExpiry
+func issue(t *testing.T, id string, paymentExpiry time.Duration, tokenExpiry string) (string, *paseto.PasetoCompleteResult) {
+	builder := protocol.PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	var expiresAt int64
+
+	if paymentExpiry != 0 {
+		expiresAt = time.Now().Add(paymentExpiry).UnixMilli()
+	}
+
+	token, err := builder.CreatePaymentInstruction(protocol.InstructionPayload{
+		Payment: protocol.PaymentInstruction{
+			Id:            id,
+			Address:       "crypto-address",
+			UniqueAssetId: "ntrc20_tTR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+			Amount:        "10",
+			ExpiresAt:     expiresAt,
+		},
+	}, keys["secretKey"], protocol.QrCriptoCreateOptions{
+		SignOptions:   paseto.PasetoSignOptions{KeyId: "key-1", Jti: "jti-" + id, ExpiresIn: tokenExpiry, Assertion: []byte(keys["publicKey"])},
+		KeyIssuer:     "merchant.com",
+		KeyExpiration: time.Now().Add(1e9).Format(utils.RFC3339Mili),
+	})
+
+	if err != nil {
+		t.Fatalf("issue FAIL --> %v", err)
+	}
+
+	data, err := builder.Read(token, keys["publicKey"], protocol.QrCriptoReadOptions{})
+
+	if err != nil {
+		t.Fatalf("issue FAIL --> %v", err)
+	}
+
+	return token, data
+}
+
+// Should track an instruction through its states and emit events
+func TestTracker(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := context.Background()
+	events := []Event{}
+
+	tracker := Tracker{Store: NewMemoryStore(), OnEvent: func(event Event) { events = append(events, event) }}
+
+	token, data := issue(t, "payment-1", time.Hour, "10m")
+
+	instruction, err := tracker.Track(ctx, token, data)
+
+	assert.Nil(err)
+	assert.Equal(StateCreated, instruction.State)
+	assert.Equal("jti-payment-1", instruction.Jti)
+	exp, _ := time.Parse(utils.RFC3339Mili, data.Payload.Exp)
+	assert.Equal(exp.UnixMilli(), instruction.ExpiresAt)
+
+	_, err = tracker.Track(ctx, token, data)
+	assert.Equal(ErrExists, err)
+
+	_, err = tracker.Transition(ctx, "merchant.com", "payment-1", StatePresented)
+	assert.Nil(err)
+
+	_, err = tracker.TransitionByJti(ctx, "merchant.com", data.Payload.Jti, StatePartiallyPaid)
+	assert.Nil(err)
+
+	instruction, err = tracker.Transition(ctx, "merchant.com", "payment-1", StatePaid)
+
+	assert.Nil(err)
+	assert.Equal(StatePaid, instruction.State)
+
+	_, err = tracker.Transition(ctx, "merchant.com", "payment-1", StateCancelled)
+	assert.Equal(ErrInvalidTransition, err)
+
+	assert.Equal(4, len(events))
+	assert.Equal(State(""), events[0].From)
+	assert.Equal(StatePresented, events[2].From)
+	assert.Equal(StatePartiallyPaid, events[3].From)
+	assert.Equal(StatePaid, events[3].To)
+}
+
+// Should expire instructions past the payment expiry or the token exp
+func TestTrackerExpiry(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := context.Background()
+	clock := &fixedClock{now: time.Now()}
+	tracker := Tracker{Store: NewMemoryStore(), Clock: clock}
+
+	token, data := issue(t, "payment-1", 5*time.Minute, "1h")
+	instruction, _ := tracker.Track(ctx, token, data)
+
+	assert.Equal(data.Payload.Data["payment"].(map[string]interface{})["expires_at"], instruction.ExpiresAt)
+
+	token, data = issue(t, "payment-2", time.Hour, "20m")
+	_, _ = tracker.Track(ctx, token, data)
+
+	token, data = issue(t, "payment-3", 10*time.Minute, "1h")
+	_, _ = tracker.Track(ctx, token, data)
+	_, _ = tracker.Transition(ctx, "merchant.com", "payment-3", StatePaid)
+
+	clock.now = clock.now.Add(15 * time.Minute)
+
+	_, err := tracker.Transition(ctx, "merchant.com", "payment-1", StatePresented)
+	assert.Equal(ErrExpired, err)
+
+	instruction, _ = tracker.Store.Get(ctx, "merchant.com", "payment-1")
+	assert.Equal(StateExpired, instruction.State)
+
+	clock.now = clock.now.Add(10 * time.Minute)
+
+	expired, err := tracker.ExpireDue(ctx)
+
+	assert.Nil(err)
+	assert.Equal(1, len(expired))
+	assert.Equal("payment-2", expired[0].Id)
+
+	instruction, _ = tracker.Store.Get(ctx, "merchant.com", "payment-3")
+	assert.Equal(StatePaid, instruction.State)
+}
+
+// Should use the token exp when the payment has no expiry
+func TestTrackWithoutPaymentExpiry(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := context.Background()
+	tracker := Tracker{Store: NewMemoryStore()}
+
+	token, data := issue(t, "payment-1", 0, "10m")
+	instruction, err := tracker.Track(ctx, token, data)
+
+	assert.Nil(err)
+
+	exp, _ := time.Parse(utils.RFC3339Mili, data.Payload.Exp)
+
+	assert.Equal(exp.UnixMilli(), instruction.ExpiresAt)
+
+	_, err = tracker.Transition(ctx, "merchant.com", "payment-1", StatePresented)
+
+	assert.Nil(err)
+}
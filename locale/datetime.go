@@ -0,0 +1,202 @@
+package locale
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// relativeUnits are the units of relative times, largest first.
+var relativeUnits = []struct {
+	name     string
+	duration time.Duration
+}{
+	{name: "day", duration: 24 * time.Hour},
+	{name: "hour", duration: time.Hour},
+	{name: "minute", duration: time.Minute},
+	{name: "second", duration: time.Second},
+}
+
+// Expiry describes the expiration of an instruction for display.
+type Expiry struct {
+	Relative string // Expiration relative to now (e.g., "in 14 minutes", "hace 2 horas")
+	Absolute string // Expiration date and time (e.g., "May 1, 2024, 12:14 PM", "1 mai 2024, 12:14")
+	Expired  bool   // Whether the instruction has expired
+}
+
+// FormatRelative formats a time relative to now with its largest whole unit (e.g., "in 14
+// minutes", "3 hours ago", "dentro de 1 día"). Times less than a second away are "now".
+//
+// Parameters:
+//   - t: Time to format
+//   - now: Current time
+//
+// Returns:
+//   - The relative time
+func (l Locale) FormatRelative(t time.Time, now time.Time) string {
+	difference := t.Sub(now)
+	forms := l.table.Relative.Future
+
+	if difference < 0 {
+		difference = -difference
+		forms = l.table.Relative.Past
+	}
+
+	for _, unit := range relativeUnits {
+		count := int64(difference / unit.duration)
+
+		if count > 0 {
+			form := l.plural(forms[unit.name], count)
+
+			return strings.ReplaceAll(form, "{0}", l.group(strconv.FormatInt(count, 10)))
+		}
+	}
+
+	return l.table.Now
+}
+
+// FormatDateTime formats a date and time with the medium date and short time patterns of the
+// locale (e.g., "May 1, 2024, 12:14 PM" in en, "01.05.2024, 12:14" in de).
+//
+// Parameters:
+//   - t: Time to format, in the location to display
+//
+// Returns:
+//   - The formatted date and time
+func (l Locale) FormatDateTime(t time.Time) string {
+	return strings.NewReplacer(
+		"{1}", l.formatPattern(l.table.DatePattern, t),
+		"{0}", l.formatPattern(l.table.TimePattern, t),
+	).Replace(l.table.DateTimePattern)
+}
+
+// FormatExpiry formats the expiration of an instruction relative to now and as a date and
+// time in a location.
+//
+// Parameters:
+//   - expiresAt: Expiration time as a Unix timestamp in milliseconds, as found in instructions
+//   - now: Current time
+//   - location: Location to display the date and time in (UTC if nil)
+//
+// Returns:
+//   - The formatted expiration
+func (l Locale) FormatExpiry(expiresAt int64, now time.Time, location *time.Location) Expiry {
+	if location == nil {
+		location = time.UTC
+	}
+
+	expiration := time.UnixMilli(expiresAt)
+
+	return Expiry{
+		Relative: l.FormatRelative(expiration, now),
+		Absolute: l.FormatDateTime(expiration.In(location)),
+		Expired:  !expiration.After(now),
+	}
+}
+
+// formatPattern formats a time with a CLDR date or time pattern. The supported fields are
+// y, yy, M, MM, MMM, d, dd, H, HH, h, hh, m, mm, s, ss and a; text between single quotes is
+// copied as is, and two single quotes stand for a quote.
+func (l Locale) formatPattern(pattern string, t time.Time) string {
+	var b strings.Builder
+
+	runes := []rune(pattern)
+
+	for index := 0; index < len(runes); {
+		letter := runes[index]
+
+		if letter == '\'' {
+			index = quoted(&b, runes, index)
+
+			continue
+		}
+
+		width := 1
+
+		for index+width < len(runes) && runes[index+width] == letter {
+			width++
+		}
+
+		if field, ok := l.formatField(letter, width, t); ok {
+			b.WriteString(field)
+		} else {
+			b.WriteString(string(runes[index : index+width]))
+		}
+
+		index += width
+	}
+
+	return b.String()
+}
+
+// formatField formats a pattern field, or returns false if the letter is not a field.
+func (l Locale) formatField(letter rune, width int, t time.Time) (string, bool) {
+	switch letter {
+	case 'y':
+		if width == 2 {
+			return fmt.Sprintf("%02d", t.Year()%100), true
+		}
+
+		return strconv.Itoa(t.Year()), true
+	case 'M':
+		if width >= 3 {
+			return l.table.Months[t.Month()-1], true
+		}
+
+		return pad(int(t.Month()), width), true
+	case 'd':
+		return pad(t.Day(), width), true
+	case 'H':
+		return pad(t.Hour(), width), true
+	case 'h':
+		hour := t.Hour() % 12
+
+		if hour == 0 {
+			hour = 12
+		}
+
+		return pad(hour, width), true
+	case 'm':
+		return pad(t.Minute(), width), true
+	case 's':
+		return pad(t.Second(), width), true
+	case 'a':
+		return l.table.DayPeriods[t.Hour()/12], true
+	}
+
+	return "", false
+}
+
+// quoted writes the quoted text starting at a quote and returns the index following it.
+func quoted(b *strings.Builder, runes []rune, index int) int {
+	if index+1 < len(runes) && runes[index+1] == '\'' {
+		b.WriteRune('\'')
+
+		return index + 2
+	}
+
+	for index++; index < len(runes); index++ {
+		if runes[index] != '\'' {
+			b.WriteRune(runes[index])
+
+			continue
+		}
+
+		if index+1 < len(runes) && runes[index+1] == '\'' {
+			b.WriteRune('\'')
+			index++
+
+			continue
+		}
+
+		return index + 1
+	}
+
+	return index
+}
+
+// pad formats a number with leading zeros up to a width.
+func pad(value int, width int) string {
+	return fmt.Sprintf("%0*d", width, value)
+}
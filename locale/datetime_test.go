@@ -0,0 +1,94 @@
+package locale
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+// Should format times relative to now with their largest whole unit
+func TestFormatRelative(t *testing.T) {
+	tests := []struct {
+		tag        string
+		difference time.Duration
+		expected   string
+	}{
+		{tag: "en", difference: 14*time.Minute + 59*time.Second, expected: "in 14 minutes"},
+		{tag: "en", difference: time.Minute, expected: "in 1 minute"},
+		{tag: "en", difference: -3 * time.Hour, expected: "3 hours ago"},
+		{tag: "en", difference: 45 * time.Second, expected: "in 45 seconds"},
+		{tag: "en", difference: 500 * time.Millisecond, expected: "now"},
+		{tag: "en", difference: 50 * 24 * time.Hour, expected: "in 50 days"},
+		{tag: "es", difference: 26 * time.Hour, expected: "dentro de 1 día"},
+		{tag: "es", difference: -2 * time.Minute, expected: "hace 2 minutos"},
+		{tag: "de", difference: 2 * time.Hour, expected: "in 2 Stunden"},
+		{tag: "de", difference: -time.Second, expected: "vor 1 Sekunde"},
+		{tag: "pt", difference: 0, expected: "agora"},
+		{tag: "pt", difference: 5 * time.Minute, expected: "em 5 minutos"},
+		{tag: "fr", difference: -24 * time.Hour, expected: "il y a 1 jour"},
+	}
+
+	for _, test := range tests {
+		relative := Lookup(test.tag).FormatRelative(now.Add(test.difference), now)
+
+		assert.Equal(t, test.expected, relative, "%s: %s", test.tag, test.difference)
+	}
+}
+
+// Should format dates and times with the patterns of the locale
+func TestFormatDateTime(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected string
+	}{
+		{tag: "en", expected: "May 1, 2024, 12:14 PM"},
+		{tag: "en-GB", expected: "1 May 2024, 12:14"},
+		{tag: "de", expected: "01.05.2024, 12:14"},
+		{tag: "es", expected: "1 may 2024, 12:14"},
+		{tag: "pt", expected: "1 de mai. de 2024 12:14"},
+		{tag: "fr", expected: "1 mai 2024, 12:14"},
+	}
+
+	for _, test := range tests {
+		formatted := Lookup(test.tag).FormatDateTime(now.Add(14 * time.Minute))
+
+		assert.Equal(t, test.expected, formatted, test.tag)
+	}
+
+	assert.Equal(t, "Jan 2, 2025, 12:05 AM", Lookup("en").FormatDateTime(time.Date(2025, 1, 2, 0, 5, 0, 0, time.UTC)))
+	assert.Equal(t, "2 ene 2025, 9:05", Lookup("es").FormatDateTime(time.Date(2025, 1, 2, 9, 5, 0, 0, time.UTC)))
+}
+
+// Should format expirations in a location
+func TestFormatExpiry(t *testing.T) {
+	assert := assert.New(t)
+
+	buenosAires := time.FixedZone("ART", -3*60*60)
+	expiresAt := now.Add(14*time.Minute + 30*time.Second).UnixMilli()
+
+	assert.Equal(Expiry{
+		Relative: "dentro de 14 minutos",
+		Absolute: "1 may 2024, 09:14",
+	}, Lookup("es-AR").FormatExpiry(expiresAt, now, buenosAires))
+
+	assert.Equal(Expiry{
+		Relative: "14 minutes ago",
+		Absolute: "May 1, 2024, 12:14 PM",
+		Expired:  true,
+	}, Lookup("en").FormatExpiry(expiresAt, now.Add(29*time.Minute), nil))
+
+	assert.True(Lookup("en").FormatExpiry(now.UnixMilli(), now, nil).Expired)
+}
+
+// Should copy quoted text and unknown letters as is
+func TestFormatPattern(t *testing.T) {
+	locale := Lookup("en")
+	at := time.Date(2024, 3, 9, 7, 5, 3, 0, time.UTC)
+
+	assert.Equal(t, "09/03/24 07:05:03", locale.formatPattern("dd/MM/yy HH:mm:ss", at))
+	assert.Equal(t, "o'clock 7 Q", locale.formatPattern("'o''clock' h Q", at))
+	assert.Equal(t, "7'05", locale.formatPattern("h''mm", at))
+}
@@ -0,0 +1,215 @@
+// Package locale formats instruction amounts and expiry times for display, following the
+// conventions of the payer's locale: decimal and grouping separators, currency symbols and
+// their placement, rounding to the currency or asset precision, and date and relative time
+// expressions.
+//
+// The locale data is embedded in CLDR-style tables (data/locales.json and
+// data/currencies.json) covering common locales. Regional locales inherit the fields they do
+// not define from their language (e.g., es-AR from es). Lookup matches any BCP 47 tag to the
+// closest supported locale, falling back to English.
+package locale
+
+import (
+	"embed"
+	"encoding/json"
+	"sort"
+
+	"golang.org/x/text/language"
+)
+
+// defaultTag is the locale used when no supported locale matches.
+const defaultTag = "en"
+
+//go:embed data/*.json
+var data embed.FS
+
+// relativeForms holds the plural forms of a relative time unit.
+type relativeForms struct {
+	One   string `json:"one"`   // Form for a count of one (e.g., "in {0} minute")
+	Other string `json:"other"` // Form for other counts (e.g., "in {0} minutes")
+}
+
+// relativeTable holds the relative time expressions of a locale, by unit (second, minute,
+// hour, day).
+type relativeTable struct {
+	Future map[string]relativeForms `json:"future"` // Expressions of future times
+	Past   map[string]relativeForms `json:"past"`   // Expressions of past times
+}
+
+// table holds the locale data of a locale. Empty fields are inherited from the parent.
+type table struct {
+	Parent          string            `json:"parent,omitempty"`           // Locale the missing fields are inherited from
+	Decimal         string            `json:"decimal,omitempty"`          // Decimal separator
+	Group           string            `json:"group,omitempty"`            // Grouping separator
+	Grouping        []int             `json:"grouping,omitempty"`         // Primary and optional secondary group sizes
+	MinGrouping     int               `json:"min_grouping,omitempty"`     // Minimum number of digits in the highest group for grouping to apply
+	CurrencyPattern string            `json:"currency_pattern,omitempty"` // Currency pattern, ¤ standing for the symbol
+	Symbols         map[string]string `json:"symbols,omitempty"`          // Currency symbols by ISO 4217 code
+	PluralRule      string            `json:"plural_rule,omitempty"`      // Plural rule: "one" (1 is singular) or "one_or_zero" (0 and 1 are singular)
+	DatePattern     string            `json:"date_pattern,omitempty"`     // Date pattern (CLDR pattern letters)
+	TimePattern     string            `json:"time_pattern,omitempty"`     // Time pattern (CLDR pattern letters)
+	DateTimePattern string            `json:"datetime_pattern,omitempty"` // Pattern joining the time ({0}) and the date ({1})
+	Months          []string          `json:"months,omitempty"`           // Abbreviated month names
+	DayPeriods      []string          `json:"day_periods,omitempty"`      // AM and PM markers
+	Now             string            `json:"now,omitempty"`              // Expression of the current time
+	Relative        *relativeTable    `json:"relative,omitempty"`         // Relative time expressions
+}
+
+// currencyTable holds the number of fraction digits of the currencies.
+type currencyTable struct {
+	DefaultDigits int32            `json:"default_digits"` // Fraction digits of currencies not listed
+	Digits        map[string]int32 `json:"digits"`         // Fraction digits by ISO 4217 code
+}
+
+// Locale formats values following the conventions of a locale.
+type Locale struct {
+	Tag   string // BCP 47 tag of the supported locale (e.g., es-AR)
+	table table
+}
+
+var (
+	locales    map[string]table // Resolved tables by tag
+	currencies currencyTable    // Currency fraction digits
+	tags       []string         // Supported tags, default first
+	matcher    language.Matcher // Matcher of the supported tags
+)
+
+func init() {
+	raw := map[string]table{}
+
+	mustLoad("data/locales.json", &raw)
+	mustLoad("data/currencies.json", &currencies)
+
+	locales = map[string]table{}
+
+	for tag := range raw {
+		locales[tag] = resolve(raw, tag)
+	}
+
+	tags = []string{defaultTag}
+
+	for tag := range raw {
+		if tag != defaultTag {
+			tags = append(tags, tag)
+		}
+	}
+
+	sort.Strings(tags[1:])
+
+	languageTags := make([]language.Tag, len(tags))
+
+	for index, tag := range tags {
+		languageTags[index] = language.MustParse(tag)
+	}
+
+	matcher = language.NewMatcher(languageTags)
+}
+
+// mustLoad decodes an embedded table. The tables are part of the package, so a decoding
+// error is a programming error.
+func mustLoad(name string, target any) {
+	content, err := data.ReadFile(name)
+
+	if err != nil {
+		panic(err)
+	}
+
+	if err := json.Unmarshal(content, target); err != nil {
+		panic(err)
+	}
+}
+
+// resolve returns the table of a locale with the fields inherited from its parents.
+func resolve(raw map[string]table, tag string) table {
+	own := raw[tag]
+
+	if own.Parent == "" {
+		return own
+	}
+
+	parent := resolve(raw, own.Parent)
+	resolved := parent
+
+	resolved.Parent = own.Parent
+	resolved.Symbols = map[string]string{}
+
+	for code, symbol := range parent.Symbols {
+		resolved.Symbols[code] = symbol
+	}
+
+	for code, symbol := range own.Symbols {
+		resolved.Symbols[code] = symbol
+	}
+
+	inherit(&resolved.Decimal, own.Decimal)
+	inherit(&resolved.Group, own.Group)
+	inherit(&resolved.CurrencyPattern, own.CurrencyPattern)
+	inherit(&resolved.PluralRule, own.PluralRule)
+	inherit(&resolved.DatePattern, own.DatePattern)
+	inherit(&resolved.TimePattern, own.TimePattern)
+	inherit(&resolved.DateTimePattern, own.DateTimePattern)
+	inherit(&resolved.Now, own.Now)
+
+	if own.Grouping != nil {
+		resolved.Grouping = own.Grouping
+	}
+
+	if own.MinGrouping != 0 {
+		resolved.MinGrouping = own.MinGrouping
+	}
+
+	if own.Months != nil {
+		resolved.Months = own.Months
+	}
+
+	if own.DayPeriods != nil {
+		resolved.DayPeriods = own.DayPeriods
+	}
+
+	if own.Relative != nil {
+		resolved.Relative = own.Relative
+	}
+
+	return resolved
+}
+
+// inherit overrides an inherited field with the own value, if set.
+func inherit(field *string, own string) {
+	if own != "" {
+		*field = own
+	}
+}
+
+// Tags returns the supported locales, English first.
+func Tags() []string {
+	return append([]string{}, tags...)
+}
+
+// Lookup returns the supported locale closest to a BCP 47 tag or Accept-Language header
+// value (e.g., "es-AR", "pt-BR", "fr-CA,fr;q=0.9"). Unsupported or invalid tags fall back
+// to English.
+//
+// Parameters:
+//   - tag: A BCP 47 language tag, or a list of tags with quality weights
+//
+// Returns:
+//   - The matched locale
+func Lookup(tag string) Locale {
+	matched := defaultTag
+
+	if tag != "" {
+		_, index := language.MatchStrings(matcher, tag)
+		matched = tags[index]
+	}
+
+	return Locale{Tag: matched, table: locales[matched]}
+}
+
+// plural returns the plural form of a count.
+func (l Locale) plural(forms relativeForms, count int64) string {
+	if count == 1 || (count == 0 && l.table.PluralRule == "one_or_zero") {
+		return forms.One
+	}
+
+	return forms.Other
+}
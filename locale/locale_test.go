@@ -0,0 +1,72 @@
+package locale
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Should match tags to the closest supported locale
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected string
+	}{
+		{tag: "es-AR", expected: "es-AR"},
+		{tag: "es-ES", expected: "es"},
+		{tag: "es-CL", expected: "es-AR"},
+		{tag: "pt-BR", expected: "pt"},
+		{tag: "pt-PT", expected: "pt-PT"},
+		{tag: "en-US", expected: "en"},
+		{tag: "en-AU", expected: "en-GB"},
+		{tag: "de-AT", expected: "de"},
+		{tag: "fr-CA,fr;q=0.9", expected: "fr"},
+		{tag: "ja-JP", expected: "en"},
+		{tag: "not a tag", expected: "en"},
+		{tag: "", expected: "en"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, Lookup(test.tag).Tag, test.tag)
+	}
+}
+
+// Should inherit the fields a regional locale does not define
+func TestLookupInherits(t *testing.T) {
+	assert := assert.New(t)
+
+	locale := Lookup("es-AR")
+
+	assert.Equal(",", locale.table.Decimal)
+	assert.Equal("$", locale.table.Symbols["ARS"])
+	assert.Equal("€", locale.table.Symbols["EUR"])
+	assert.Equal(Lookup("es").table.Months, locale.table.Months)
+}
+
+// Should define every field in every locale
+func TestTablesComplete(t *testing.T) {
+	assert.Equal(t, "en", Tags()[0])
+
+	for _, tag := range Tags() {
+		table := Lookup(tag).table
+
+		assert.NotEmpty(t, table.Decimal, tag)
+		assert.NotEmpty(t, table.Group, tag)
+		assert.NotEmpty(t, table.Grouping, tag)
+		assert.Contains(t, table.CurrencyPattern, currencySign, tag)
+		assert.NotEmpty(t, table.PluralRule, tag)
+		assert.NotEmpty(t, table.DatePattern, tag)
+		assert.NotEmpty(t, table.TimePattern, tag)
+		assert.NotEmpty(t, table.DateTimePattern, tag)
+		assert.Len(t, table.Months, 12, tag)
+		assert.Len(t, table.DayPeriods, 2, tag)
+		assert.NotEmpty(t, table.Now, tag)
+
+		for _, unit := range relativeUnits {
+			for _, forms := range []map[string]relativeForms{table.Relative.Future, table.Relative.Past} {
+				assert.Contains(t, forms[unit.name].One, "{0}", "%s: %s", tag, unit.name)
+				assert.Contains(t, forms[unit.name].Other, "{0}", "%s: %s", tag, unit.name)
+			}
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package locale
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fluxisus/naspip-go/v3/protocol"
+	"github.com/shopspring/decimal"
+)
+
+// ErrInvalidAmount is returned when an amount is not a decimal number.
+var ErrInvalidAmount = errors.New("invalid amount")
+
+const (
+	currencySign = "¤"      // Placeholder of the currency symbol in currency patterns
+	nbsp         = "\u00a0" // Space inserted between a letter symbol and the number
+)
+
+// FormatDecimal formats a decimal amount with the separators and grouping of the locale,
+// rounded half to even to a number of fraction digits (e.g., "1234.565" with 2 digits is
+// "1,234.56" in en and "1.234,56" in de).
+//
+// Parameters:
+//   - amount: Decimal amount, as found in instructions (e.g., "1234.5")
+//   - fractionDigits: Number of fraction digits to round and pad to
+//
+// Returns:
+//   - The formatted amount
+//   - ErrInvalidAmount if the amount is not a decimal number
+func (l Locale) FormatDecimal(amount string, fractionDigits int32) (string, error) {
+	value, err := decimal.NewFromString(amount)
+
+	if err != nil {
+		return "", ErrInvalidAmount
+	}
+
+	value = value.RoundBank(fractionDigits)
+	number := l.formatNumber(value, fractionDigits, fractionDigits)
+
+	if value.Sign() < 0 {
+		return "-" + number, nil
+	}
+
+	return number, nil
+}
+
+// FormatCurrency formats an amount in a fiat currency, such as an order total with its
+// CoinCode. The amount is rounded to the digits of the currency (2 unless listed in
+// data/currencies.json) and the locale symbol and pattern are applied (e.g., "1.234,56 €"
+// in de, "€1,234.56" in en). Currencies without a symbol in the locale show their code.
+//
+// Parameters:
+//   - amount: Decimal amount (e.g., "1234.56")
+//   - coinCode: ISO 4217 currency code (e.g., "EUR")
+//
+// Returns:
+//   - The formatted amount
+//   - ErrInvalidAmount if the amount is not a decimal number
+func (l Locale) FormatCurrency(amount string, coinCode string) (string, error) {
+	value, err := decimal.NewFromString(amount)
+
+	if err != nil {
+		return "", ErrInvalidAmount
+	}
+
+	code := strings.ToUpper(coinCode)
+	digits := CurrencyDigits(code)
+	symbol, ok := l.table.Symbols[code]
+
+	if !ok {
+		symbol = code
+	}
+
+	value = value.RoundBank(digits)
+
+	return l.applyCurrency(l.formatNumber(value, digits, digits), symbol, value.Sign() < 0), nil
+}
+
+// FormatAsset formats an amount of a payment asset, such as a payment instruction amount.
+// The amount is rounded to the asset decimals and trailing zeros are trimmed down to two
+// fraction digits (or the asset decimals, if fewer), so "12.1" USDT is "USDT 12.10" in en
+// and "12,10 USDT" in es, while "0.00012345" BTC keeps its digits.
+//
+// Parameters:
+//   - amount: Decimal amount in asset units (e.g., "12.1")
+//   - asset: Asset of the amount, providing its symbol and decimals
+//
+// Returns:
+//   - The formatted amount
+//   - ErrInvalidAmount if the amount is not a decimal number
+func (l Locale) FormatAsset(amount string, asset protocol.Asset) (string, error) {
+	value, err := decimal.NewFromString(amount)
+
+	if err != nil {
+		return "", ErrInvalidAmount
+	}
+
+	value = value.RoundBank(asset.Decimals)
+	minDigits := min(2, asset.Decimals)
+	number := l.formatNumber(value, minDigits, asset.Decimals)
+
+	symbol := asset.Symbol
+
+	if symbol == "" {
+		symbol = asset.UniqueAssetId
+	}
+
+	return l.applyCurrency(number, symbol, value.Sign() < 0), nil
+}
+
+// CurrencyDigits returns the number of fraction digits of a currency (e.g., 2 for EUR, 0
+// for JPY, 3 for KWD).
+//
+// Parameters:
+//   - coinCode: ISO 4217 currency code
+//
+// Returns:
+//   - The number of fraction digits
+func CurrencyDigits(coinCode string) int32 {
+	if digits, ok := currencies.Digits[strings.ToUpper(coinCode)]; ok {
+		return digits
+	}
+
+	return currencies.DefaultDigits
+}
+
+// formatNumber formats the absolute value of a rounded amount, with at least minDigits and at
+// most maxDigits fraction digits.
+func (l Locale) formatNumber(value decimal.Decimal, minDigits int32, maxDigits int32) string {
+	integer, fraction, _ := strings.Cut(value.Abs().StringFixed(maxDigits), ".")
+
+	for int32(len(fraction)) > minDigits && strings.HasSuffix(fraction, "0") {
+		fraction = fraction[:len(fraction)-1]
+	}
+
+	number := l.group(integer)
+
+	if fraction != "" {
+		number += l.table.Decimal + fraction
+	}
+
+	return number
+}
+
+// group inserts the grouping separators into the integer digits of an amount.
+func (l Locale) group(integer string) string {
+	primary := l.table.Grouping[0]
+	secondary := primary
+
+	if len(l.table.Grouping) > 1 {
+		secondary = l.table.Grouping[1]
+	}
+
+	if len(integer) < primary+max(l.table.MinGrouping, 1) {
+		return integer
+	}
+
+	groups := []string{integer[len(integer)-primary:]}
+	integer = integer[:len(integer)-primary]
+
+	for len(integer) > secondary {
+		groups = append([]string{integer[len(integer)-secondary:]}, groups...)
+		integer = integer[:len(integer)-secondary]
+	}
+
+	return strings.Join(append([]string{integer}, groups...), l.table.Group)
+}
+
+// applyCurrency places a formatted number and a symbol into the currency pattern of the
+// locale. A space is inserted between a letter symbol and the number (e.g., "USDT 12.10"),
+// and negative amounts are prefixed with a minus sign.
+func (l Locale) applyCurrency(number string, symbol string, negative bool) string {
+	pattern := l.table.CurrencyPattern
+	start := strings.IndexAny(pattern, "#0")
+	end := strings.LastIndexAny(pattern, "#0") + 1
+	prefix := strings.ReplaceAll(pattern[:start], currencySign, symbol)
+	suffix := strings.ReplaceAll(pattern[end:], currencySign, symbol)
+
+	if last, _ := utf8.DecodeLastRuneInString(prefix); unicode.IsLetter(last) {
+		prefix += nbsp
+	}
+
+	if first, _ := utf8.DecodeRuneInString(suffix); unicode.IsLetter(first) {
+		suffix = nbsp + suffix
+	}
+
+	formatted := prefix + number + suffix
+
+	if negative {
+		return "-" + formatted
+	}
+
+	return formatted
+}
@@ -0,0 +1,113 @@
+package locale
+
+import (
+	"testing"
+
+	"github.com/fluxisus/naspip-go/v3/protocol"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Should group and round decimal amounts
+func TestFormatDecimal(t *testing.T) {
+	tests := []struct {
+		tag      string
+		amount   string
+		digits   int32
+		expected string
+	}{
+		{tag: "en", amount: "1234.565", digits: 2, expected: "1,234.56"},
+		{tag: "en", amount: "1234.575", digits: 2, expected: "1,234.58"},
+		{tag: "de", amount: "1234567.5", digits: 2, expected: "1.234.567,50"},
+		{tag: "es", amount: "1234", digits: 0, expected: "1234"},
+		{tag: "es", amount: "12345", digits: 0, expected: "12.345"},
+		{tag: "fr", amount: "-1234.5", digits: 1, expected: "-1 234,5"},
+		{tag: "en-IN", amount: "12345678", digits: 0, expected: "1,23,45,678"},
+		{tag: "en", amount: "-0.001", digits: 2, expected: "0.00"},
+	}
+
+	for _, test := range tests {
+		formatted, err := Lookup(test.tag).FormatDecimal(test.amount, test.digits)
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, formatted, "%s: %s", test.tag, test.amount)
+	}
+}
+
+// Should format amounts in fiat currencies
+func TestFormatCurrency(t *testing.T) {
+	tests := []struct {
+		tag      string
+		amount   string
+		coinCode string
+		expected string
+	}{
+		{tag: "de", amount: "1234.56", coinCode: "EUR", expected: "1.234,56 €"},
+		{tag: "en", amount: "1234.56", coinCode: "USD", expected: "$1,234.56"},
+		{tag: "en", amount: "1234.56", coinCode: "eur", expected: "€1,234.56"},
+		{tag: "en", amount: "-1", coinCode: "USD", expected: "-$1.00"},
+		{tag: "en", amount: "12.5", coinCode: "CHF", expected: "CHF 12.50"},
+		{tag: "es", amount: "1234.56", coinCode: "EUR", expected: "1234,56 €"},
+		{tag: "es", amount: "12345.67", coinCode: "EUR", expected: "12.345,67 €"},
+		{tag: "es-AR", amount: "12345.5", coinCode: "ARS", expected: "$ 12.345,50"},
+		{tag: "es-AR", amount: "10", coinCode: "USD", expected: "US$ 10,00"},
+		{tag: "pt", amount: "99.9", coinCode: "BRL", expected: "R$ 99,90"},
+		{tag: "en-IN", amount: "123456.78", coinCode: "INR", expected: "₹1,23,456.78"},
+		{tag: "en", amount: "1234.5", coinCode: "JPY", expected: "¥1,234"},
+		{tag: "en", amount: "1.2345", coinCode: "KWD", expected: "KWD 1.234"},
+	}
+
+	for _, test := range tests {
+		formatted, err := Lookup(test.tag).FormatCurrency(test.amount, test.coinCode)
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, formatted, "%s: %s %s", test.tag, test.amount, test.coinCode)
+	}
+}
+
+// Should format amounts in payment assets with their decimals
+func TestFormatAsset(t *testing.T) {
+	usdt := protocol.Asset{UniqueAssetId: "ntrc20_tTR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", Symbol: "USDT", Decimals: 6}
+	btc := protocol.Asset{UniqueAssetId: "nbtc", Symbol: "BTC", Decimals: 8}
+
+	tests := []struct {
+		tag      string
+		amount   string
+		asset    protocol.Asset
+		expected string
+	}{
+		{tag: "en", amount: "12.1", asset: usdt, expected: "USDT 12.10"},
+		{tag: "es", amount: "1234.5", asset: usdt, expected: "1234,50 USDT"},
+		{tag: "de", amount: "1234.1234567", asset: usdt, expected: "1.234,123457 USDT"},
+		{tag: "en", amount: "0.00012345", asset: btc, expected: "BTC 0.00012345"},
+		{tag: "fr", amount: "1", asset: protocol.Asset{UniqueAssetId: "nxyz", Decimals: 0}, expected: "1 nxyz"},
+	}
+
+	for _, test := range tests {
+		formatted, err := Lookup(test.tag).FormatAsset(test.amount, test.asset)
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, formatted, "%s: %s", test.tag, test.amount)
+	}
+}
+
+// Should reject amounts that are not decimal numbers
+func TestFormatInvalidAmount(t *testing.T) {
+	locale := Lookup("en")
+
+	_, err := locale.FormatDecimal("1,5", 2)
+	assert.Equal(t, ErrInvalidAmount, err)
+
+	_, err = locale.FormatCurrency("", "USD")
+	assert.Equal(t, ErrInvalidAmount, err)
+
+	_, err = locale.FormatAsset("abc", protocol.Asset{Symbol: "USDT", Decimals: 6})
+	assert.Equal(t, ErrInvalidAmount, err)
+}
+
+// Should return the fraction digits of currencies
+func TestCurrencyDigits(t *testing.T) {
+	assert.Equal(t, int32(2), CurrencyDigits("EUR"))
+	assert.Equal(t, int32(0), CurrencyDigits("jpy"))
+	assert.Equal(t, int32(3), CurrencyDigits("KWD"))
+}
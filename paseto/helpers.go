@@ -3,27 +3,33 @@ package paseto
 import (
 	"bytes"
 	"crypto/ed25519"
+	"crypto/rand"
 	"errors"
 	"strings"
 
 	"github.com/fluxisus/naspip-go/v3/encoding/protobuf"
 	"github.com/fluxisus/naspip-go/v3/utils"
+	"golang.org/x/crypto/blake2b"
+
+	pasetoV4 "zntr.io/paseto/v4"
 )
 
-// GenerateKey creates a new Ed25519 key pair for use with PASETO v4 tokens.
+// GenerateKey creates a new Ed25519 key pair for use with PASETO v4.public tokens,
+// or a symmetric key for use with PASETO v4.local tokens.
 //
 // Parameters:
-//   - purpose: The PASETO purpose ("public" is the only supported value for v4)
+//   - purpose: The PASETO purpose ("public" or "local")
 //   - format: The output format for the keys ("keyobject" or "paserk")
 //
 // Returns:
-//   - A map containing "secretKey" and "publicKey" entries
+//   - A map containing "secretKey" and "publicKey" entries, or a "localKey" entry for the local purpose
 //   - An error if the purpose or format is invalid
 //
 // The "keyobject" format returns raw base64url-encoded keys.
-// The "paserk" format returns PASERK-formatted keys (k4.secret/k4.public prefixed).
+// The "paserk" format returns PASERK-formatted keys (k4.secret/k4.public/k4.local prefixed).
+// Local keys are only generated in the "paserk" format, the only one GetLocalKey accepts.
 func GenerateKey(purpose string, format string) (map[string]string, error) {
-	if purpose != "public" {
+	if purpose != "public" && purpose != "local" {
 		return nil, errors.New("unsupported v4 purpose")
 	}
 
@@ -31,8 +37,24 @@ func GenerateKey(purpose string, format string) (map[string]string, error) {
 		return nil, errors.New("invalid format")
 	}
 
+	if purpose == "local" && format != "paserk" {
+		return nil, errors.New("local keys require the paserk format")
+	}
+
 	result := make(map[string]string)
 
+	if purpose == "local" {
+		localKey := make([]byte, pasetoV4.KeyLength)
+
+		if _, err := rand.Read(localKey); err != nil {
+			return nil, err
+		}
+
+		result["localKey"] = "k4.local." + utils.EncodeRawURLBase64(localKey)
+
+		return result, nil
+	}
+
 	publicKey, privateKey, _ := ed25519.GenerateKey(nil)
 
 	if format == "paserk" {
@@ -77,6 +99,108 @@ func GetPublicKey(key string) ed25519.PublicKey {
 	return ed25519.PublicKey(key)
 }
 
+// GetLocalKey converts a PASERK-formatted PASETO v4.local symmetric key (k4.local.* format)
+// to the key type used for encryption.
+//
+// Raw keys are not accepted: any 32-byte string, such as a raw Ed25519 public key, would
+// otherwise be usable as a shared key, letting whoever knows it create accepted tokens.
+//
+// Returns an error if the key is not in the k4.local format or is not 32 bytes long.
+func GetLocalKey(key string) (*pasetoV4.LocalKey, error) {
+	if !strings.HasPrefix(key, "k4.local.") {
+		return nil, errors.New("invalid local key")
+	}
+
+	keyBytes, err := utils.DecodeRawURLBase64(key[9:])
+
+	if err != nil || len(keyBytes) != pasetoV4.KeyLength {
+		return nil, errors.New("invalid local key")
+	}
+
+	var localKey pasetoV4.LocalKey
+	copy(localKey[:], keyBytes)
+
+	return &localKey, nil
+}
+
+// LocalKeyId computes the PASERK key identifier (k4.lid) of a PASETO v4.local symmetric key.
+// The identifier can be published in token footers so readers can select the shared key
+// without exposing the key itself.
+//
+// Parameters:
+//   - key: The symmetric key
+//
+// Returns:
+//   - The key identifier in "k4.lid.[hash]" format
+func LocalKeyId(key *pasetoV4.LocalKey) string {
+	const header = "k4.lid."
+
+	hash, _ := blake2b.New(33, nil)
+	hash.Write([]byte(header + "k4.local." + utils.EncodeRawURLBase64(key[:])))
+
+	return header + utils.EncodeRawURLBase64(hash.Sum(nil))
+}
+
+// PublicKeyIdOf computes the PASERK key identifier of a public key of any supported version:
+// k4.pid for Ed25519 public keys and k3.pid for P-384 public keys.
+// Raw keys are told apart by their length.
+//
+// Parameters:
+//   - key: The public key in raw or PASERK format
+//
+// Returns:
+//   - The key identifier, or an empty string if the key is not recognized
+func PublicKeyIdOf(key string) string {
+	if v3Key, err := GetV3PublicKey(key); err == nil {
+		return V3PublicKeyId(v3Key)
+	}
+
+	if publicKey := GetPublicKey(key); len(publicKey) == ed25519.PublicKeySize {
+		return PublicKeyId(publicKey)
+	}
+
+	return ""
+}
+
+// PublicKeyId computes the PASERK key identifier (k4.pid) of an Ed25519 public key.
+// The identifier can be published in token footers so verifiers can look up the key
+// without exposing the key itself.
+//
+// Parameters:
+//   - key: The Ed25519 public key
+//
+// Returns:
+//   - The key identifier in "k4.pid.[hash]" format
+func PublicKeyId(key ed25519.PublicKey) string {
+	const header = "k4.pid."
+
+	hash, _ := blake2b.New(33, nil)
+	hash.Write([]byte(header + "k4.public." + utils.EncodeRawURLBase64(key)))
+
+	return header + utils.EncodeRawURLBase64(hash.Sum(nil))
+}
+
+// HandlerFor returns the standard handler for the version and purpose of a PASETO token.
+//
+// Parameters:
+//   - token: A PASETO token string
+//
+// Returns:
+//   - The handler able to verify the token
+//   - An error if the token version or purpose is not supported
+func HandlerFor(token string) (PasetoHandler, error) {
+	switch {
+	case strings.HasPrefix(token, "v4.public."):
+		return PasetoV4Handler{}, nil
+	case strings.HasPrefix(token, "v4.local."):
+		return PasetoV4LocalHandler{}, nil
+	case strings.HasPrefix(token, "v3.public."):
+		return PasetoV3Handler{}, nil
+	}
+
+	return nil, errors.New("unsupported PASETO version")
+}
+
 // DecodeV4 parses a PASETO v4 token string without verifying its signature.
 // This is useful for extracting token information before verification.
 //
@@ -90,10 +214,35 @@ func GetPublicKey(key string) ed25519.PublicKey {
 // Note: This function does not verify the token's signature,
 // so the extracted payload should not be trusted without verification.
 func DecodeV4(token string) (PasetoCompleteResult, error) {
+	if !strings.HasPrefix(token, "v4.") {
+		return PasetoCompleteResult{}, errors.New("unsupported PASETO version")
+	}
+
+	return Decode(token)
+}
+
+// Decode parses a PASETO v3.public, v4.public or v4.local token string without verifying it.
+// The payload of v4.local tokens is encrypted and is not returned.
+//
+// Parameters:
+//   - token: A PASETO token string
+//
+// Returns:
+//   - A PasetoCompleteResult containing the parsed token parts
+//   - An error if the token is not a valid PASETO format or its version is not supported
+//
+// Note: This function does not verify the token's signature,
+// so the extracted payload should not be trusted without verification.
+func Decode(token string) (PasetoCompleteResult, error) {
 
 	data := strings.Split(token, ".")
 
 	length := len(data)
+
+	if length != 3 && length != 4 {
+		return PasetoCompleteResult{}, errors.New("token is not a PASETO formatted value")
+	}
+
 	var version, purpose, payload string = data[0], data[1], data[2]
 	var encodedFooter string
 
@@ -101,15 +250,11 @@ func DecodeV4(token string) (PasetoCompleteResult, error) {
 		encodedFooter = data[3]
 	}
 
-	if length != 3 && length != 4 {
-		return PasetoCompleteResult{}, errors.New("token is not a PASETO formatted value")
-	}
-
-	if version != "v4" {
+	if version != "v4" && version != "v3" {
 		return PasetoCompleteResult{}, errors.New("unsupported PASETO version")
 	}
 
-	if purpose != "local" && purpose != "public" {
+	if purpose != "public" && (purpose != "local" || version != "v4") {
 		return PasetoCompleteResult{}, errors.New("unsupported PASETO purpose")
 	}
 
@@ -125,13 +270,19 @@ func DecodeV4(token string) (PasetoCompleteResult, error) {
 		return result, nil
 	}
 
+	var signatureSize = ed25519.SignatureSize
+
+	if version == "v3" {
+		signatureSize = 2 * v3KeySize
+	}
+
 	raw, errRaw := utils.DecodeRawURLBase64(payload)
 
-	if errRaw != nil {
+	if errRaw != nil || len(raw) < signatureSize {
 		return PasetoCompleteResult{}, errors.New("token is not a PASETO formatted value")
 	}
 
-	var rawPayload = raw[0 : len(raw)-64]
+	var rawPayload = raw[0 : len(raw)-signatureSize]
 
 	var parseProto protobuf.PasetoTokenData
 
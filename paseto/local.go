@@ -0,0 +1,72 @@
+package paseto
+
+import (
+	"crypto/rand"
+
+	pasetoV4 "zntr.io/paseto/v4"
+)
+
+// PasetoV4LocalHandler implements the PasetoV4 interface for PASETO v4.local tokens.
+// The payload is encrypted with XChaCha20 and authenticated with BLAKE2b using a
+// 32-byte symmetric key, so only holders of the shared key can read the token.
+// The same key is passed to Sign and Verify.
+type PasetoV4LocalHandler struct{}
+
+// Sign creates a new encrypted PASETO v4.local token with the provided payload and options.
+//
+// Parameters:
+//   - payload: Protocol buffer encoded data to include in the token
+//   - localKey: Symmetric key in raw or PASERK format (k4.local)
+//   - options: Configuration options for the token
+//
+// Returns:
+//   - A PASETO v4.local token string or an error if token creation fails
+func (p PasetoV4LocalHandler) Sign(payload []byte, localKey string, options PasetoSignOptions) (string, error) {
+	key, err := GetLocalKey(localKey)
+
+	if err != nil {
+		return "", err
+	}
+
+	dataBytes, err := buildClaims(payload, options)
+
+	if err != nil {
+		return "", err
+	}
+
+	return pasetoV4.Encrypt(rand.Reader, key, dataBytes, options.Footer, options.Assertion)
+}
+
+// Verify decrypts and validates a PASETO v4.local token using the provided key and options.
+//
+// Parameters:
+//   - token: PASETO v4.local token to decrypt
+//   - localKey: Symmetric key in raw or PASERK format (k4.local)
+//   - options: Verification options and expected claims
+//
+// Returns:
+//   - A parsed PasetoCompleteResult containing the token data if decryption succeeds
+//   - An error if decryption or verification fails for any reason
+func (p PasetoV4LocalHandler) Verify(token string, localKey string, options PasetoVerifyOptions) (*PasetoCompleteResult, error) {
+	key, err := GetLocalKey(localKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tokenBytes, err := pasetoV4.Decrypt(key, token, options.Footer, options.Assertion)
+
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := parseClaims(tokenBytes, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var data = PasetoCompleteResult{Version: "v4", Purpose: "local", Footer: []byte{}, Payload: payload}
+
+	return &data, nil
+}
@@ -0,0 +1,89 @@
+package paseto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fluxisus/naspip-go/v3/encoding/protobuf"
+	"github.com/stretchr/testify/assert"
+)
+
+// Should create a shared key for local tokens
+func TestGenerateLocalKey(t *testing.T) {
+	assert := assert.New(t)
+
+	keys, err := GenerateKey("local", "paserk")
+
+	assert.Nil(err)
+	assert.True(strings.HasPrefix(keys["localKey"], "k4.local."))
+
+	localKey, err := GetLocalKey(keys["localKey"])
+
+	assert.Nil(err)
+	assert.True(strings.HasPrefix(LocalKeyId(localKey), "k4.lid."))
+
+	_, err = GetLocalKey("k4.local.c2hvcnQ")
+
+	assert.EqualError(err, "invalid local key")
+
+	// Raw 32-byte strings, such as raw public keys, are not local keys
+	_, err = GetLocalKey(strings.Repeat("k", 32))
+
+	assert.EqualError(err, "invalid local key")
+
+	_, err = GenerateKey("local", "keyobject")
+
+	assert.EqualError(err, "local keys require the paserk format")
+}
+
+// Should encrypt a token and decrypt it with the same key
+func TestLocalEncryptAndDecrypt(t *testing.T) {
+	assert := assert.New(t)
+
+	var handler = PasetoV4LocalHandler{}
+	var payload = protobuf.PasetoTokenData{
+		Data: &protobuf.PasetoTokenData_InstructionPayload{
+			InstructionPayload: &protobuf.InstructionPayload{
+				Payment: &protobuf.PaymentInstruction{
+					Id: "test-id",
+				},
+				Order: &protobuf.InstructionOrder{
+					Merchant: &protobuf.InstructionMerchant{
+						Name:  "Merchant",
+						TaxId: "30-12345678-9",
+					},
+				},
+			},
+		},
+	}
+
+	payloadBytes, _ := protobuf.EncodeProto(&payload)
+
+	localKeys, _ := GenerateKey("local", "paserk")
+	otherKeys, _ := GenerateKey("local", "paserk")
+
+	token, err := handler.Sign(payloadBytes, localKeys["localKey"], PasetoSignOptions{ExpiresIn: "1h", Footer: []byte("footer")})
+
+	assert.Nil(err)
+	assert.True(strings.HasPrefix(token, "v4.local."))
+
+	decoded, err := DecodeV4(token)
+
+	assert.Nil(err)
+	assert.Equal("local", decoded.Purpose)
+	assert.Nil(decoded.Payload.Data)
+
+	verified, err := handler.Verify(token, localKeys["localKey"], PasetoVerifyOptions{Footer: []byte("footer")})
+
+	assert.Nil(err)
+	assert.Equal("local", verified.Purpose)
+	assert.Equal("test-id", verified.Payload.Data["payment"].(map[string]interface{})["id"])
+
+	_, err = handler.Verify(token, otherKeys["localKey"], PasetoVerifyOptions{Footer: []byte("footer")})
+
+	assert.NotNil(err)
+
+	_, err = handler.Sign(payloadBytes, keys["secretKey"], PasetoSignOptions{})
+
+	assert.EqualError(err, "invalid local key")
+}
@@ -12,6 +12,15 @@ import (
 	pasetoV4 "zntr.io/paseto/v4"
 )
 
+// Errors returned by the handlers when a token is rejected.
+var (
+	ErrInvalidSignature = errors.New("paseto: invalid token signature")
+	ErrAudienceMismatch = errors.New("audience mismatch")
+	ErrTokenNotActive   = errors.New("token is not active yet")
+	ErrTokenExpired     = errors.New("token is expired")
+	ErrMaxTokenAge      = errors.New("maxTokenAge exceeded")
+)
+
 // PasetoTokenData represents the payload structure of a PASETO token.
 // It contains standard PASETO claims as well as custom data for NASPIP.
 type PasetoTokenData struct {
@@ -31,8 +40,8 @@ type PasetoTokenData struct {
 // PasetoCompleteResult represents a fully parsed PASETO token.
 // It includes version information, purpose, footer, and the payload data.
 type PasetoCompleteResult struct {
-	Version string          `json:"version"` // PASETO version (v4)
-	Purpose string          `json:"purpose"` // PASETO purpose (public)
+	Version string          `json:"version"` // PASETO version (v3 or v4)
+	Purpose string          `json:"purpose"` // PASETO purpose (public or local)
 	Footer  []byte          `json:"footer"`  // Token footer
 	Payload PasetoTokenData `json:"payload"` // Parsed token payload
 }
@@ -51,10 +60,62 @@ type PasetoV4Handler struct{}
 // Returns:
 //   - A PASETO v4 token string or an error if token creation fails
 func (p PasetoV4Handler) Sign(payload []byte, privateKey string, options PasetoSignOptions) (string, error) {
+	dataBytes, err := buildClaims(payload, options)
+
+	if err != nil {
+		return "", err
+	}
+
+	var key = GetPrivateKey(privateKey)
+
+	return pasetoV4.Sign(dataBytes, key, options.Footer, options.Assertion)
+}
+
+// Verify validates a PASETO v4 token using the provided public key and options.
+//
+// Parameters:
+//   - token: PASETO v4 token to verify
+//   - publicKey: Ed25519 public key in raw or PASERK format
+//   - options: Verification options and expected claims
+//
+// Returns:
+//   - A parsed PasetoCompleteResult containing the token data if verification succeeds
+//   - An error if verification fails for any reason
+func (p PasetoV4Handler) Verify(token string, publicKey string, options PasetoVerifyOptions) (*PasetoCompleteResult, error) {
+
+	var key = GetPublicKey(publicKey)
+
+	tokenBytes, err := pasetoV4.Verify(token, key, options.Footer, options.Assertion)
+
+	if err != nil {
+		return nil, signatureError(err)
+	}
+
+	payload, err := parseClaims(tokenBytes, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var data = PasetoCompleteResult{Version: "v4", Purpose: "public", Footer: []byte{}, Payload: payload}
+
+	return &data, nil
+}
+
+// buildClaims sets the registered claims of the signing options on a protobuf encoded payload.
+//
+// Parameters:
+//   - payload: Protocol buffer encoded data to include in the token
+//   - options: Configuration options for the token
+//
+// Returns:
+//   - The protobuf encoded token content
+//   - An error if an option has an invalid format
+func buildClaims(payload []byte, options PasetoSignOptions) ([]byte, error) {
 	var data protobuf.PasetoTokenData
 
 	if err := protobuf.DecodeProto(payload, &data); err != nil {
-		return "", err
+		return nil, err
 	}
 
 	var issuedAt = time.Now().UTC()
@@ -71,7 +132,7 @@ func (p PasetoV4Handler) Sign(payload []byte, privateKey string, options PasetoS
 		issuedAt, err = time.Parse(utils.RFC3339Mili, options.IssuedAt)
 
 		if err != nil {
-			return "", errors.New("invalid issuedAt format")
+			return nil, errors.New("invalid issuedAt format")
 		}
 		data.Iat = issuedAt.Format(utils.RFC3339Mili)
 	}
@@ -80,7 +141,7 @@ func (p PasetoV4Handler) Sign(payload []byte, privateKey string, options PasetoS
 		dur, err := str2duration.ParseDuration(options.ExpiresIn)
 
 		if err != nil {
-			return "", errors.New("invalid expiresIn format")
+			return nil, errors.New("invalid expiresIn format")
 		}
 
 		data.Exp = issuedAt.Add(dur).Format(utils.RFC3339Mili)
@@ -90,57 +151,35 @@ func (p PasetoV4Handler) Sign(payload []byte, privateKey string, options PasetoS
 		dur, err := str2duration.ParseDuration(options.NotBefore)
 
 		if err != nil {
-			return "", errors.New("invalid notBefore format")
+			return nil, errors.New("invalid notBefore format")
 		}
 
 		data.Nbf = issuedAt.Add(dur).Format(utils.RFC3339Mili)
 	}
 
-	var key = GetPrivateKey(privateKey)
-
-	dataBytes, err := protobuf.EncodeProto(&data)
-
-	if err != nil {
-		return "", err
-	}
-
-	return pasetoV4.Sign(dataBytes, key, options.Footer, options.Assertion)
+	return protobuf.EncodeProto(&data)
 }
 
-// Verify validates a PASETO v4 token using the provided public key and options.
+// parseClaims decodes the verified content of a token and validates its claims.
 //
 // Parameters:
-//   - token: PASETO v4 token to verify
-//   - publicKey: Ed25519 public key in raw or PASERK format
+//   - tokenBytes: The protobuf encoded token content
 //   - options: Verification options and expected claims
 //
 // Returns:
-//   - A parsed PasetoCompleteResult containing the token data if verification succeeds
-//   - An error if verification fails for any reason
-func (p PasetoV4Handler) Verify(token string, publicKey string, options PasetoVerifyOptions) (*PasetoCompleteResult, error) {
-
-	var key = GetPublicKey(publicKey)
-
-	tokenBytes, err := pasetoV4.Verify(token, key, options.Footer, options.Assertion)
-
-	if err != nil {
-		return nil, err
-	}
-
+//   - The token payload
+//   - An error if the content cannot be decoded or a claim is invalid
+func parseClaims(tokenBytes []byte, options PasetoVerifyOptions) (PasetoTokenData, error) {
 	var tokenData protobuf.PasetoTokenData
 
-	err = protobuf.DecodeProto(tokenBytes, &tokenData)
-
-	if err != nil {
-		return nil, err
+	if err := protobuf.DecodeProto(tokenBytes, &tokenData); err != nil {
+		return PasetoTokenData{}, err
 	}
 
 	var payload PasetoTokenData
 
-	err = protobuf.ConvertProtoToGo(&tokenData, &payload)
-
-	if err != nil {
-		return nil, err
+	if err := protobuf.ConvertProtoToGo(&tokenData, &payload); err != nil {
+		return PasetoTokenData{}, err
 	}
 
 	//For InstructionPayload, convert payment.expires_at to int64
@@ -150,15 +189,43 @@ func (p PasetoV4Handler) Verify(token string, publicKey string, options PasetoVe
 		}
 	}
 
-	verifyErr := assertPayload(payload, options)
+	//For InstructionPayload with an exchange rate quote, convert quote.expires_at to int64
+	if quote, ok := payload.Data["quote"].(map[string]interface{}); ok {
+		if expiresAt, ok := quote["expires_at"].(string); ok {
+			quote["expires_at"] = utils.FormatStringTimestampToUnixMilli(expiresAt)
+		}
+	}
 
-	if verifyErr != nil {
-		return nil, verifyErr
+	//For MultiAssetPayload, convert payments[].expires_at to int64
+	if payments, ok := payload.Data["payments"].([]interface{}); ok {
+		for _, payment := range payments {
+			if expiresAt, ok := payment.(map[string]interface{})["expires_at"].(string); ok {
+				payment.(map[string]interface{})["expires_at"] = utils.FormatStringTimestampToUnixMilli(expiresAt)
+			}
+		}
 	}
 
-	var data = PasetoCompleteResult{Version: "v4", Purpose: "public", Footer: []byte{}, Payload: payload}
+	//For RevocationList, convert revocations[].revoked_at to int64
+	if revocations, ok := payload.Data["revocations"].([]interface{}); ok {
+		for _, revocation := range revocations {
+			if revokedAt, ok := revocation.(map[string]interface{})["revoked_at"].(string); ok {
+				revocation.(map[string]interface{})["revoked_at"] = utils.FormatStringTimestampToUnixMilli(revokedAt)
+			}
+		}
+	}
 
-	return &data, nil
+	//For ReceiptPayload, MandatePayload, MandateAcceptance, KeyCertificate and WebhookEvent, convert top-level timestamps to int64
+	for _, field := range []string{"paid_at", "start_at", "end_at", "accepted_at", "not_before", "not_after", "occurred_at"} {
+		if value, ok := payload.Data[field].(string); ok {
+			payload.Data[field] = utils.FormatStringTimestampToUnixMilli(value)
+		}
+	}
+
+	if err := assertPayload(payload, options); err != nil {
+		return PasetoTokenData{}, err
+	}
+
+	return payload, nil
 }
 
 // assertPayload validates the claims within a PASETO token payload according to the verification options.
@@ -181,7 +248,7 @@ func assertPayload(payload PasetoTokenData, options PasetoVerifyOptions) error {
 
 	// Check aud
 	if options.Audience != "" && payload.Aud != options.Audience {
-		return errors.New("audience mismatch")
+		return ErrAudienceMismatch
 	}
 
 	// Check iat
@@ -210,7 +277,7 @@ func assertPayload(payload PasetoTokenData, options PasetoVerifyOptions) error {
 		}
 
 		if now.Before(nbf) {
-			return errors.New("token is not active yet")
+			return ErrTokenNotActive
 		}
 	}
 
@@ -227,7 +294,7 @@ func assertPayload(payload PasetoTokenData, options PasetoVerifyOptions) error {
 		}
 
 		if now.After(exp) {
-			return errors.New("token is expired")
+			return ErrTokenExpired
 		}
 	}
 
@@ -243,9 +310,19 @@ func assertPayload(payload PasetoTokenData, options PasetoVerifyOptions) error {
 		iat, _ := time.Parse(utils.RFC3339Mili, payload.Iat)
 
 		if now.After(iat.Add(maxDuration)) {
-			return errors.New("maxTokenAge exceeded")
+			return ErrMaxTokenAge
 		}
 	}
 
 	return nil
 }
+
+// signatureError returns ErrInvalidSignature for the signature failures of zntr.io/paseto,
+// which reports them with an unexported error, and the error unchanged otherwise.
+func signatureError(err error) error {
+	if err.Error() == ErrInvalidSignature.Error() {
+		return ErrInvalidSignature
+	}
+
+	return err
+}
@@ -374,3 +374,58 @@ func TestVerifyUrlPayload(t *testing.T) {
 	assert.NotNil(verified)
 	assert.Equal("https://example.fluxis.us/public/checkout/1234567890", verified.Payload.Data["url"])
 }
+
+// Should return sentinel errors for the verification failures
+func TestVerifySentinelErrors(t *testing.T) {
+	var handler = PasetoV4Handler{}
+	var payload = protobuf.PasetoTokenData{
+		Data: &protobuf.PasetoTokenData_UrlPayload{
+			UrlPayload: &protobuf.UrlPayload{
+				Url: "test-url",
+			},
+		},
+	}
+
+	payloadBytes, _ := protobuf.EncodeProto(&payload)
+
+	issuedAt := time.Now().UTC().Add(-1000 * time.Hour).Format(utils.RFC3339Mili)
+
+	expired, _ := handler.Sign(payloadBytes, keys["secretKey"], PasetoSignOptions{ExpiresIn: "0s"})
+	old, _ := handler.Sign(payloadBytes, keys["secretKey"], PasetoSignOptions{IssuedAt: issuedAt, ExpiresIn: "10000h"})
+	notActive, _ := handler.Sign(payloadBytes, keys["secretKey"], PasetoSignOptions{NotBefore: "1h"})
+	withAudience, _ := handler.Sign(payloadBytes, keys["secretKey"], PasetoSignOptions{Audience: "test-audience"})
+
+	tests := []struct {
+		name      string
+		token     string
+		publicKey string
+		options   PasetoVerifyOptions
+		err       error
+	}{
+		{name: "invalid signature", token: withAudience, publicKey: keys["otherPublicKey"], err: ErrInvalidSignature},
+		{name: "expired", token: expired, publicKey: keys["publicKey"], err: ErrTokenExpired},
+		{name: "max token age", token: old, publicKey: keys["publicKey"], options: PasetoVerifyOptions{MaxTokenAge: "100h"}, err: ErrMaxTokenAge},
+		{name: "not active", token: notActive, publicKey: keys["publicKey"], err: ErrTokenNotActive},
+		{name: "audience mismatch", token: withAudience, publicKey: keys["publicKey"], options: PasetoVerifyOptions{Audience: "wrong-audience"}, err: ErrAudienceMismatch},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := handler.Verify(test.token, test.publicKey, test.options)
+
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+// Should compute the PASERK identifier of a public key
+func TestPublicKeyId(t *testing.T) {
+	assert := assert.New(t)
+
+	pid := PublicKeyId(GetPublicKey(keys["publicKey"]))
+
+	assert.True(strings.HasPrefix(pid, "k4.pid."))
+	assert.Len(pid, len("k4.pid.")+44)
+	assert.Equal(pid, PublicKeyId(GetPublicKey(keys["publicKey"])))
+	assert.NotEqual(pid, PublicKeyId(GetPublicKey(keys["otherPublicKey"])))
+}
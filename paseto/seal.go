@@ -0,0 +1,221 @@
+package paseto
+
+import (
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/subtle"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/fluxisus/naspip-go/v3/utils"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/chacha20"
+
+	pasetoV4 "zntr.io/paseto/v4"
+)
+
+const sealHeader = "k4.seal."
+
+// SealLocalKey wraps a v4.local key to a recipient, following the PASERK k4.seal operation.
+// The recipient Ed25519 public key is converted to X25519 and used with an ephemeral key pair,
+// so only the holder of the matching secret key can unwrap the local key.
+//
+// Parameters:
+//   - localKey: The symmetric key to wrap
+//   - recipientPublicKey: The recipient Ed25519 public key in raw or PASERK format (k4.public)
+//
+// Returns:
+//   - The wrapped key in "k4.seal.[data]" format
+//   - An error if the recipient key is invalid
+func SealLocalKey(localKey *pasetoV4.LocalKey, recipientPublicKey string) (string, error) {
+	xpk, err := x25519PublicKey(GetPublicKey(recipientPublicKey))
+
+	if err != nil {
+		return "", err
+	}
+
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+
+	if err != nil {
+		return "", err
+	}
+
+	epk := ephemeral.PublicKey().Bytes()
+
+	xk, err := ephemeral.ECDH(xpk)
+
+	if err != nil {
+		return "", errors.New("invalid recipient public key")
+	}
+
+	cipher, err := sealCipher(xk, epk, xpk.Bytes())
+
+	if err != nil {
+		return "", err
+	}
+
+	edk := make([]byte, pasetoV4.KeyLength)
+	cipher.XORKeyStream(edk, localKey[:])
+
+	tag := sealTag(xk, epk, xpk.Bytes(), edk)
+
+	return sealHeader + utils.EncodeRawURLBase64(append(append(tag, epk...), edk...)), nil
+}
+
+// UnsealLocalKey unwraps a v4.local key wrapped with SealLocalKey.
+//
+// Parameters:
+//   - sealedKey: The wrapped key in "k4.seal.[data]" format
+//   - recipientSecretKey: The recipient Ed25519 private key in raw or PASERK format (k4.secret)
+//
+// Returns:
+//   - The unwrapped symmetric key
+//   - An error if the key was not wrapped to the recipient or was modified
+func UnsealLocalKey(sealedKey string, recipientSecretKey string) (*pasetoV4.LocalKey, error) {
+	if !strings.HasPrefix(sealedKey, sealHeader) {
+		return nil, errors.New("invalid sealed key")
+	}
+
+	raw, err := utils.DecodeRawURLBase64(sealedKey[len(sealHeader):])
+
+	if err != nil || len(raw) != 32+32+pasetoV4.KeyLength {
+		return nil, errors.New("invalid sealed key")
+	}
+
+	tag, epk, edk := raw[:32], raw[32:64], raw[64:]
+
+	privateKey := GetPrivateKey(recipientSecretKey)
+
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return nil, errors.New("invalid recipient secret key")
+	}
+
+	xsk, err := x25519PrivateKey(privateKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ephemeral, err := ecdh.X25519().NewPublicKey(epk)
+
+	if err != nil {
+		return nil, errors.New("invalid sealed key")
+	}
+
+	xk, err := xsk.ECDH(ephemeral)
+
+	if err != nil {
+		return nil, errors.New("invalid sealed key")
+	}
+
+	xpk := xsk.PublicKey().Bytes()
+
+	if subtle.ConstantTimeCompare(tag, sealTag(xk, epk, xpk, edk)) != 1 {
+		return nil, errors.New("invalid sealed key")
+	}
+
+	cipher, err := sealCipher(xk, epk, xpk)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var localKey pasetoV4.LocalKey
+	cipher.XORKeyStream(localKey[:], edk)
+
+	return &localKey, nil
+}
+
+// sealCipher derives the XChaCha20 cipher of the k4.seal operation.
+//
+// Parameters:
+//   - xk: The X25519 shared secret
+//   - epk: The ephemeral X25519 public key
+//   - xpk: The recipient X25519 public key
+//
+// Returns:
+//   - The XChaCha20 cipher wrapping the local key
+//   - An error if the cipher cannot be created
+func sealCipher(xk []byte, epk []byte, xpk []byte) (*chacha20.Cipher, error) {
+	ek := blake2b.Sum256(concat([]byte{0x01}, []byte(sealHeader), xk, epk, xpk))
+
+	nonce, _ := blake2b.New(24, nil)
+	nonce.Write(concat(epk, xpk))
+
+	return chacha20.NewUnauthenticatedCipher(ek[:], nonce.Sum(nil))
+}
+
+// sealTag computes the authentication tag of a wrapped key.
+func sealTag(xk []byte, epk []byte, xpk []byte, edk []byte) []byte {
+	ak := blake2b.Sum256(concat([]byte{0x02}, []byte(sealHeader), xk, epk, xpk))
+
+	mac, _ := blake2b.New256(ak[:])
+	mac.Write(concat([]byte(sealHeader), epk, edk))
+
+	return mac.Sum(nil)
+}
+
+// x25519PublicKey converts an Ed25519 public key to its birationally equivalent X25519
+// public key, u = (1 + y) / (1 - y) mod 2^255 - 19.
+func x25519PublicKey(key ed25519.PublicKey) (*ecdh.PublicKey, error) {
+	if len(key) != ed25519.PublicKeySize {
+		return nil, errors.New("invalid recipient public key")
+	}
+
+	prime := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+
+	encoded := make([]byte, ed25519.PublicKeySize)
+	copy(encoded, key)
+	encoded[31] &= 0x7f
+
+	y := new(big.Int).SetBytes(reverse(encoded))
+
+	denominator := new(big.Int).Sub(big.NewInt(1), y)
+	denominator.Mod(denominator, prime)
+
+	if denominator.Sign() == 0 {
+		return nil, errors.New("invalid recipient public key")
+	}
+
+	u := new(big.Int).Add(big.NewInt(1), y)
+	u.Mul(u, denominator.ModInverse(denominator, prime))
+	u.Mod(u, prime)
+
+	return ecdh.X25519().NewPublicKey(reverse(u.FillBytes(make([]byte, 32))))
+}
+
+// x25519PrivateKey converts an Ed25519 private key to its X25519 private key.
+func x25519PrivateKey(key ed25519.PrivateKey) (*ecdh.PrivateKey, error) {
+	hash := sha512.Sum512(key.Seed())
+
+	hash[0] &= 248
+	hash[31] &= 127
+	hash[31] |= 64
+
+	return ecdh.X25519().NewPrivateKey(hash[:32])
+}
+
+// reverse returns the bytes in reverse order, converting between little and big endian.
+func reverse(data []byte) []byte {
+	result := make([]byte, len(data))
+
+	for i, b := range data {
+		result[len(data)-1-i] = b
+	}
+
+	return result
+}
+
+// concat joins byte slices into a new slice.
+func concat(parts ...[]byte) []byte {
+	var result []byte
+
+	for _, part := range parts {
+		result = append(result, part...)
+	}
+
+	return result
+}
@@ -0,0 +1,57 @@
+package paseto
+
+import (
+	"crypto/ed25519"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Should wrap a local key that only the recipient can unwrap
+func TestSealLocalKey(t *testing.T) {
+	assert := assert.New(t)
+
+	localKeys, _ := GenerateKey("local", "paserk")
+	localKey, _ := GetLocalKey(localKeys["localKey"])
+
+	sealed, err := SealLocalKey(localKey, keys["publicKey"])
+
+	assert.Nil(err)
+	assert.True(strings.HasPrefix(sealed, "k4.seal."))
+
+	unsealed, err := UnsealLocalKey(sealed, keys["secretKey"])
+
+	assert.Nil(err)
+	assert.Equal(localKey, unsealed)
+
+	_, err = UnsealLocalKey(sealed, keys["otherSecretKey"])
+
+	assert.EqualError(err, "invalid sealed key")
+
+	tampered := sealed[:len(sealed)-2] + "AA"
+
+	_, err = UnsealLocalKey(tampered, keys["secretKey"])
+
+	assert.EqualError(err, "invalid sealed key")
+
+	_, err = SealLocalKey(localKey, "k4.public.c2hvcnQ")
+
+	assert.EqualError(err, "invalid recipient public key")
+}
+
+// Should convert Ed25519 keys to matching X25519 keys
+func TestX25519KeyConversion(t *testing.T) {
+	assert := assert.New(t)
+
+	publicKey, privateKey, _ := ed25519.GenerateKey(nil)
+
+	xpk, err := x25519PublicKey(publicKey)
+
+	assert.Nil(err)
+
+	xsk, err := x25519PrivateKey(privateKey)
+
+	assert.Nil(err)
+	assert.Equal(xsk.PublicKey().Bytes(), xpk.Bytes())
+}
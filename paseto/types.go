@@ -1,6 +1,7 @@
-// Package paseto provides PASETO (Platform-Agnostic Security Tokens) v4 functionality
+// Package paseto provides PASETO (Platform-Agnostic Security Tokens) functionality
 // for the NASPIP protocol. It handles the creation, signing, and verification of tokens
-// using Ed25519 asymmetric keys.
+// using Ed25519 asymmetric keys (v4.public) or NIST P-384 keys (v3.public), and the
+// encryption of tokens using shared symmetric keys (v4.local).
 package paseto
 
 // PasetoSignOptions contains the options for signing a PASETO token.
@@ -32,9 +33,14 @@ type PasetoVerifyOptions struct {
 	Audience    string // Expected audience of the token
 }
 
-// PasetoV4 defines the interface for PASETO v4 token operations.
-// Implementations of this interface provide methods for signing and verifying tokens.
-type PasetoV4 interface {
+// PasetoV4 is the former name of PasetoHandler, kept for compatibility.
+type PasetoV4 = PasetoHandler
+
+// PasetoHandler defines the interface for PASETO token operations, independent of the
+// PASETO version. Implementations of this interface provide methods for signing and verifying
+// tokens, see PasetoV4Handler (v4.public), PasetoV4LocalHandler (v4.local) and
+// PasetoV3Handler (v3.public).
+type PasetoHandler interface {
 	// Sign creates a signed PASETO token with the provided payload and options.
 	Sign(payload []byte, privateKey string, options PasetoSignOptions) (string, error)
 
@@ -0,0 +1,185 @@
+package paseto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/fluxisus/naspip-go/v3/utils"
+	"golang.org/x/crypto/blake2b"
+
+	pasetoV3 "zntr.io/paseto/v3"
+)
+
+// v3KeySize is the size in bytes of a P-384 private key scalar.
+const v3KeySize = 48
+
+// PasetoV3Handler implements the PasetoHandler interface for PASETO v3.public tokens.
+// It signs and verifies tokens using ECDSA over the NIST P-384 curve, for issuers whose
+// key management only supports NIST curves.
+type PasetoV3Handler struct{}
+
+// Sign creates a new PASETO v3.public token with the provided payload and signing options.
+//
+// Parameters:
+//   - payload: Protocol buffer encoded data to include in the token
+//   - privateKey: P-384 private key in raw or PASERK format (k3.secret)
+//   - options: Configuration options for the token
+//
+// Returns:
+//   - A PASETO v3 token string or an error if token creation fails
+func (p PasetoV3Handler) Sign(payload []byte, privateKey string, options PasetoSignOptions) (string, error) {
+	key, err := GetV3PrivateKey(privateKey)
+
+	if err != nil {
+		return "", err
+	}
+
+	dataBytes, err := buildClaims(payload, options)
+
+	if err != nil {
+		return "", err
+	}
+
+	return pasetoV3.Sign(dataBytes, key, options.Footer, options.Assertion)
+}
+
+// Verify validates a PASETO v3.public token using the provided public key and options.
+//
+// Parameters:
+//   - token: PASETO v3 token to verify
+//   - publicKey: P-384 public key in raw or PASERK format (k3.public)
+//   - options: Verification options and expected claims
+//
+// Returns:
+//   - A parsed PasetoCompleteResult containing the token data if verification succeeds
+//   - An error if verification fails for any reason
+func (p PasetoV3Handler) Verify(token string, publicKey string, options PasetoVerifyOptions) (*PasetoCompleteResult, error) {
+	key, err := GetV3PublicKey(publicKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tokenBytes, err := pasetoV3.Verify(token, key, options.Footer, options.Assertion)
+
+	if err != nil {
+		return nil, signatureError(err)
+	}
+
+	payload, err := parseClaims(tokenBytes, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var data = PasetoCompleteResult{Version: "v3", Purpose: "public", Footer: []byte{}, Payload: payload}
+
+	return &data, nil
+}
+
+// GenerateV3Key creates a new P-384 key pair for use with PASETO v3 tokens.
+//
+// Parameters:
+//   - purpose: The PASETO purpose ("public" is the only supported value for v3)
+//   - format: The output format for the keys ("keyobject" or "paserk")
+//
+// Returns:
+//   - A map containing "secretKey" and "publicKey" entries
+//   - An error if the purpose or format is invalid
+//
+// The "keyobject" format returns raw base64url-encoded keys.
+// The "paserk" format returns PASERK-formatted keys (k3.secret/k3.public prefixed).
+func GenerateV3Key(purpose string, format string) (map[string]string, error) {
+	if purpose != "public" {
+		return nil, errors.New("unsupported v3 purpose")
+	}
+
+	if format != "keyobject" && format != "paserk" {
+		return nil, errors.New("invalid format")
+	}
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string)
+
+	result["secretKey"] = utils.EncodeRawURLBase64(privateKey.D.FillBytes(make([]byte, v3KeySize)))
+	result["publicKey"] = utils.EncodeRawURLBase64(elliptic.MarshalCompressed(elliptic.P384(), privateKey.X, privateKey.Y))
+
+	if format == "paserk" {
+		result["secretKey"] = "k3.secret." + result["secretKey"]
+		result["publicKey"] = "k3.public." + result["publicKey"]
+	}
+
+	return result, nil
+}
+
+// GetV3PrivateKey converts a string representation of a P-384 private key
+// to the crypto/ecdsa.PrivateKey type.
+//
+// It supports both raw keys (the 48-byte scalar) and PASERK-formatted keys (k3.secret.* format).
+// For PASERK keys, it extracts and decodes the base64url-encoded portion.
+func GetV3PrivateKey(key string) (*ecdsa.PrivateKey, error) {
+	keyBytes := []byte(key)
+
+	if strings.HasPrefix(key, "k3.secret.") {
+		keyBytes, _ = utils.DecodeRawURLBase64(key[10:])
+	}
+
+	curve := elliptic.P384()
+	d := new(big.Int).SetBytes(keyBytes)
+
+	if len(keyBytes) != v3KeySize || d.Sign() == 0 || d.Cmp(curve.Params().N) >= 0 {
+		return nil, errors.New("invalid v3 secret key")
+	}
+
+	privateKey := &ecdsa.PrivateKey{D: d}
+	privateKey.Curve = curve
+	privateKey.X, privateKey.Y = curve.ScalarBaseMult(keyBytes)
+
+	return privateKey, nil
+}
+
+// GetV3PublicKey converts a string representation of a P-384 public key
+// to the crypto/ecdsa.PublicKey type.
+//
+// It supports both raw keys (the 49-byte compressed point) and PASERK-formatted keys (k3.public.* format).
+// For PASERK keys, it extracts and decodes the base64url-encoded portion.
+func GetV3PublicKey(key string) (*ecdsa.PublicKey, error) {
+	keyBytes := []byte(key)
+
+	if strings.HasPrefix(key, "k3.public.") {
+		keyBytes, _ = utils.DecodeRawURLBase64(key[10:])
+	}
+
+	x, y := elliptic.UnmarshalCompressed(elliptic.P384(), keyBytes)
+
+	if x == nil {
+		return nil, errors.New("invalid v3 public key")
+	}
+
+	return &ecdsa.PublicKey{Curve: elliptic.P384(), X: x, Y: y}, nil
+}
+
+// V3PublicKeyId computes the PASERK key identifier (k3.pid) of a P-384 public key.
+//
+// Parameters:
+//   - key: The P-384 public key
+//
+// Returns:
+//   - The key identifier in "k3.pid.[hash]" format
+func V3PublicKeyId(key *ecdsa.PublicKey) string {
+	const header = "k3.pid."
+
+	hash, _ := blake2b.New(33, nil)
+	hash.Write([]byte(header + "k3.public." + utils.EncodeRawURLBase64(elliptic.MarshalCompressed(elliptic.P384(), key.X, key.Y))))
+
+	return header + utils.EncodeRawURLBase64(hash.Sum(nil))
+}
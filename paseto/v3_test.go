@@ -0,0 +1,110 @@
+package paseto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fluxisus/naspip-go/v3/encoding/protobuf"
+	"github.com/stretchr/testify/assert"
+)
+
+// Should create P-384 key pairs
+func TestGenerateV3Key(t *testing.T) {
+	assert := assert.New(t)
+
+	v3Keys, err := GenerateV3Key("public", "paserk")
+
+	assert.Nil(err)
+	assert.True(strings.HasPrefix(v3Keys["secretKey"], "k3.secret."))
+	assert.True(strings.HasPrefix(v3Keys["publicKey"], "k3.public."))
+
+	privateKey, err := GetV3PrivateKey(v3Keys["secretKey"])
+
+	assert.Nil(err)
+
+	publicKey, err := GetV3PublicKey(v3Keys["publicKey"])
+
+	assert.Nil(err)
+	assert.True(privateKey.PublicKey.Equal(publicKey))
+	assert.True(strings.HasPrefix(PublicKeyIdOf(v3Keys["publicKey"]), "k3.pid."))
+	assert.True(strings.HasPrefix(PublicKeyIdOf(keys["publicKey"]), "k4.pid."))
+
+	_, err = GenerateV3Key("local", "paserk")
+
+	assert.EqualError(err, "unsupported v3 purpose")
+
+	_, err = GetV3PrivateKey(keys["secretKey"])
+
+	assert.EqualError(err, "invalid v3 secret key")
+
+	_, err = GetV3PublicKey(keys["publicKey"])
+
+	assert.EqualError(err, "invalid v3 public key")
+}
+
+// Should sign a v3 token and verify it
+func TestV3SignAndVerify(t *testing.T) {
+	assert := assert.New(t)
+
+	var handler = PasetoV3Handler{}
+	var payload = protobuf.PasetoTokenData{
+		Data: &protobuf.PasetoTokenData_InstructionPayload{
+			InstructionPayload: &protobuf.InstructionPayload{
+				Payment: &protobuf.PaymentInstruction{
+					Id: "test-id",
+				},
+			},
+		},
+	}
+
+	payloadBytes, _ := protobuf.EncodeProto(&payload)
+
+	v3Keys, _ := GenerateV3Key("public", "paserk")
+	otherKeys, _ := GenerateV3Key("public", "paserk")
+
+	token, err := handler.Sign(payloadBytes, v3Keys["secretKey"], PasetoSignOptions{ExpiresIn: "1h", KeyId: "test-kid"})
+
+	assert.Nil(err)
+	assert.True(strings.HasPrefix(token, "v3.public."))
+
+	decoded, err := Decode(token)
+
+	assert.Nil(err)
+	assert.Equal("v3", decoded.Version)
+	assert.Equal("test-kid", decoded.Payload.Kid)
+
+	_, err = DecodeV4(token)
+
+	assert.EqualError(err, "unsupported PASETO version")
+
+	verified, err := handler.Verify(token, v3Keys["publicKey"], PasetoVerifyOptions{})
+
+	assert.Nil(err)
+	assert.Equal("v3", verified.Version)
+	assert.Equal("test-id", verified.Payload.Data["payment"].(map[string]interface{})["id"])
+
+	_, err = handler.Verify(token, otherKeys["publicKey"], PasetoVerifyOptions{})
+
+	assert.EqualError(err, "paseto: invalid token signature")
+}
+
+// Should select the handler from the token header
+func TestHandlerFor(t *testing.T) {
+	assert := assert.New(t)
+
+	handler, _ := HandlerFor("v3.public.payload")
+
+	assert.Equal(PasetoV3Handler{}, handler)
+
+	handler, _ = HandlerFor("v4.public.payload")
+
+	assert.Equal(PasetoV4Handler{}, handler)
+
+	handler, _ = HandlerFor("v4.local.payload")
+
+	assert.Equal(PasetoV4LocalHandler{}, handler)
+
+	_, err := HandlerFor("v2.public.payload")
+
+	assert.EqualError(err, "unsupported PASETO version")
+}
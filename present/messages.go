@@ -0,0 +1,262 @@
+package present
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// defaultLanguage is the language used when the requested locale is not supported.
+const defaultLanguage = "en"
+
+// message identifies a localised text.
+type message string
+
+const (
+	msgPayTo           message = "pay_to"
+	msgUnknownMerchant message = "unknown_merchant"
+	msgAmount          message = "amount"
+	msgAnyAmount       message = "any_amount"
+	msgAtLeast         message = "at_least"
+	msgUpTo            message = "up_to"
+	msgRange           message = "range"
+	msgNetwork         message = "network"
+	msgAddress         message = "address"
+	msgAddressTag      message = "address_tag"
+	msgExpiresIn       message = "expires_in"
+	msgExpired         message = "expired"
+	msgOrder           message = "order"
+	msgItem            message = "item"
+	msgQuantity        message = "quantity"
+	msgTax             message = "tax"
+	msgDiscount        message = "discount"
+	msgShipping        message = "shipping"
+	msgTotal           message = "total"
+	msgWarnings        message = "warnings"
+
+	msgWarningOpenAmount      message = "warning_open_amount"
+	msgWarningNearExpiry      message = "warning_near_expiry"
+	msgWarningExpired         message = "warning_expired"
+	msgWarningAddressTag      message = "warning_address_tag"
+	msgWarningMissingMerchant message = "warning_missing_merchant"
+	msgWarningUnknownAsset    message = "warning_unknown_asset"
+)
+
+// catalog holds the texts of each base language of the locale package, so that regional
+// variants such as "de-AT" or "pt-PT" use the texts of their language. Texts are fmt formats.
+var catalog = map[string]map[message]string{
+	"en": {
+		msgPayTo:           "Pay %s",
+		msgUnknownMerchant: "Unknown merchant",
+		msgAmount:          "Amount",
+		msgAnyAmount:       "Any amount of %s",
+		msgAtLeast:         "At least %s",
+		msgUpTo:            "Up to %s",
+		msgRange:           "%s to %s",
+		msgNetwork:         "Network",
+		msgAddress:         "Address",
+		msgAddressTag:      "Memo / tag",
+		msgExpiresIn:       "Expires in %s",
+		msgExpired:         "Expired",
+		msgOrder:           "Order",
+		msgItem:            "Item",
+		msgQuantity:        "Qty",
+		msgTax:             "Tax",
+		msgDiscount:        "Discount",
+		msgShipping:        "Shipping",
+		msgTotal:           "Total",
+		msgWarnings:        "Warnings",
+
+		msgWarningOpenAmount:      "The amount is not fixed: check it before confirming.",
+		msgWarningNearExpiry:      "The payment instruction expires in %s.",
+		msgWarningExpired:         "The payment instruction has expired: do not pay it.",
+		msgWarningAddressTag:      "Include the memo / tag %s: payments without it may be lost.",
+		msgWarningMissingMerchant: "The merchant is not identified.",
+		msgWarningUnknownAsset:    "The asset %s is not recognised by this wallet.",
+	},
+	"es": {
+		msgPayTo:           "Pagar a %s",
+		msgUnknownMerchant: "Comercio desconocido",
+		msgAmount:          "Monto",
+		msgAnyAmount:       "Cualquier monto de %s",
+		msgAtLeast:         "Al menos %s",
+		msgUpTo:            "Hasta %s",
+		msgRange:           "De %s a %s",
+		msgNetwork:         "Red",
+		msgAddress:         "Dirección",
+		msgAddressTag:      "Memo / etiqueta",
+		msgExpiresIn:       "Vence en %s",
+		msgExpired:         "Vencida",
+		msgOrder:           "Orden",
+		msgItem:            "Ítem",
+		msgQuantity:        "Cant.",
+		msgTax:             "Impuesto",
+		msgDiscount:        "Descuento",
+		msgShipping:        "Envío",
+		msgTotal:           "Total",
+		msgWarnings:        "Advertencias",
+
+		msgWarningOpenAmount:      "El monto no es fijo: verifícalo antes de confirmar.",
+		msgWarningNearExpiry:      "La instrucción de pago vence en %s.",
+		msgWarningExpired:         "La instrucción de pago venció: no la pagues.",
+		msgWarningAddressTag:      "Incluye el memo / etiqueta %s: los pagos sin él pueden perderse.",
+		msgWarningMissingMerchant: "El comercio no está identificado.",
+		msgWarningUnknownAsset:    "Esta billetera no reconoce el activo %s.",
+	},
+	"pt": {
+		msgPayTo:           "Pagar a %s",
+		msgUnknownMerchant: "Comerciante desconhecido",
+		msgAmount:          "Valor",
+		msgAnyAmount:       "Qualquer valor de %s",
+		msgAtLeast:         "No mínimo %s",
+		msgUpTo:            "Até %s",
+		msgRange:           "De %s a %s",
+		msgNetwork:         "Rede",
+		msgAddress:         "Endereço",
+		msgAddressTag:      "Memo / tag",
+		msgExpiresIn:       "Expira em %s",
+		msgExpired:         "Expirada",
+		msgOrder:           "Pedido",
+		msgItem:            "Item",
+		msgQuantity:        "Qtd.",
+		msgTax:             "Imposto",
+		msgDiscount:        "Desconto",
+		msgShipping:        "Frete",
+		msgTotal:           "Total",
+		msgWarnings:        "Avisos",
+
+		msgWarningOpenAmount:      "O valor não é fixo: confira antes de confirmar.",
+		msgWarningNearExpiry:      "A instrução de pagamento expira em %s.",
+		msgWarningExpired:         "A instrução de pagamento expirou: não a pague.",
+		msgWarningAddressTag:      "Inclua o memo / tag %s: pagamentos sem ele podem ser perdidos.",
+		msgWarningMissingMerchant: "O comerciante não está identificado.",
+		msgWarningUnknownAsset:    "O ativo %s não é reconhecido por esta carteira.",
+	},
+	"fr": {
+		msgPayTo:           "Payer %s",
+		msgUnknownMerchant: "Commerçant inconnu",
+		msgAmount:          "Montant",
+		msgAnyAmount:       "Montant libre en %s",
+		msgAtLeast:         "Au moins %s",
+		msgUpTo:            "Jusqu'à %s",
+		msgRange:           "De %s à %s",
+		msgNetwork:         "Réseau",
+		msgAddress:         "Adresse",
+		msgAddressTag:      "Mémo / tag",
+		msgExpiresIn:       "Expire dans %s",
+		msgExpired:         "Expirée",
+		msgOrder:           "Commande",
+		msgItem:            "Article",
+		msgQuantity:        "Qté",
+		msgTax:             "Taxe",
+		msgDiscount:        "Remise",
+		msgShipping:        "Livraison",
+		msgTotal:           "Total",
+		msgWarnings:        "Avertissements",
+
+		msgWarningOpenAmount:      "Le montant n'est pas fixe : vérifiez-le avant de confirmer.",
+		msgWarningNearExpiry:      "L'instruction de paiement expire dans %s.",
+		msgWarningExpired:         "L'instruction de paiement a expiré : ne la payez pas.",
+		msgWarningAddressTag:      "Indiquez le mémo / tag %s : les paiements sans celui-ci peuvent être perdus.",
+		msgWarningMissingMerchant: "Le commerçant n'est pas identifié.",
+		msgWarningUnknownAsset:    "L'actif %s n'est pas reconnu par ce portefeuille.",
+	},
+	"de": {
+		msgPayTo:           "An %s zahlen",
+		msgUnknownMerchant: "Unbekannter Händler",
+		msgAmount:          "Betrag",
+		msgAnyAmount:       "Beliebiger Betrag in %s",
+		msgAtLeast:         "Mindestens %s",
+		msgUpTo:            "Bis zu %s",
+		msgRange:           "%s bis %s",
+		msgNetwork:         "Netzwerk",
+		msgAddress:         "Adresse",
+		msgAddressTag:      "Memo / Tag",
+		msgExpiresIn:       "Läuft ab in %s",
+		msgExpired:         "Abgelaufen",
+		msgOrder:           "Bestellung",
+		msgItem:            "Artikel",
+		msgQuantity:        "Menge",
+		msgTax:             "Steuer",
+		msgDiscount:        "Rabatt",
+		msgShipping:        "Versand",
+		msgTotal:           "Gesamt",
+		msgWarnings:        "Warnungen",
+
+		msgWarningOpenAmount:      "Der Betrag ist nicht festgelegt: Prüfen Sie ihn vor der Bestätigung.",
+		msgWarningNearExpiry:      "Die Zahlungsanweisung läuft in %s ab.",
+		msgWarningExpired:         "Die Zahlungsanweisung ist abgelaufen: Bezahlen Sie sie nicht.",
+		msgWarningAddressTag:      "Geben Sie das Memo / den Tag %s an: Zahlungen ohne Angabe können verloren gehen.",
+		msgWarningMissingMerchant: "Der Händler ist nicht identifiziert.",
+		msgWarningUnknownAsset:    "Das Asset %s wird von dieser Wallet nicht erkannt.",
+	},
+	"it": {
+		msgPayTo:           "Paga %s",
+		msgUnknownMerchant: "Esercente sconosciuto",
+		msgAmount:          "Importo",
+		msgAnyAmount:       "Importo libero in %s",
+		msgAtLeast:         "Almeno %s",
+		msgUpTo:            "Fino a %s",
+		msgRange:           "Da %s a %s",
+		msgNetwork:         "Rete",
+		msgAddress:         "Indirizzo",
+		msgAddressTag:      "Memo / tag",
+		msgExpiresIn:       "Scade tra %s",
+		msgExpired:         "Scaduta",
+		msgOrder:           "Ordine",
+		msgItem:            "Articolo",
+		msgQuantity:        "Qtà",
+		msgTax:             "Imposta",
+		msgDiscount:        "Sconto",
+		msgShipping:        "Spedizione",
+		msgTotal:           "Totale",
+		msgWarnings:        "Avvisi",
+
+		msgWarningOpenAmount:      "L'importo non è fisso: verificalo prima di confermare.",
+		msgWarningNearExpiry:      "L'istruzione di pagamento scade tra %s.",
+		msgWarningExpired:         "L'istruzione di pagamento è scaduta: non pagarla.",
+		msgWarningAddressTag:      "Includi il memo / tag %s: i pagamenti senza di esso possono andare persi.",
+		msgWarningMissingMerchant: "L'esercente non è identificato.",
+		msgWarningUnknownAsset:    "L'asset %s non è riconosciuto da questo wallet.",
+	},
+}
+
+// Languages returns the languages with a message catalog, sorted.
+func Languages() []string {
+	languages := make([]string, 0, len(catalog))
+
+	for lang := range catalog {
+		languages = append(languages, lang)
+	}
+
+	slices.Sort(languages)
+
+	return languages
+}
+
+// language returns the supported language of a locale, such as "es" for "es-AR", or the
+// default language.
+func language(locale string) string {
+	base, _, _ := strings.Cut(strings.ToLower(strings.ReplaceAll(locale, "_", "-")), "-")
+
+	if _, ok := catalog[base]; ok {
+		return base
+	}
+
+	return defaultLanguage
+}
+
+// text returns the localised text of a message, formatted with the arguments.
+func text(lang string, key message, args ...any) string {
+	format, ok := catalog[lang][key]
+
+	if !ok {
+		format = catalog[defaultLanguage][key]
+	}
+
+	if len(args) == 0 {
+		return format
+	}
+
+	return fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,48 @@
+package present
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fluxisus/naspip-go/v3/locale"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Should resolve locales to their supported language
+func TestLanguage(t *testing.T) {
+	tests := []struct {
+		locale   string
+		expected string
+	}{
+		{locale: "es-AR", expected: "es"},
+		{locale: "pt_BR", expected: "pt"},
+		{locale: "EN-us", expected: "en"},
+		{locale: "fr-FR", expected: "fr"},
+		{locale: "de-AT", expected: "de"},
+		{locale: "it_IT", expected: "it"},
+		{locale: "ja-JP", expected: "en"},
+		{locale: "", expected: "en"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, language(test.locale), test.locale)
+	}
+}
+
+// Should define every message in every language
+func TestCatalogComplete(t *testing.T) {
+	assert.Equal(t, []string{"de", "en", "es", "fr", "it", "pt"}, Languages())
+
+	// Every base language of the locale package has a catalog
+	for _, tag := range locale.Tags() {
+		base, _, _ := strings.Cut(tag, "-")
+		assert.Contains(t, Languages(), base, tag)
+	}
+
+	for _, lang := range Languages() {
+		for key := range catalog[defaultLanguage] {
+			assert.NotEmpty(t, catalog[lang][key], "%s: %s", lang, key)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+// Package present turns a verified payment instruction into the content of a wallet
+// confirmation screen: the merchant, the amount with the asset symbol, the network, the
+// address and memo, the expiry countdown and the order lines, in the payer's language, with
+// amounts and dates formatted for the payer's locale by the locale package.
+//
+// Summarize returns a structured Summary that wallets lay out themselves, along with the
+// Warnings the payer must see before confirming. Summary.Text and Summary.Markdown render it
+// for terminals, chat bots and notifications.
+package present
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/locale"
+	"github.com/fluxisus/naspip-go/v3/protocol"
+)
+
+// defaultNearExpiry is the default remaining time under which a near expiry warning is given.
+const defaultNearExpiry = 2 * time.Minute
+
+// Warning codes.
+const (
+	WarningOpenAmount      = "open_amount"      // The payer chooses the amount
+	WarningNearExpiry      = "near_expiry"      // The instruction expires soon
+	WarningExpired         = "expired"          // The instruction has expired
+	WarningAddressTag      = "address_tag"      // The payment must carry the address tag
+	WarningMissingMerchant = "missing_merchant" // The instruction does not identify the merchant
+	WarningUnknownAsset    = "unknown_asset"    // The asset is not in the asset registry
+)
+
+// Options configures a summary.
+type Options struct {
+	Locale     string                  // Language of the payer as a BCP 47 tag (e.g., es-AR), English when unsupported
+	Assets     *protocol.AssetRegistry // Registry giving the symbol and network of the assets, may be nil
+	Clock      protocol.Clock          // Clock used for the expiry countdown, protocol.SystemClock when nil
+	NearExpiry time.Duration           // Remaining time under which a near expiry warning is given, 2m when zero
+	Location   *time.Location          // Location of the payer for the expiry date and time, UTC when nil
+}
+
+// Summary is the content of a confirmation screen.
+type Summary struct {
+	Language      string        // Language of the texts
+	Title         string        // Title of the screen (e.g., "Pay Coffee Shop")
+	Merchant      string        // Merchant name, empty when the instruction does not identify it
+	Amount        string        // Amount to pay in the payer's locale, with the asset symbol
+	IsOpen        bool          // Whether the payer chooses the amount
+	UniqueAssetId string        // Asset identifier
+	Symbol        string        // Asset symbol, the unique asset ID when the asset is unknown
+	AssetName     string        // Human readable name of the asset, if known
+	Network       string        // Network of the asset, if known
+	Address       string        // Recipient address
+	AddressTag    string        // Tag or memo the payment must carry, if any
+	ExpiresAt     int64         // Unix timestamp (milliseconds) when the instruction expires, zero when it does not expire
+	ExpiresIn     time.Duration // Remaining time before expiry, zero or negative when expired, zero when it does not expire
+	Expiry        string        // Expiry countdown (e.g., "Expires in 14m 30s"), empty when it does not expire
+	ExpiresOn     string        // Expiry date and time in the payer's locale and location (e.g., "May 1, 2024, 12:14 PM"), empty when it does not expire
+	Order         *Order        // Order details, if any
+	Warnings      []Warning     // Warnings to show before confirming
+}
+
+// Order is the order section of a summary.
+type Order struct {
+	Description string // Order description
+	Lines       []Line // Items, taxes, discounts and shipping, in this order
+	Total       string // Order total, with the currency symbol
+}
+
+// LineKind is the kind of an order line.
+type LineKind string
+
+const (
+	LineItem     LineKind = "item"     // A purchased item
+	LineTax      LineKind = "tax"      // A tax
+	LineDiscount LineKind = "discount" // A discount, with a negative amount
+	LineShipping LineKind = "shipping" // The shipping cost
+)
+
+// Line is an order line.
+type Line struct {
+	Kind     LineKind // Kind of line
+	Label    string   // Description of the line
+	Quantity int      // Number of units, zero when not applicable
+	Amount   string   // Amount of the line, with the currency symbol
+}
+
+// Warning is a message the payer must see before confirming.
+type Warning struct {
+	Code    string // Stable warning code, see the Warning constants
+	Message string // Localised message
+}
+
+// HasWarning reports whether the summary carries a warning with the given code.
+func (s Summary) HasWarning(code string) bool {
+	for _, warning := range s.Warnings {
+		if warning.Code == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Summarize builds the confirmation summary of a payment instruction. The payload must have
+// been verified, for example with PaymentInstructionsBuilder.Read.
+//
+// Parameters:
+//   - payload: The payment instruction
+//   - options: Locale, asset registry and clock
+//
+// Returns:
+//   - The summary
+func Summarize(payload protocol.InstructionPayload, options Options) Summary {
+	lang := language(options.Locale)
+	loc := locale.Lookup(options.Locale)
+	payment := payload.Payment
+
+	summary := Summary{
+		Language:      lang,
+		IsOpen:        payment.IsOpen,
+		UniqueAssetId: payment.UniqueAssetId,
+		Symbol:        payment.UniqueAssetId,
+		Address:       payment.Address,
+		AddressTag:    payment.AddressTag,
+		ExpiresAt:     payment.ExpiresAt,
+	}
+
+	var asset *protocol.Asset
+
+	if options.Assets != nil {
+		if registered, ok := options.Assets.Get(payment.UniqueAssetId); ok {
+			asset = &registered
+			summary.Symbol = asset.Symbol
+			summary.AssetName = asset.DisplayName
+			summary.Network = asset.Network
+		}
+	}
+
+	if payload.Order != nil && payload.Order.Merchant != nil {
+		summary.Merchant = strings.TrimSpace(payload.Order.Merchant.Name)
+	}
+
+	if summary.Merchant != "" {
+		summary.Title = text(lang, msgPayTo, summary.Merchant)
+	} else {
+		summary.Title = text(lang, msgPayTo, text(lang, msgUnknownMerchant))
+	}
+
+	summary.Amount = paymentAmount(lang, loc, payment, asset, summary.Symbol)
+
+	clock := options.Clock
+
+	if clock == nil {
+		clock = protocol.SystemClock{}
+	}
+
+	// Instructions without expiration (ExpiresAt zero) have no countdown
+	if payment.ExpiresAt != 0 {
+		summary.ExpiresIn = time.UnixMilli(payment.ExpiresAt).Sub(clock.Now()).Truncate(time.Second)
+
+		if summary.ExpiresIn > 0 {
+			summary.Expiry = text(lang, msgExpiresIn, formatDuration(summary.ExpiresIn))
+		} else {
+			summary.Expiry = text(lang, msgExpired)
+		}
+
+		summary.ExpiresOn = loc.FormatExpiry(payment.ExpiresAt, clock.Now(), options.Location).Absolute
+	}
+
+	if payload.Order != nil {
+		summary.Order = orderSummary(lang, loc, *payload.Order)
+	}
+
+	summary.Warnings = warnings(lang, summary, options)
+
+	return summary
+}
+
+// paymentAmount returns the amount to pay. Open amounts show their range.
+func paymentAmount(lang string, loc locale.Locale, payment protocol.PaymentInstruction, asset *protocol.Asset, symbol string) string {
+	amount := func(value string) string {
+		return assetAmount(loc, value, asset, symbol)
+	}
+
+	if !payment.IsOpen {
+		return amount(payment.Amount)
+	}
+
+	switch {
+	case payment.MinAmount != "" && payment.MaxAmount != "":
+		return text(lang, msgRange, amount(payment.MinAmount), amount(payment.MaxAmount))
+	case payment.MinAmount != "":
+		return text(lang, msgAtLeast, amount(payment.MinAmount))
+	case payment.MaxAmount != "":
+		return text(lang, msgUpTo, amount(payment.MaxAmount))
+	}
+
+	return text(lang, msgAnyAmount, symbol)
+}
+
+// orderSummary returns the order section of a summary.
+func orderSummary(lang string, loc locale.Locale, order protocol.InstructionOrder) *Order {
+	summary := &Order{Description: order.Description, Total: currencyAmount(loc, order.Total, order.CoinCode)}
+
+	for _, item := range order.Items {
+		coinCode := item.CoinCode
+
+		if coinCode == "" {
+			coinCode = order.CoinCode
+		}
+
+		summary.Lines = append(summary.Lines, Line{
+			Kind:     LineItem,
+			Label:    item.Description,
+			Quantity: item.Quantity,
+			Amount:   currencyAmount(loc, item.Amount, coinCode),
+		})
+	}
+
+	for _, tax := range order.Taxes {
+		label := tax.TaxType
+
+		if label == "" {
+			label = text(lang, msgTax)
+		}
+
+		if tax.Rate != "" {
+			label = fmt.Sprintf("%s (%s%%)", label, tax.Rate)
+		}
+
+		summary.Lines = append(summary.Lines, Line{Kind: LineTax, Label: label, Amount: currencyAmount(loc, tax.Amount, order.CoinCode)})
+	}
+
+	for _, discount := range order.Discounts {
+		label := discount.Description
+
+		if label == "" {
+			label = text(lang, msgDiscount)
+		}
+
+		summary.Lines = append(summary.Lines, Line{Kind: LineDiscount, Label: label, Amount: currencyAmount(loc, "-"+discount.Amount, order.CoinCode)})
+	}
+
+	if order.Shipping != "" {
+		summary.Lines = append(summary.Lines, Line{Kind: LineShipping, Label: text(lang, msgShipping), Amount: currencyAmount(loc, order.Shipping, order.CoinCode)})
+	}
+
+	return summary
+}
+
+// warnings returns the warnings of a summary.
+func warnings(lang string, summary Summary, options Options) []Warning {
+	nearExpiry := options.NearExpiry
+
+	if nearExpiry <= 0 {
+		nearExpiry = defaultNearExpiry
+	}
+
+	result := []Warning{}
+
+	if summary.ExpiresAt != 0 {
+		if summary.ExpiresIn <= 0 {
+			result = append(result, Warning{Code: WarningExpired, Message: text(lang, msgWarningExpired)})
+		} else if summary.ExpiresIn <= nearExpiry {
+			result = append(result, Warning{Code: WarningNearExpiry, Message: text(lang, msgWarningNearExpiry, formatDuration(summary.ExpiresIn))})
+		}
+	}
+
+	if summary.AddressTag != "" {
+		result = append(result, Warning{Code: WarningAddressTag, Message: text(lang, msgWarningAddressTag, summary.AddressTag)})
+	}
+
+	if summary.IsOpen {
+		result = append(result, Warning{Code: WarningOpenAmount, Message: text(lang, msgWarningOpenAmount)})
+	}
+
+	if summary.Merchant == "" {
+		result = append(result, Warning{Code: WarningMissingMerchant, Message: text(lang, msgWarningMissingMerchant)})
+	}
+
+	if options.Assets != nil {
+		if _, ok := options.Assets.Get(summary.UniqueAssetId); !ok {
+			result = append(result, Warning{Code: WarningUnknownAsset, Message: text(lang, msgWarningUnknownAsset, summary.UniqueAssetId)})
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,256 @@
+package present
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/protocol"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const usdt = "ntrc20_tTR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+
+var now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+// fixedClock is a protocol.Clock returning a fixed time
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+// registry returns an asset registry with USDT on Tron
+func registry(t *testing.T) *protocol.AssetRegistry {
+	assets, err := protocol.NewAssetRegistry(protocol.Asset{
+		UniqueAssetId: usdt, Symbol: "USDT", Decimals: 6, Network: "tron", DisplayName: "Tether USD",
+	})
+
+	if err != nil {
+		t.Fatalf("registry FAIL --> %v", err)
+	}
+
+	return assets
+}
+
+// coffeeOrder returns an instruction paying a coffee shop order
+func coffeeOrder() protocol.InstructionPayload {
+	return protocol.InstructionPayload{
+		Payment: protocol.PaymentInstruction{
+			Id:            "payment-id",
+			Address:       "TQmTzBAXYGhP9FdKWZZHLxG9MQK8kKYvN2",
+			UniqueAssetId: usdt,
+			Amount:        "12.1",
+			ExpiresAt:     now.Add(14*time.Minute + 30*time.Second + 400*time.Millisecond).UnixMilli(),
+		},
+		Order: &protocol.InstructionOrder{
+			Total:       "12.10",
+			CoinCode:    "USD",
+			Description: "Order #42",
+			Merchant:    &protocol.InstructionMerchant{Name: "Coffee Shop"},
+			Items: []protocol.InstructionItem{
+				{Description: "Latte", Amount: "10.00", Quantity: 2, UnitPrice: "5.00"},
+			},
+			Taxes:     []protocol.InstructionTax{{TaxType: "VAT", Rate: "21", Amount: "2.10"}},
+			Discounts: []protocol.InstructionDiscount{{Description: "WELCOME", Amount: "1.00"}},
+			Shipping:  "1.00",
+		},
+	}
+}
+
+// Should summarize a fixed amount instruction with its order
+func TestSummarize(t *testing.T) {
+	assert := assert.New(t)
+
+	summary := Summarize(coffeeOrder(), Options{Assets: registry(t), Clock: fixedClock{now}})
+
+	assert.Equal("en", summary.Language)
+	assert.Equal("Pay Coffee Shop", summary.Title)
+	assert.Equal("Coffee Shop", summary.Merchant)
+	assert.Equal("USDT\u00a012.10", summary.Amount)
+	assert.Equal("USDT", summary.Symbol)
+	assert.Equal("Tether USD", summary.AssetName)
+	assert.Equal("tron", summary.Network)
+	assert.Equal(14*time.Minute+30*time.Second, summary.ExpiresIn)
+	assert.Equal("Expires in 14m 30s", summary.Expiry)
+	assert.Equal("May 1, 2024, 12:14 PM", summary.ExpiresOn)
+	assert.Empty(summary.Warnings)
+
+	assert.Equal(&Order{
+		Description: "Order #42",
+		Total:       "$12.10",
+		Lines: []Line{
+			{Kind: LineItem, Label: "Latte", Quantity: 2, Amount: "$10.00"},
+			{Kind: LineTax, Label: "VAT (21%)", Amount: "$2.10"},
+			{Kind: LineDiscount, Label: "WELCOME", Amount: "-$1.00"},
+			{Kind: LineShipping, Label: "Shipping", Amount: "$1.00"},
+		},
+	}, summary.Order)
+}
+
+// Should describe open amounts
+func TestSummarizeOpenAmount(t *testing.T) {
+	tests := []struct {
+		name      string
+		minAmount string
+		maxAmount string
+		locale    string
+		amount    string
+	}{
+		{name: "range", minAmount: "1", maxAmount: "100", amount: "USDT\u00a01.00 to USDT\u00a0100.00"},
+		{name: "minimum", minAmount: "5", amount: "At least USDT\u00a05.00"},
+		{name: "maximum", maxAmount: "50", amount: "Up to USDT\u00a050.00"},
+		{name: "any", amount: "Any amount of USDT"},
+		{name: "range in spanish", minAmount: "1", maxAmount: "1500", locale: "es-AR", amount: "De USDT\u00a01,00 a USDT\u00a01.500,00"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			payload := coffeeOrder()
+			payload.Payment.IsOpen = true
+			payload.Payment.Amount = ""
+			payload.Payment.MinAmount = test.minAmount
+			payload.Payment.MaxAmount = test.maxAmount
+
+			summary := Summarize(payload, Options{Locale: test.locale, Assets: registry(t), Clock: fixedClock{now}})
+
+			assert.Equal(t, test.amount, summary.Amount)
+			assert.True(t, summary.IsOpen)
+			assert.True(t, summary.HasWarning(WarningOpenAmount))
+		})
+	}
+}
+
+// Should warn about conditions the payer must check
+func TestSummarizeWarnings(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(payload *protocol.InstructionPayload)
+		options  Options
+		warnings []string
+	}{
+		{
+			name: "near expiry",
+			modify: func(payload *protocol.InstructionPayload) {
+				payload.Payment.ExpiresAt = now.Add(90 * time.Second).UnixMilli()
+			},
+			warnings: []string{WarningNearExpiry},
+		},
+		{
+			name:     "custom near expiry",
+			modify:   func(payload *protocol.InstructionPayload) {},
+			options:  Options{NearExpiry: 20 * time.Minute},
+			warnings: []string{WarningNearExpiry},
+		},
+		{
+			name:     "expired",
+			modify:   func(payload *protocol.InstructionPayload) { payload.Payment.ExpiresAt = now.UnixMilli() },
+			warnings: []string{WarningExpired},
+		},
+		{
+			name:     "address tag",
+			modify:   func(payload *protocol.InstructionPayload) { payload.Payment.AddressTag = "104729" },
+			warnings: []string{WarningAddressTag},
+		},
+		{
+			name:     "missing merchant",
+			modify:   func(payload *protocol.InstructionPayload) { payload.Order.Merchant = nil },
+			warnings: []string{WarningMissingMerchant},
+		},
+		{
+			name:     "missing order",
+			modify:   func(payload *protocol.InstructionPayload) { payload.Order = nil },
+			warnings: []string{WarningMissingMerchant},
+		},
+		{
+			name: "unknown asset",
+			modify: func(payload *protocol.InstructionPayload) {
+				payload.Payment.UniqueAssetId = "nbsc_t0x55d398326f99059fF775485246999027B3197955"
+			},
+			warnings: []string{WarningUnknownAsset},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			payload := coffeeOrder()
+			test.modify(&payload)
+
+			options := test.options
+			options.Clock = fixedClock{now}
+			options.Assets = registry(t)
+
+			summary := Summarize(payload, options)
+
+			codes := []string{}
+
+			for _, warning := range summary.Warnings {
+				codes = append(codes, warning.Code)
+				assert.NotEmpty(t, warning.Message)
+			}
+
+			assert.Equal(t, test.warnings, codes)
+		})
+	}
+}
+
+// Should fall back to the unique asset ID and skip the asset check without a registry
+func TestSummarizeWithoutRegistry(t *testing.T) {
+	assert := assert.New(t)
+
+	payload := coffeeOrder()
+	payload.Order = nil
+
+	summary := Summarize(payload, Options{Locale: "es", Clock: fixedClock{now}})
+
+	assert.Equal("Pagar a Comercio desconocido", summary.Title)
+	assert.Equal("12.1 "+usdt, summary.Amount)
+	assert.Equal("", summary.Network)
+	assert.Nil(summary.Order)
+	assert.Equal([]Warning{{Code: WarningMissingMerchant, Message: "El comercio no está identificado."}}, summary.Warnings)
+}
+
+// Should leave the expiry empty and not warn about it for instructions without expiration
+func TestSummarizeWithoutExpiry(t *testing.T) {
+	assert := assert.New(t)
+
+	payload := coffeeOrder()
+	payload.Payment.ExpiresAt = 0
+
+	summary := Summarize(payload, Options{Assets: registry(t), Clock: fixedClock{now}})
+
+	assert.Equal(int64(0), summary.ExpiresAt)
+	assert.Equal(time.Duration(0), summary.ExpiresIn)
+	assert.Empty(summary.Expiry)
+	assert.Empty(summary.ExpiresOn)
+	assert.False(summary.HasWarning(WarningExpired))
+	assert.False(summary.HasWarning(WarningNearExpiry))
+	assert.NotContains(summary.Text(), "Expire")
+	assert.NotContains(summary.Markdown(), "Expire")
+}
+
+// Should format amounts and the expiry date for the payer's locale and location
+func TestSummarizeLocale(t *testing.T) {
+	assert := assert.New(t)
+
+	payload := coffeeOrder()
+	payload.Payment.Amount = "1234.5"
+	payload.Order.CoinCode = "EUR"
+	payload.Order.Total = "1234.5"
+
+	summary := Summarize(payload, Options{
+		Locale:   "de-DE",
+		Assets:   registry(t),
+		Clock:    fixedClock{now},
+		Location: time.FixedZone("CEST", 2*60*60),
+	})
+
+	assert.Equal("de", summary.Language)
+	assert.Equal("An Coffee Shop zahlen", summary.Title)
+	assert.Equal("1.234,50\u00a0USDT", summary.Amount)
+	assert.Equal("1.234,50\u00a0€", summary.Order.Total)
+	assert.Equal("-1,00\u00a0€", summary.Order.Lines[2].Amount)
+	assert.Equal("01.05.2024, 14:14", summary.ExpiresOn)
+}
@@ -0,0 +1,205 @@
+package present
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/locale"
+	"github.com/fluxisus/naspip-go/v3/protocol"
+)
+
+// markdownEscaper escapes the characters with a meaning in Markdown.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "|", `\|`, "<", `\<`, ">", `\>`, "#", `\#`,
+)
+
+// Text renders the summary as plain text, one field per line.
+//
+// Returns:
+//   - The plain text summary
+func (s Summary) Text() string {
+	var b strings.Builder
+
+	b.WriteString(s.Title + "\n")
+	fmt.Fprintf(&b, "%s: %s\n", text(s.Language, msgAmount), s.Amount)
+
+	if s.Network != "" {
+		fmt.Fprintf(&b, "%s: %s\n", text(s.Language, msgNetwork), s.Network)
+	}
+
+	fmt.Fprintf(&b, "%s: %s\n", text(s.Language, msgAddress), s.Address)
+
+	if s.AddressTag != "" {
+		fmt.Fprintf(&b, "%s: %s\n", text(s.Language, msgAddressTag), s.AddressTag)
+	}
+
+	if s.Expiry != "" {
+		b.WriteString(s.Expiry + "\n")
+	}
+
+	if s.Order != nil {
+		b.WriteString("\n" + text(s.Language, msgOrder))
+
+		if s.Order.Description != "" {
+			b.WriteString(": " + s.Order.Description)
+		}
+
+		b.WriteString("\n")
+
+		for _, line := range s.Order.Lines {
+			fmt.Fprintf(&b, "  %s: %s\n", lineLabel(line), line.Amount)
+		}
+
+		fmt.Fprintf(&b, "  %s: %s\n", text(s.Language, msgTotal), s.Order.Total)
+	}
+
+	if len(s.Warnings) > 0 {
+		b.WriteString("\n" + text(s.Language, msgWarnings) + ":\n")
+
+		for _, warning := range s.Warnings {
+			b.WriteString("  ! " + warning.Message + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// Markdown renders the summary as Markdown, with the order lines in a table and the warnings
+// in a block quote.
+//
+// Returns:
+//   - The Markdown summary
+func (s Summary) Markdown() string {
+	var b strings.Builder
+
+	b.WriteString("### " + escapeMarkdown(s.Title) + "\n\n")
+	fmt.Fprintf(&b, "- **%s:** %s\n", text(s.Language, msgAmount), escapeMarkdown(s.Amount))
+
+	if s.Network != "" {
+		fmt.Fprintf(&b, "- **%s:** %s\n", text(s.Language, msgNetwork), escapeMarkdown(s.Network))
+	}
+
+	fmt.Fprintf(&b, "- **%s:** %s\n", text(s.Language, msgAddress), codeSpan(s.Address))
+
+	if s.AddressTag != "" {
+		fmt.Fprintf(&b, "- **%s:** %s\n", text(s.Language, msgAddressTag), codeSpan(s.AddressTag))
+	}
+
+	if s.Expiry != "" {
+		fmt.Fprintf(&b, "- _%s_\n", escapeMarkdown(s.Expiry))
+	}
+
+	if s.Order != nil {
+		b.WriteString("\n#### " + text(s.Language, msgOrder))
+
+		if s.Order.Description != "" {
+			b.WriteString(": " + escapeMarkdown(s.Order.Description))
+		}
+
+		b.WriteString("\n\n")
+		fmt.Fprintf(&b, "| %s | %s | %s |\n", text(s.Language, msgItem), text(s.Language, msgQuantity), text(s.Language, msgAmount))
+		b.WriteString("| --- | ---: | ---: |\n")
+
+		for _, line := range s.Order.Lines {
+			quantity := ""
+
+			if line.Quantity > 0 {
+				quantity = strconv.Itoa(line.Quantity)
+			}
+
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", escapeMarkdown(line.Label), quantity, escapeMarkdown(line.Amount))
+		}
+
+		fmt.Fprintf(&b, "| **%s** | | **%s** |\n", text(s.Language, msgTotal), escapeMarkdown(s.Order.Total))
+	}
+
+	if len(s.Warnings) > 0 {
+		b.WriteString("\n> **" + text(s.Language, msgWarnings) + "**\n")
+
+		for _, warning := range s.Warnings {
+			b.WriteString(">\n> - " + escapeMarkdown(warning.Message) + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// lineLabel returns the label of an order line, prefixed by its quantity.
+func lineLabel(line Line) string {
+	if line.Quantity > 0 {
+		return fmt.Sprintf("%d × %s", line.Quantity, line.Label)
+	}
+
+	return line.Label
+}
+
+// formatAmount appends the symbol or currency code to an amount.
+func formatAmount(amount string, symbol string) string {
+	if symbol == "" {
+		return amount
+	}
+
+	return amount + " " + symbol
+}
+
+// assetAmount formats an amount of the payment asset in the payer's locale. Without the
+// asset decimals, or when the amount is not a decimal number, the amount is shown as is.
+func assetAmount(loc locale.Locale, amount string, asset *protocol.Asset, symbol string) string {
+	if asset != nil {
+		if formatted, err := loc.FormatAsset(amount, *asset); err == nil {
+			return formatted
+		}
+	}
+
+	return formatAmount(amount, symbol)
+}
+
+// currencyAmount formats an order amount in the payer's locale, or shows it as is when it is
+// not a decimal number.
+func currencyAmount(loc locale.Locale, amount string, coinCode string) string {
+	if formatted, err := loc.FormatCurrency(amount, coinCode); err == nil {
+		return formatted
+	}
+
+	return formatAmount(amount, coinCode)
+}
+
+// formatDuration formats a remaining time with its two largest units (e.g., 1h 05m, 14m 30s, 45s).
+func formatDuration(duration time.Duration) string {
+	duration = duration.Truncate(time.Second)
+
+	hours := int(duration / time.Hour)
+	minutes := int(duration % time.Hour / time.Minute)
+	seconds := int(duration % time.Minute / time.Second)
+
+	switch {
+	case hours > 0:
+		return fmt.Sprintf("%dh %02dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%dm %02ds", minutes, seconds)
+	}
+
+	return fmt.Sprintf("%ds", seconds)
+}
+
+// escapeMarkdown escapes a text for Markdown.
+func escapeMarkdown(value string) string {
+	return markdownEscaper.Replace(value)
+}
+
+// codeSpan returns a Markdown code span containing the value.
+func codeSpan(value string) string {
+	fence := "`"
+
+	for strings.Contains(value, fence) {
+		fence += "`"
+	}
+
+	if strings.HasPrefix(value, "`") || strings.HasSuffix(value, "`") {
+		return fence + " " + value + " " + fence
+	}
+
+	return fence + value + fence
+}
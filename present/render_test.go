@@ -0,0 +1,89 @@
+package present
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Should render the summary as plain text
+func TestText(t *testing.T) {
+	payload := coffeeOrder()
+	payload.Payment.AddressTag = "104729"
+
+	summary := Summarize(payload, Options{Assets: registry(t), Clock: fixedClock{now}})
+
+	assert.Equal(t, "Pay Coffee Shop\n"+
+		"Amount: USDT\u00a012.10\n"+
+		`Network: tron
+Address: TQmTzBAXYGhP9FdKWZZHLxG9MQK8kKYvN2
+Memo / tag: 104729
+Expires in 14m 30s
+
+Order: Order #42
+  2 × Latte: $10.00
+  VAT (21%): $2.10
+  WELCOME: -$1.00
+  Shipping: $1.00
+  Total: $12.10
+
+Warnings:
+  ! Include the memo / tag 104729: payments without it may be lost.
+`, summary.Text())
+}
+
+// Should render the summary as Markdown
+func TestMarkdown(t *testing.T) {
+	payload := coffeeOrder()
+	payload.Order.Merchant.Name = "Coffee_Shop"
+	payload.Order.Items[0].Description = "Latte | large"
+	payload.Payment.ExpiresAt = now.Add(time.Minute).UnixMilli()
+
+	summary := Summarize(payload, Options{Locale: "es", Assets: registry(t), Clock: fixedClock{now}})
+
+	assert.Equal(t, "### Pagar a Coffee\\_Shop\n"+
+		"\n"+
+		"- **Monto:** 12,10\u00a0USDT\n"+
+		"- **Red:** tron\n"+
+		"- **Dirección:** `TQmTzBAXYGhP9FdKWZZHLxG9MQK8kKYvN2`\n"+
+		"- _Vence en 1m 00s_\n"+
+		"\n"+
+		"#### Orden: Order \\#42\n"+
+		"\n"+
+		"| Ítem | Cant. | Monto |\n"+
+		"| --- | ---: | ---: |\n"+
+		"| Latte \\| large | 2 | 10,00\u00a0US$ |\n"+
+		"| VAT (21%) |  | 2,10\u00a0US$ |\n"+
+		"| WELCOME |  | -1,00\u00a0US$ |\n"+
+		"| Envío |  | 1,00\u00a0US$ |\n"+
+		"| **Total** | | **12,10\u00a0US$** |\n"+
+		"\n"+
+		"> **Advertencias**\n"+
+		">\n"+
+		"> - La instrucción de pago vence en 1m 00s.\n", summary.Markdown())
+}
+
+// Should format remaining times with their two largest units
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		expected string
+	}{
+		{duration: 45 * time.Second, expected: "45s"},
+		{duration: 14*time.Minute + 30*time.Second, expected: "14m 30s"},
+		{duration: time.Hour + 5*time.Minute + 59*time.Second, expected: "1h 05m"},
+		{duration: 26 * time.Hour, expected: "26h 00m"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, formatDuration(test.duration))
+	}
+}
+
+// Should wrap values containing backticks in longer code spans
+func TestCodeSpan(t *testing.T) {
+	assert.Equal(t, "`memo`", codeSpan("memo"))
+	assert.Equal(t, "``a`b``", codeSpan("a`b"))
+	assert.Equal(t, "`` `a ``", codeSpan("`a"))
+}
@@ -0,0 +1,172 @@
+package protocol
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/fluxisus/naspip-go/v3/utils"
+	validator "github.com/tiendc/go-validator"
+)
+
+// Asset describes a payable asset and the precision it supports.
+// Assets are identified by the same unique asset ID used in payment instructions.
+type Asset struct {
+	UniqueAssetId string `json:"unique_asset_id"`        // Asset identifier (cryptocurrency/token)
+	Symbol        string `json:"symbol"`                 // Ticker symbol (e.g., USDC, USDT)
+	Decimals      int32  `json:"decimals"`               // Number of decimal places supported by the asset
+	Network       string `json:"network"`                // Network the asset lives on (e.g., tron, polygon)
+	DisplayName   string `json:"display_name,omitempty"` // Human readable name of the asset
+}
+
+// ToBaseUnits converts a decimal amount into the asset's smallest unit.
+// It returns an error if the amount has more precision than the asset supports.
+func (a Asset) ToBaseUnits(amount string) (string, error) {
+	return utils.ToBaseUnits(amount, a.Decimals)
+}
+
+// FromBaseUnits converts an amount expressed in the asset's smallest unit into a decimal amount.
+func (a Asset) FromBaseUnits(amount string) (string, error) {
+	return utils.FromBaseUnits(amount, a.Decimals)
+}
+
+// AssetRegistry holds the set of assets known to an issuer or wallet.
+// It is used to enforce that payment amounts do not exceed the precision of their asset.
+// An AssetRegistry is safe for concurrent use.
+type AssetRegistry struct {
+	mu     sync.RWMutex
+	assets map[string]Asset
+}
+
+// NewAssetRegistry creates an asset registry containing the provided assets.
+//
+// Returns:
+//   - The registry if all assets are valid
+//   - An error if an asset is invalid or registered more than once
+func NewAssetRegistry(assets ...Asset) (*AssetRegistry, error) {
+	registry := &AssetRegistry{assets: make(map[string]Asset)}
+
+	for _, asset := range assets {
+		if err := registry.Register(asset); err != nil {
+			return nil, err
+		}
+	}
+
+	return registry, nil
+}
+
+// LoadAssetRegistry creates an asset registry from a JSON array of assets.
+//
+// Example input:
+//
+//	[{"unique_asset_id": "npolygon_t0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359", "symbol": "USDC", "decimals": 6, "network": "polygon"}]
+//
+// Returns:
+//   - The registry if the JSON is valid and all assets are valid
+//   - An error if decoding or validation fails
+func LoadAssetRegistry(r io.Reader) (*AssetRegistry, error) {
+	var assets []Asset
+
+	if err := json.NewDecoder(r).Decode(&assets); err != nil {
+		return nil, errors.New("invalid asset registry json")
+	}
+
+	return NewAssetRegistry(assets...)
+}
+
+// Register adds an asset to the registry.
+// It returns an error if the asset is invalid or its ID is already registered.
+func (r *AssetRegistry) Register(asset Asset) error {
+	if err := validateAsset(asset); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.assets[asset.UniqueAssetId]; ok {
+		return errors.New("asset already registered")
+	}
+
+	r.assets[asset.UniqueAssetId] = asset
+
+	return nil
+}
+
+// Get returns the asset registered under the given unique asset ID.
+// The boolean result reports whether the asset was found.
+func (r *AssetRegistry) Get(uniqueAssetId string) (Asset, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	asset, ok := r.assets[uniqueAssetId]
+
+	return asset, ok
+}
+
+// ValidatePayment checks that the payment amounts do not have more decimal places than the
+// payment asset supports. Payments for assets that are not in the registry are not checked.
+//
+// Returns:
+//   - nil if the amounts fit the asset precision
+//   - An error describing the first offending amount otherwise
+func (r *AssetRegistry) ValidatePayment(payment PaymentInstruction) error {
+	asset, ok := r.Get(payment.UniqueAssetId)
+
+	if !ok {
+		return nil
+	}
+
+	errs := validator.Validate(
+		validator.When(payment.Amount != "").Then(
+			validator.Must(utils.FitsDecimals(payment.Amount, asset.Decimals)).OnError(
+				validator.SetField("payment_amount", nil),
+				validator.SetCustomKey("PAYMENT_AMOUNT_PRECISION_INVALID"),
+			)),
+		validator.When(payment.MinAmount != "").Then(
+			validator.Must(utils.FitsDecimals(payment.MinAmount, asset.Decimals)).OnError(
+				validator.SetField("payment_min_amount", nil),
+				validator.SetCustomKey("PAYMENT_MIN_AMOUNT_PRECISION_INVALID"),
+			)),
+		validator.When(payment.MaxAmount != "").Then(
+			validator.Must(utils.FitsDecimals(payment.MaxAmount, asset.Decimals)).OnError(
+				validator.SetField("payment_max_amount", nil),
+				validator.SetCustomKey("PAYMENT_MAX_AMOUNT_PRECISION_INVALID"),
+			)),
+	)
+
+	if len(errs) > 0 {
+		return errs[0]
+	}
+
+	return nil
+}
+
+// validateAsset performs validation on an asset definition.
+//
+// Returns:
+//   - nil if the asset is valid
+//   - An error describing the problem if validation fails
+func validateAsset(asset Asset) error {
+	errs := validator.Validate(
+		validator.StrLen(&asset.UniqueAssetId, 1, 100).OnError(
+			validator.SetField("asset_unique_asset_id", nil),
+		),
+		validator.StrLen(&asset.Symbol, 1, 20).OnError(
+			validator.SetField("asset_symbol", nil),
+		),
+		validator.NumRange(&asset.Decimals, 0, 36).OnError(
+			validator.SetField("asset_decimals", nil),
+		),
+		validator.StrLen(&asset.Network, 1, 50).OnError(
+			validator.SetField("asset_network", nil),
+		),
+	)
+
+	if len(errs) > 0 {
+		return errs[0]
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package protocol
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var registryJson = `[
+	{"unique_asset_id": "npolygon_t0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359", "symbol": "USDC", "decimals": 6, "network": "polygon", "display_name": "USD Coin"},
+	{"unique_asset_id": "nbitcoin", "symbol": "BTC", "decimals": 8, "network": "bitcoin"}
+]`
+
+// Should load asset registry from json
+func TestLoadAssetRegistry(t *testing.T) {
+	assert := assert.New(t)
+
+	registry, err := LoadAssetRegistry(strings.NewReader(registryJson))
+
+	if err != nil {
+		t.Errorf("TestLoadAssetRegistry FAIL --> %v", err)
+	}
+
+	asset, ok := registry.Get("npolygon_t0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359")
+
+	assert.True(ok)
+	assert.Equal("USDC", asset.Symbol)
+	assert.Equal(int32(6), asset.Decimals)
+	assert.Equal("USD Coin", asset.DisplayName)
+
+	_, ok = registry.Get("unknown-asset")
+
+	assert.False(ok)
+}
+
+// Should fail to load an invalid asset registry
+func TestLoadInvalidAssetRegistry(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := LoadAssetRegistry(strings.NewReader("not-json"))
+
+	assert.EqualError(err, "invalid asset registry json")
+
+	_, err = NewAssetRegistry(
+		Asset{UniqueAssetId: "nbitcoin", Symbol: "BTC", Decimals: 8, Network: "bitcoin"},
+		Asset{UniqueAssetId: "nbitcoin", Symbol: "BTC", Decimals: 8, Network: "bitcoin"},
+	)
+
+	assert.EqualError(err, "asset already registered")
+
+	_, err = NewAssetRegistry(Asset{UniqueAssetId: "nbitcoin", Symbol: "BTC", Decimals: -1, Network: "bitcoin"})
+
+	assert.NotNil(err)
+}
+
+// Should convert amounts between decimal strings and base units
+func TestAssetBaseUnits(t *testing.T) {
+	assert := assert.New(t)
+
+	var asset = Asset{UniqueAssetId: "nbitcoin", Symbol: "BTC", Decimals: 8, Network: "bitcoin"}
+
+	units, err := asset.ToBaseUnits("1.5")
+
+	assert.Nil(err)
+	assert.Equal("150000000", units)
+
+	units, err = asset.ToBaseUnits("0.00000001")
+
+	assert.Nil(err)
+	assert.Equal("1", units)
+
+	_, err = asset.ToBaseUnits("0.000000001")
+
+	assert.EqualError(err, "amount exceeds asset precision")
+
+	amount, err := asset.FromBaseUnits("150000000")
+
+	assert.Nil(err)
+	assert.Equal("1.5", amount)
+
+	_, err = asset.FromBaseUnits("1.5")
+
+	assert.EqualError(err, "invalid base units amount")
+}
+
+// Should validate payment amounts against asset precision
+func TestValidatePaymentPrecision(t *testing.T) {
+	assert := assert.New(t)
+
+	registry, _ := LoadAssetRegistry(strings.NewReader(registryJson))
+
+	var usdc = "npolygon_t0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359"
+
+	assert.Nil(registry.ValidatePayment(PaymentInstruction{UniqueAssetId: usdc, Amount: "10.520000"}))
+	assert.Nil(registry.ValidatePayment(PaymentInstruction{UniqueAssetId: "unknown-asset", Amount: "0.0000000000000000001"}))
+	assert.NotNil(registry.ValidatePayment(PaymentInstruction{UniqueAssetId: usdc, Amount: "0.0000000000000000001"}))
+	assert.NotNil(registry.ValidatePayment(PaymentInstruction{UniqueAssetId: usdc, IsOpen: true, MinAmount: "0.0000001"}))
+	assert.NotNil(registry.ValidatePayment(PaymentInstruction{UniqueAssetId: usdc, IsOpen: true, MaxAmount: "1.1234567"}))
+}
+
+// Should fail to create payment instruction with amount exceeding asset precision
+func TestCreatePaymentExceedingAssetPrecision(t *testing.T) {
+	assert := assert.New(t)
+
+	registry, _ := LoadAssetRegistry(strings.NewReader(registryJson))
+
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}, AssetRegistry: registry}
+
+	var payload = InstructionPayload{
+		Payment: PaymentInstruction{
+			Id:            "payment-id",
+			UniqueAssetId: "npolygon_t0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359",
+			Address:       "crypto-address",
+			Amount:        "0.0000000000000000001",
+			ExpiresAt:     time.Now().Add(time.Hour).UnixMilli(),
+		},
+	}
+
+	var createOptions = QrCriptoCreateOptions{
+		SignOptions:   paseto.PasetoSignOptions{KeyId: "key-id-one", ExpiresIn: "5m"},
+		KeyIssuer:     "payment-processor.com",
+		KeyExpiration: time.Now().Add(1e9).Format(utils.RFC3339Mili),
+	}
+
+	qrToken, err := builder.CreatePaymentInstruction(payload, keys["secretKey"], createOptions)
+
+	assert.Equal("", qrToken)
+	assert.NotNil(err)
+
+	payload.Payment.Amount = "10.52"
+
+	qrToken, err = builder.CreatePaymentInstruction(payload, keys["secretKey"], createOptions)
+
+	assert.Nil(err)
+	assert.True(strings.HasPrefix(qrToken, "naspip;"))
+}
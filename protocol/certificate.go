@@ -0,0 +1,307 @@
+package protocol
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/fluxisus/naspip-go/v3/encoding/protobuf"
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	validator "github.com/tiendc/go-validator"
+)
+
+// maxCertificateChain is the maximum number of key certificates between a token and a trust anchor.
+const maxCertificateChain = 3
+
+// KeyCertificate delegates a merchant key. It is signed by an issuer key (a trust anchor or
+// another certified key) and certifies that PublicKey may sign NASPIP tokens with the given
+// key issuer and key ID, so wallets only need to hold the issuer's public key.
+//
+// A certificate is itself a NASPIP token created with CreateKeyCertificate. It is carried in
+// the PASETO footer of the tokens signed by the certified key (see QrCriptoCreateOptions.KeyCertificate),
+// and a certificate signed by an intermediate key carries that key's certificate in its own footer.
+//
+// Only keys certified with IsCA may sign certificates, for their own key issuer and within
+// their own validity window and asset restrictions. PathLength limits how many CA certificates
+// may follow in the chain (0 for a CA that only certifies signing keys).
+type KeyCertificate struct {
+	PublicKey       string   `json:"public_key"`                  // Certified public key (PASERK format)
+	KeyId           string   `json:"kid"`                         // Key ID the certified key signs with
+	KeyIssuer       string   `json:"kis"`                         // Key issuer the certified key signs with
+	AllowedAssetIds []string `json:"allowed_asset_ids,omitempty"` // Assets the key may request, empty for any
+	NotBefore       int64    `json:"not_before"`                  // Unix timestamp when the certificate becomes valid
+	NotAfter        int64    `json:"not_after"`                   // Unix timestamp when the certificate expires
+	IsCA            bool     `json:"is_ca,omitempty"`             // Whether the certified key may sign certificates
+	PathLength      int32    `json:"path_length,omitempty"`       // Maximum number of CA certificates that may follow in the chain
+}
+
+// AllowsAsset reports whether the certified key may request payments in the given asset.
+func (c KeyCertificate) AllowsAsset(uniqueAssetId string) bool {
+	return len(c.AllowedAssetIds) == 0 || slices.Contains(c.AllowedAssetIds, uniqueAssetId)
+}
+
+// CreateKeyCertificate creates a NASPIP token certifying a delegated key.
+// To build a chain, set options.KeyCertificate to the certificate of the signing key.
+//
+// Parameters:
+//   - data: The certificate content
+//   - secretKey: The issuer private key (in raw or PASERK format) to sign the certificate
+//   - options: Options for token creation
+//
+// Returns:
+//   - A NASPIP token string containing the certificate if creation succeeds
+//   - An error if validation or creation fails
+func (p PaymentInstructionsBuilder) CreateKeyCertificate(data KeyCertificate, secretKey string, options QrCriptoCreateOptions) (string, error) {
+
+	isValid, err := validateKeyCertificate(data)
+
+	if !isValid {
+		return "", err
+	}
+
+	protoPayload := &protobuf.KeyCertificate{}
+	if err := protobuf.ConvertGoToProto(data, protoPayload); err != nil {
+		return "", err
+	}
+
+	var payload = &protobuf.PasetoTokenData{
+		Data: &protobuf.PasetoTokenData_KeyCertificate{
+			KeyCertificate: protoPayload,
+		},
+	}
+
+	return p.create(payload, secretKey, options)
+}
+
+// VerifyKeyCertificate verifies a key certificate token and its chain up to a trust anchor.
+// Certificates carried in the footer are verified first; the last one must be signed by a
+// key returned by trustAnchors. Each certificate must be within its validity window at the
+// clock's current time. When revocations is set, every key that signed a certificate of the
+// chain is checked for revocation, as Read does for the key that signed the token.
+//
+// A certificate signed by a certified key is narrowed by the issuer's certificate: the issuer
+// must be a CA with room left in its PathLength, both must have the same key issuer, and the
+// certificate may not be valid outside the issuer's validity window nor allow assets that the
+// issuer's certificate does not allow.
+//
+// Parameters:
+//   - certificate: The NASPIP token containing the certificate
+//   - trustAnchors: Resolver for the root issuer public keys
+//   - clock: Source of the current time, SystemClock when nil
+//   - revocations: Revocation checker of the chain keys, none when nil
+//
+// Returns:
+//   - The verified certificate
+//   - An error if any certificate in the chain is invalid, ErrKeyRevoked if a chain key is revoked
+func (p PaymentInstructionsBuilder) VerifyKeyCertificate(certificate string, trustAnchors KeyResolver, clock Clock, revocations RevocationChecker) (*KeyCertificate, error) {
+	if clock == nil {
+		clock = SystemClock{}
+	}
+
+	return p.verifyKeyCertificate(certificate, trustAnchors, clock, revocations, 1)
+}
+
+// verifyKeyCertificate verifies a certificate at the given depth of the chain.
+func (p PaymentInstructionsBuilder) verifyKeyCertificate(certificate string, trustAnchors KeyResolver, clock Clock, revocations RevocationChecker, depth int) (*KeyCertificate, error) {
+	if depth > maxCertificateChain {
+		return nil, errors.New("key certificate chain too long")
+	}
+
+	decodedQr, err := p.Decode(certificate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := paseto.Decode(decodedQr.Token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	footer, err := parseFooter(decoded.Footer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var issuer *KeyCertificate
+	var publicKey string
+
+	if footer.KeyCertificate != "" {
+		issuer, err = p.verifyKeyCertificate(footer.KeyCertificate, trustAnchors, clock, revocations, depth+1)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if issuer.KeyIssuer != decodedQr.KeyIssuer || issuer.KeyId != decodedQr.KeyId {
+			return nil, errors.New("key certificate does not match token key")
+		}
+
+		publicKey = issuer.PublicKey
+	} else {
+		publicKey, err = trustAnchors.ResolveKey(decodedQr.KeyIssuer, decodedQr.KeyId)
+
+		if err != nil {
+			return nil, errors.New("untrusted key certificate issuer")
+		}
+	}
+
+	data, err := p.Read(certificate, publicKey, QrCriptoReadOptions{
+		VerifyOptions:     paseto.PasetoVerifyOptions{Footer: decoded.Footer},
+		RevocationChecker: revocations,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := data.Payload.Data["public_key"]; !ok {
+		return nil, errors.New("token does not contain a key certificate")
+	}
+
+	var cert KeyCertificate
+
+	if err := PayloadOf(data.Payload.Data, &cert); err != nil {
+		return nil, err
+	}
+
+	now := clock.Now().UnixMilli()
+
+	if now < cert.NotBefore {
+		return nil, errors.New("key certificate not yet valid")
+	}
+
+	if now > cert.NotAfter {
+		return nil, errors.New("key certificate expired")
+	}
+
+	if issuer != nil {
+		if err := checkIssuerConstraints(cert, *issuer); err != nil {
+			return nil, err
+		}
+	}
+
+	return &cert, nil
+}
+
+// checkIssuerConstraints checks that a certificate signed by a certified key stays within
+// the constraints of the issuer's certificate.
+//
+// Parameters:
+//   - cert: The certificate signed by the certified key
+//   - issuer: The certificate of the signing key
+//
+// Returns:
+//   - nil if the certificate is narrower than or equal to its issuer
+//   - An error describing the violated constraint otherwise
+func checkIssuerConstraints(cert KeyCertificate, issuer KeyCertificate) error {
+	if !issuer.IsCA {
+		return errors.New("key certificate issuer is not a CA")
+	}
+
+	if cert.IsCA && cert.PathLength >= issuer.PathLength {
+		return errors.New("key certificate path length exceeded")
+	}
+
+	if cert.KeyIssuer != issuer.KeyIssuer {
+		return errors.New("key certificate issuer mismatch")
+	}
+
+	if cert.NotBefore < issuer.NotBefore || cert.NotAfter > issuer.NotAfter {
+		return errors.New("key certificate exceeds issuer restrictions")
+	}
+
+	if len(issuer.AllowedAssetIds) > 0 {
+		if len(cert.AllowedAssetIds) == 0 {
+			return errors.New("key certificate exceeds issuer restrictions")
+		}
+
+		for _, asset := range cert.AllowedAssetIds {
+			if !issuer.AllowsAsset(asset) {
+				return errors.New("key certificate exceeds issuer restrictions")
+			}
+		}
+	}
+
+	return nil
+}
+
+// payloadAssets returns the asset IDs requested by the token data of any payload type.
+func payloadAssets(data map[string]interface{}) []string {
+	assets := []string{}
+
+	if payment, ok := data["payment"].(map[string]interface{}); ok {
+		if asset, ok := payment["unique_asset_id"].(string); ok {
+			assets = append(assets, asset)
+		}
+	}
+
+	if payments, ok := data["payments"].([]interface{}); ok {
+		for _, payment := range payments {
+			if asset, ok := payment.(map[string]interface{})["unique_asset_id"].(string); ok {
+				assets = append(assets, asset)
+			}
+		}
+	}
+
+	if options, ok := data["payment_options"].([]interface{}); ok {
+		for _, option := range options {
+			if asset, ok := option.(string); ok {
+				assets = append(assets, asset)
+			}
+		}
+	}
+
+	if asset, ok := data["unique_asset_id"].(string); ok {
+		assets = append(assets, asset)
+	}
+
+	return assets
+}
+
+// validateKeyCertificate performs validation on a key certificate.
+//
+// Parameters:
+//   - payload: The certificate to validate
+//
+// Returns:
+//   - true if the certificate passes all validation rules
+//   - false and an error describing the problem if validation fails
+func validateKeyCertificate(payload KeyCertificate) (bool, error) {
+	errs := validator.Validate(
+		validator.StrLen(&payload.PublicKey, 1, 200).OnError(
+			validator.SetField("public_key", nil),
+		),
+		validator.StrLen(&payload.KeyId, 1, 100).OnError(
+			validator.SetField("kid", nil),
+		),
+		validator.StrLen(&payload.KeyIssuer, 1, 100).OnError(
+			validator.SetField("kis", nil),
+		),
+		validator.Slice(payload.AllowedAssetIds).ForEach(func(elem string, index int, vld validator.ItemValidator) {
+			vld.Validate(
+				validator.StrLen(&elem, 1, 100).OnError(
+					validator.SetField("allowed_asset_ids", nil),
+				),
+			)
+		}),
+		validator.NumGT(&payload.NotAfter, payload.NotBefore).OnError(
+			validator.SetField("not_after", nil),
+		),
+		validator.NumGTE(&payload.PathLength, 0).OnError(
+			validator.SetField("path_length", nil),
+		),
+		validator.When(!payload.IsCA).Then(
+			validator.NumEQ(&payload.PathLength, 0).OnError(
+				validator.SetField("path_length", nil),
+				validator.SetCustomKey("KEY_CERTIFICATE_PATH_LENGTH_REQUIRES_CA"),
+			)),
+	)
+
+	if len(errs) > 0 {
+		return false, errs[0]
+	}
+
+	return true, nil
+}
@@ -0,0 +1,180 @@
+package protocol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// certificateOptions returns the options to sign a token with the given key
+func certificateOptions(kis string, kid string, publicKey string, certificate string) QrCriptoCreateOptions {
+	return QrCriptoCreateOptions{
+		SignOptions:    paseto.PasetoSignOptions{KeyId: kid, ExpiresIn: "5m", Assertion: []byte(publicKey)},
+		KeyIssuer:      kis,
+		KeyExpiration:  time.Now().Add(1e9).Format(utils.RFC3339Mili),
+		KeyCertificate: certificate,
+	}
+}
+
+// Should verify instructions signed by delegated keys up to the trust anchor
+func TestReadWithKeyCertificate(t *testing.T) {
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	rootKeys, _ := paseto.GenerateKey("public", "paserk")
+	intermediateKeys, _ := paseto.GenerateKey("public", "paserk")
+
+	var anchors = StaticKeyResolver{"psp.com": {"root-1": rootKeys["publicKey"]}}
+	var asset = refundOriginal.Payment.UniqueAssetId
+
+	var now = time.Now()
+
+	var merchantCertificate = KeyCertificate{
+		PublicKey:       keys["publicKey"],
+		KeyId:           "merchant-key",
+		KeyIssuer:       "merchant.com",
+		AllowedAssetIds: []string{asset},
+		NotBefore:       now.Add(-time.Hour).UnixMilli(),
+		NotAfter:        now.Add(time.Hour).UnixMilli(),
+	}
+
+	rootSigned, err := builder.CreateKeyCertificate(merchantCertificate, rootKeys["secretKey"], certificateOptions("psp.com", "root-1", rootKeys["publicKey"], ""))
+
+	assert.Nil(t, err)
+
+	var intermediateCertificate = KeyCertificate{
+		PublicKey:       intermediateKeys["publicKey"],
+		KeyId:           "region-1",
+		KeyIssuer:       "merchant.com",
+		AllowedAssetIds: []string{asset, "npolygon_t0xc2132D05D31c914a87C6611C10748AEb04B58e8F"},
+		NotBefore:       merchantCertificate.NotBefore,
+		NotAfter:        merchantCertificate.NotAfter,
+		IsCA:            true,
+		PathLength:      1,
+	}
+
+	intermediate, err := builder.CreateKeyCertificate(intermediateCertificate, rootKeys["secretKey"], certificateOptions("psp.com", "root-1", rootKeys["publicKey"], ""))
+
+	assert.Nil(t, err)
+
+	var intermediateOptions = certificateOptions("merchant.com", "region-1", intermediateKeys["publicKey"], intermediate)
+
+	intermediateSigned, err := builder.CreateKeyCertificate(merchantCertificate, intermediateKeys["secretKey"], intermediateOptions)
+
+	assert.Nil(t, err)
+
+	expired := merchantCertificate
+	expired.NotBefore = now.Add(-2 * time.Hour).UnixMilli()
+	expired.NotAfter = now.Add(-time.Hour).UnixMilli()
+
+	expiredSigned, _ := builder.CreateKeyCertificate(expired, rootKeys["secretKey"], certificateOptions("psp.com", "root-1", rootKeys["publicKey"], ""))
+
+	otherAsset := merchantCertificate
+	otherAsset.AllowedAssetIds = []string{"npolygon_t0xc2132D05D31c914a87C6611C10748AEb04B58e8F"}
+
+	otherAssetSigned, _ := builder.CreateKeyCertificate(otherAsset, rootKeys["secretKey"], certificateOptions("psp.com", "root-1", rootKeys["publicKey"], ""))
+
+	unrestricted := merchantCertificate
+	unrestricted.AllowedAssetIds = nil
+
+	unrestrictedSigned, _ := builder.CreateKeyCertificate(unrestricted, intermediateKeys["secretKey"], intermediateOptions)
+
+	outlives := merchantCertificate
+	outlives.NotAfter = now.Add(2 * time.Hour).UnixMilli()
+
+	outlivesSigned, _ := builder.CreateKeyCertificate(outlives, intermediateKeys["secretKey"], intermediateOptions)
+
+	otherIssuer := merchantCertificate
+	otherIssuer.KeyIssuer = "other.com"
+
+	otherIssuerSigned, _ := builder.CreateKeyCertificate(otherIssuer, intermediateKeys["secretKey"], intermediateOptions)
+
+	// A signing key certified without IsCA cannot certify other keys
+	leafIntermediate := intermediateCertificate
+	leafIntermediate.IsCA = false
+	leafIntermediate.PathLength = 0
+
+	leafIntermediateCertificate, _ := builder.CreateKeyCertificate(leafIntermediate, rootKeys["secretKey"], certificateOptions("psp.com", "root-1", rootKeys["publicKey"], ""))
+	notCASigned, _ := builder.CreateKeyCertificate(merchantCertificate, intermediateKeys["secretKey"], certificateOptions("merchant.com", "region-1", intermediateKeys["publicKey"], leafIntermediateCertificate))
+
+	// A CA with path length 0 cannot certify another CA
+	subKeys, _ := paseto.GenerateKey("public", "paserk")
+
+	lastCA := intermediateCertificate
+	lastCA.PathLength = 0
+
+	lastCACertificate, _ := builder.CreateKeyCertificate(lastCA, rootKeys["secretKey"], certificateOptions("psp.com", "root-1", rootKeys["publicKey"], ""))
+	subCACertificate, _ := builder.CreateKeyCertificate(KeyCertificate{
+		PublicKey: subKeys["publicKey"],
+		KeyId:     "store-1",
+		KeyIssuer: "merchant.com",
+		NotBefore: merchantCertificate.NotBefore,
+		NotAfter:  merchantCertificate.NotAfter,
+		IsCA:      true,
+	}, intermediateKeys["secretKey"], certificateOptions("merchant.com", "region-1", intermediateKeys["publicKey"], lastCACertificate))
+	pathExceededSigned, _ := builder.CreateKeyCertificate(merchantCertificate, subKeys["secretKey"], certificateOptions("merchant.com", "store-1", subKeys["publicKey"], subCACertificate))
+
+	tests := []struct {
+		name        string
+		certificate string
+		anchors     KeyResolver
+		clock       Clock
+		err         string
+	}{
+		{name: "signed by root", certificate: rootSigned, anchors: anchors},
+		{name: "signed by intermediate", certificate: intermediateSigned, anchors: anchors},
+		{name: "untrusted root", certificate: rootSigned, anchors: StaticKeyResolver{}, err: "untrusted key certificate issuer"},
+		{name: "expired certificate", certificate: expiredSigned, anchors: anchors, err: "key certificate expired"},
+		{name: "valid at the clock time", certificate: expiredSigned, anchors: anchors, clock: fixedClock(now.Add(-90 * time.Minute))},
+		{name: "expired at the clock time", certificate: rootSigned, anchors: anchors, clock: fixedClock(now.Add(2 * time.Hour)), err: "key certificate expired"},
+		{name: "asset not allowed", certificate: otherAssetSigned, anchors: anchors, err: "asset not allowed by key certificate"},
+		{name: "wider than issuer", certificate: unrestrictedSigned, anchors: anchors, err: "key certificate exceeds issuer restrictions"},
+		{name: "outlives issuer", certificate: outlivesSigned, anchors: anchors, err: "key certificate exceeds issuer restrictions"},
+		{name: "other key issuer", certificate: otherIssuerSigned, anchors: anchors, err: "key certificate issuer mismatch"},
+		{name: "issuer not a CA", certificate: notCASigned, anchors: anchors, err: "key certificate issuer is not a CA"},
+		{name: "path length exceeded", certificate: pathExceededSigned, anchors: anchors, err: "key certificate path length exceeded"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			qrToken, err := builder.CreatePaymentInstruction(refundOriginal, keys["secretKey"], certificateOptions("merchant.com", "merchant-key", keys["publicKey"], test.certificate))
+
+			assert.Nil(t, err)
+
+			data, err := builder.Read(qrToken, "", QrCriptoReadOptions{TrustAnchors: test.anchors, Clock: test.clock})
+
+			if test.err == "" {
+				assert.Nil(t, err)
+				assert.Equal(t, "merchant.com", data.Payload.Kis)
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+		})
+	}
+
+	// The certificate must certify the key named in the token prefix
+	qrToken, _ := builder.CreatePaymentInstruction(refundOriginal, keys["secretKey"], certificateOptions("merchant.com", "other-key", keys["publicKey"], rootSigned))
+
+	_, err = builder.Read(qrToken, "", QrCriptoReadOptions{TrustAnchors: anchors})
+
+	assert.EqualError(t, err, "key certificate does not match token key")
+}
+
+// Should refuse path lengths on certificates that cannot sign certificates
+func TestCreateKeyCertificatePathLength(t *testing.T) {
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	_, err := builder.CreateKeyCertificate(KeyCertificate{
+		PublicKey:  keys["publicKey"],
+		KeyId:      "merchant-key",
+		KeyIssuer:  "merchant.com",
+		NotBefore:  1,
+		NotAfter:   2,
+		PathLength: 1,
+	}, keys["secretKey"], certificateOptions("psp.com", "root-1", keys["publicKey"], ""))
+
+	assert.ErrorContains(t, err, "path_length")
+}
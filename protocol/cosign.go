@@ -0,0 +1,124 @@
+package protocol
+
+import (
+	"errors"
+
+	"github.com/fluxisus/naspip-go/v3/encoding/protobuf"
+	"github.com/fluxisus/naspip-go/v3/paseto"
+)
+
+// CoSignedPayload wraps a merchant-signed NASPIP token so that a payment processor (PSP)
+// can certify it. The outer token is signed by the PSP and the inner token by the merchant,
+// so wallets can trust both the merchant and the processor.
+type CoSignedPayload struct {
+	MerchantToken string `json:"merchant_token"` // Merchant-signed NASPIP token
+}
+
+// Signer identifies the key that signed one layer of a co-signed token.
+type Signer struct {
+	KeyIssuer string // Entity that issued the key
+	KeyId     string // Unique identifier for the key
+}
+
+// CoSignedResult contains the verified content of a co-signed token.
+type CoSignedResult struct {
+	Merchant  Signer                       // Signer of the inner token
+	Processor Signer                       // Signer of the outer token
+	Data      *paseto.PasetoCompleteResult // Verified content of the inner (merchant) token
+}
+
+// CoSignedReadOptions contains the options used to verify each layer of a co-signed token.
+type CoSignedReadOptions struct {
+	Merchant  QrCriptoReadOptions // Options for the inner, merchant-signed token
+	Processor QrCriptoReadOptions // Options for the outer, processor-signed token
+}
+
+// CoSign wraps a merchant-signed NASPIP token in a new token signed by the payment processor.
+// The merchant token is not verified here; processors should Read it before co-signing.
+//
+// Parameters:
+//   - merchantToken: The merchant-signed NASPIP token
+//   - secretKey: The processor private key (in raw or PASERK format) to sign the outer token
+//   - options: Options for the outer token creation
+//
+// Returns:
+//   - The co-signed NASPIP token string if creation succeeds
+//   - An error if the merchant token is malformed or creation fails
+func (p PaymentInstructionsBuilder) CoSign(merchantToken string, secretKey string, options QrCriptoCreateOptions) (string, error) {
+	if _, err := p.Decode(merchantToken); err != nil {
+		return "", err
+	}
+
+	var payload = &protobuf.PasetoTokenData{
+		Data: &protobuf.PasetoTokenData_CoSignedPayload{
+			CoSignedPayload: &protobuf.CoSignedPayload{MerchantToken: merchantToken},
+		},
+	}
+
+	return p.create(payload, secretKey, options)
+}
+
+// ReadCoSigned reads and verifies both layers of a co-signed NASPIP token.
+// The public key of each layer is found with its resolver using the key issuer and key ID
+// of the token prefix.
+//
+// Parameters:
+//   - qrPayment: The co-signed NASPIP token
+//   - processorKeys: Resolver for the processor public keys (outer token)
+//   - merchantKeys: Resolver for the merchant public keys (inner token)
+//   - options: Options controlling verification of each layer
+//
+// Returns:
+//   - The signers of both layers and the verified merchant token content
+//   - An error if any layer fails verification or the token is not co-signed
+func (p PaymentInstructionsBuilder) ReadCoSigned(qrPayment string, processorKeys KeyResolver, merchantKeys KeyResolver, options CoSignedReadOptions) (*CoSignedResult, error) {
+	outer, err := p.readResolved(qrPayment, processorKeys, options.Processor)
+
+	if err != nil {
+		return nil, err
+	}
+
+	merchantToken, ok := outer.Payload.Data["merchant_token"].(string)
+
+	if !ok {
+		return nil, errors.New("token is not co-signed")
+	}
+
+	inner, err := p.readResolved(merchantToken, merchantKeys, options.Merchant)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &CoSignedResult{
+		Merchant:  Signer{KeyIssuer: inner.Payload.Kis, KeyId: inner.Payload.Kid},
+		Processor: Signer{KeyIssuer: outer.Payload.Kis, KeyId: outer.Payload.Kid},
+		Data:      inner,
+	}, nil
+}
+
+// readResolved reads a NASPIP token using the public key found by the resolver.
+//
+// Parameters:
+//   - qrPayment: A NASPIP token string to verify
+//   - keys: Resolver for the public key
+//   - options: Options controlling verification behavior
+//
+// Returns:
+//   - The parsed token content if verification succeeds
+//   - An error if the key cannot be resolved or verification fails
+func (p PaymentInstructionsBuilder) readResolved(qrPayment string, keys KeyResolver, options QrCriptoReadOptions) (*paseto.PasetoCompleteResult, error) {
+	decodedQr, err := p.Decode(qrPayment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := keys.ResolveKey(decodedQr.KeyIssuer, decodedQr.KeyId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Read(qrPayment, publicKey, options)
+}
@@ -0,0 +1,71 @@
+package protocol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Should co-sign a merchant instruction and verify both signatures
+func TestCoSignAndReadCoSigned(t *testing.T) {
+	assert := assert.New(t)
+
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	processorKeys, _ := paseto.GenerateKey("public", "paserk")
+
+	var keyExpiration = time.Now().Add(1e9).Format(utils.RFC3339Mili)
+
+	merchantToken, err := builder.CreatePaymentInstruction(refundOriginal, keys["secretKey"], QrCriptoCreateOptions{
+		SignOptions:   paseto.PasetoSignOptions{KeyId: "merchant-key", ExpiresIn: "5m", Assertion: []byte(keys["publicKey"])},
+		KeyIssuer:     "merchant.com",
+		KeyExpiration: keyExpiration,
+	})
+
+	assert.Nil(err)
+
+	coSigned, err := builder.CoSign(merchantToken, processorKeys["secretKey"], QrCriptoCreateOptions{
+		SignOptions:   paseto.PasetoSignOptions{KeyId: "psp-key", ExpiresIn: "5m", Assertion: []byte(processorKeys["publicKey"])},
+		KeyIssuer:     "payment-processor.com",
+		KeyExpiration: keyExpiration,
+	})
+
+	if err != nil {
+		t.Errorf("TestCoSignAndReadCoSigned FAIL --> %v", err)
+	}
+
+	var processorResolver = StaticKeyResolver{"payment-processor.com": {"psp-key": processorKeys["publicKey"]}}
+	var merchantResolver = StaticKeyResolver{"merchant.com": {"merchant-key": keys["publicKey"]}}
+
+	result, err := builder.ReadCoSigned(coSigned, processorResolver, merchantResolver, CoSignedReadOptions{})
+
+	if err != nil {
+		t.Errorf("TestCoSignAndReadCoSigned FAIL --> %v", err)
+	}
+
+	assert.Equal(Signer{KeyIssuer: "merchant.com", KeyId: "merchant-key"}, result.Merchant)
+	assert.Equal(Signer{KeyIssuer: "payment-processor.com", KeyId: "psp-key"}, result.Processor)
+	assert.Equal("payment-id", result.Data.Payload.Data["payment"].(map[string]interface{})["id"])
+
+	// The merchant key cannot verify the processor layer
+	_, err = builder.ReadCoSigned(coSigned, StaticKeyResolver{"payment-processor.com": {"psp-key": keys["publicKey"]}}, merchantResolver, CoSignedReadOptions{})
+
+	assert.NotNil(err)
+
+	_, err = builder.ReadCoSigned(coSigned, processorResolver, StaticKeyResolver{}, CoSignedReadOptions{})
+
+	assert.EqualError(err, "public key not found")
+
+	_, err = builder.ReadCoSigned(coSigned, processorResolver, merchantResolver, CoSignedReadOptions{Merchant: QrCriptoReadOptions{KeyIssuer: "other.com"}})
+
+	assert.EqualError(err, "invalid Key Issuer")
+
+	// A plain instruction is not co-signed
+	_, err = builder.ReadCoSigned(merchantToken, merchantResolver, merchantResolver, CoSignedReadOptions{})
+
+	assert.EqualError(err, "token is not co-signed")
+}
@@ -0,0 +1,159 @@
+package protocol
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+
+	"github.com/fluxisus/naspip-go/v3/paseto"
+)
+
+// TokenFooter is the JSON footer written when QrCriptoCreateOptions.KeyFooter is enabled or a
+// KeyCertificate is set.
+// Following the PASETO key-id guidance, it carries the key metadata outside the encrypted or
+// signed payload, so verifiers can look up the key before verifying the token. The footer is
+// authenticated by the token signature.
+type TokenFooter struct {
+	KeyId          string `json:"kid,omitempty"`  // Key ID used to sign the token
+	KeyIssuer      string `json:"kis,omitempty"`  // Issuer of the key used to sign the token
+	PublicKeyId    string `json:"pid,omitempty"`  // PASERK identifier (k4.pid or k3.pid) of the signing public key
+	LocalKeyId     string `json:"lid,omitempty"`  // PASERK identifier (k4.lid) of the shared key of an encrypted token
+	SealedKey      string `json:"seal,omitempty"` // Key of a sealed token wrapped to the recipient (k4.seal), see CreateSealedPaymentInstruction
+	KeyCertificate string `json:"cert,omitempty"` // Certificate of the signing key, see KeyCertificate
+}
+
+// ReadFooter returns the footer of a NASPIP token without verifying the token.
+// It is meant for key lookup before verification; the values must not be trusted until
+// the token is verified with Read.
+//
+// Parameters:
+//   - qrPayment: A NASPIP token string
+//
+// Returns:
+//   - The token footer, empty if the token has none
+//   - An error if the token or its footer cannot be decoded
+func (p PaymentInstructionsBuilder) ReadFooter(qrPayment string) (*TokenFooter, error) {
+	decodedQr, err := p.Decode(qrPayment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := paseto.Decode(decodedQr.Token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	footer, err := parseFooter(decoded.Footer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &footer, nil
+}
+
+// encodeFooter builds the footer written by create. The kind of key identifier follows the
+// handler creating the token: k4.lid for v4.local, k3.pid for v3.public and k4.pid otherwise.
+// A custom footer (SignOptions.Footer) cannot be combined with the JSON footer.
+//
+// Parameters:
+//   - handler: The handler creating the token
+//   - secretKey: The private or shared key used to create the token, to compute the PASERK key identifier
+//   - keyOptions: Key options containing ID and issuer
+//   - options: Token creation options
+//
+// Returns:
+//   - The footer, nil when the token has no footer
+//   - An error if the footer cannot be built or a custom footer is combined with the JSON footer
+func encodeFooter(handler paseto.PasetoHandler, secretKey string, keyOptions TokenPublicKeyOptions, options QrCriptoCreateOptions) ([]byte, error) {
+	if !options.KeyFooter && options.KeyCertificate == "" {
+		return options.SignOptions.Footer, nil
+	}
+
+	if len(options.SignOptions.Footer) > 0 {
+		return nil, errors.New("custom footer cannot be combined with the key footer")
+	}
+
+	footer := TokenFooter{
+		KeyId:          keyOptions.KeyId,
+		KeyIssuer:      keyOptions.KeyIssuer,
+		KeyCertificate: options.KeyCertificate,
+	}
+
+	switch handler.(type) {
+	case paseto.PasetoV4LocalHandler:
+		localKey, err := paseto.GetLocalKey(secretKey)
+
+		if err != nil {
+			return nil, err
+		}
+
+		footer.LocalKeyId = paseto.LocalKeyId(localKey)
+
+		return json.Marshal(footer)
+	case paseto.PasetoV3Handler:
+		v3Key, err := paseto.GetV3PrivateKey(secretKey)
+
+		if err != nil {
+			return nil, err
+		}
+
+		footer.PublicKeyId = paseto.V3PublicKeyId(&v3Key.PublicKey)
+
+		return json.Marshal(footer)
+	}
+
+	privateKey := paseto.GetPrivateKey(secretKey)
+
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return nil, errors.New("invalid secret key")
+	}
+
+	footer.PublicKeyId = paseto.PublicKeyId(privateKey.Public().(ed25519.PublicKey))
+
+	return json.Marshal(footer)
+}
+
+// parseFooter decodes a token footer. A JSON object is decoded as a TokenFooter; any other
+// footer, such as a custom footer set in the sign options, yields an empty TokenFooter.
+//
+// Parameters:
+//   - footer: The raw token footer
+//
+// Returns:
+//   - The decoded footer
+//   - An error if the footer looks like JSON but cannot be decoded
+func parseFooter(footer []byte) (TokenFooter, error) {
+	if len(footer) == 0 {
+		return TokenFooter{}, nil
+	}
+
+	if !bytes.HasPrefix(footer, []byte("{")) {
+		return TokenFooter{}, nil
+	}
+
+	var data TokenFooter
+
+	if err := json.Unmarshal(footer, &data); err != nil {
+		return TokenFooter{}, errors.New("invalid token footer")
+	}
+
+	return data, nil
+}
+
+// checkFooterKey checks that the key metadata of the footer agrees with the token prefix.
+// Footers without key metadata are accepted.
+func checkFooterKey(footer TokenFooter, decodedQr QrPaymentTokenData) error {
+	if footer.KeyId != "" && footer.KeyId != decodedQr.KeyId {
+		return errors.New("token key metadata mismatch")
+	}
+
+	if footer.KeyIssuer != "" && footer.KeyIssuer != decodedQr.KeyIssuer {
+		return errors.New("token key metadata mismatch")
+	}
+
+	return nil
+}
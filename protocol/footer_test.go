@@ -0,0 +1,180 @@
+package protocol
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/paseto"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Should write the key metadata in a JSON footer readable before verification
+func TestReadFooter(t *testing.T) {
+	assert := assert.New(t)
+
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	var options = certificateOptions("merchant.com", "merchant-key", keys["publicKey"], "")
+	options.KeyFooter = true
+
+	qrToken, err := builder.CreatePaymentInstruction(refundOriginal, keys["secretKey"], options)
+
+	assert.Nil(err)
+
+	footer, err := builder.ReadFooter(qrToken)
+
+	assert.Nil(err)
+	assert.Equal("merchant-key", footer.KeyId)
+	assert.Equal("merchant.com", footer.KeyIssuer)
+	assert.Equal(paseto.PublicKeyId(paseto.GetPublicKey(keys["publicKey"])), footer.PublicKeyId)
+	assert.Empty(footer.KeyCertificate)
+
+	data, err := builder.Read(qrToken, keys["publicKey"], QrCriptoReadOptions{})
+
+	assert.Nil(err)
+	assert.Equal("merchant-key", data.Payload.Kid)
+
+	// Tokens without a footer have an empty one
+	qrToken, _ = builder.CreatePaymentInstruction(refundOriginal, keys["secretKey"], certificateOptions("merchant.com", "merchant-key", keys["publicKey"], ""))
+
+	footer, err = builder.ReadFooter(qrToken)
+
+	assert.Nil(err)
+	assert.Equal(TokenFooter{}, *footer)
+}
+
+// Should carry the key certificate in the JSON footer
+func TestReadWithKeyFooterCertificate(t *testing.T) {
+	assert := assert.New(t)
+
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	rootKeys, _ := paseto.GenerateKey("public", "paserk")
+
+	var now = time.Now()
+
+	var rootOptions = certificateOptions("psp.com", "root-1", rootKeys["publicKey"], "")
+	rootOptions.KeyFooter = true
+
+	certificate, err := builder.CreateKeyCertificate(KeyCertificate{
+		PublicKey: keys["publicKey"],
+		KeyId:     "merchant-key",
+		KeyIssuer: "merchant.com",
+		NotBefore: now.Add(-time.Hour).UnixMilli(),
+		NotAfter:  now.Add(time.Hour).UnixMilli(),
+	}, rootKeys["secretKey"], rootOptions)
+
+	assert.Nil(err)
+
+	var options = certificateOptions("merchant.com", "merchant-key", keys["publicKey"], certificate)
+	options.KeyFooter = true
+
+	qrToken, err := builder.CreatePaymentInstruction(refundOriginal, keys["secretKey"], options)
+
+	assert.Nil(err)
+
+	footer, _ := builder.ReadFooter(qrToken)
+
+	assert.Equal(certificate, footer.KeyCertificate)
+
+	data, err := builder.Read(qrToken, "", QrCriptoReadOptions{TrustAnchors: StaticKeyResolver{"psp.com": {"root-1": rootKeys["publicKey"]}}})
+
+	assert.Nil(err)
+	assert.Equal("merchant.com", data.Payload.Kis)
+}
+
+// Should reject tokens whose prefix, footer and claims disagree
+func TestReadKeyMetadataMismatch(t *testing.T) {
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	otherKeys, _ := paseto.GenerateKey("public", "paserk")
+
+	var footerOptions = certificateOptions("merchant.com", "merchant-key", keys["publicKey"], "")
+	footerOptions.KeyFooter = true
+
+	withFooter, _ := builder.CreatePaymentInstruction(refundOriginal, keys["secretKey"], footerOptions)
+	withoutFooter, _ := builder.CreatePaymentInstruction(refundOriginal, keys["secretKey"], certificateOptions("merchant.com", "merchant-key", keys["publicKey"], ""))
+
+	var forgedOptions = certificateOptions("merchant.com", "merchant-key", keys["publicKey"], "")
+	forgedOptions.SignOptions.Footer = []byte(`{"kid":"merchant-key","kis":"merchant.com","pid":"` + paseto.PublicKeyId(paseto.GetPublicKey(otherKeys["publicKey"])) + `"}`)
+
+	forgedPid, err := builder.CreatePaymentInstruction(refundOriginal, keys["secretKey"], forgedOptions)
+
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name    string
+		qrToken string
+		err     string
+	}{
+		{name: "prefix differs from footer", qrToken: strings.Replace(withFooter, ";merchant-key;", ";other-key;", 1), err: "token key metadata mismatch"},
+		{name: "prefix differs from claims", qrToken: strings.Replace(withoutFooter, "naspip;merchant.com;", "naspip;other.com;", 1), err: "token key metadata mismatch"},
+		{name: "footer key id differs from key", qrToken: forgedPid, err: "public key id mismatch"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := builder.Read(test.qrToken, keys["publicKey"], QrCriptoReadOptions{})
+
+			assert.EqualError(t, err, test.err)
+		})
+	}
+}
+
+// Should only read the key metadata from JSON footers
+func TestParseFooter(t *testing.T) {
+	tests := []struct {
+		name   string
+		footer string
+		want   TokenFooter
+		err    string
+	}{
+		{name: "empty", footer: ""},
+		{name: "json", footer: `{"kid":"merchant-key","kis":"merchant.com"}`, want: TokenFooter{KeyId: "merchant-key", KeyIssuer: "merchant.com"}},
+		{name: "raw certificate", footer: "naspip;psp.com;root-1;v4.public.payload"},
+		{name: "custom footer", footer: "order-1234"},
+		{name: "invalid json", footer: `{"kid":`, err: "invalid token footer"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			footer, err := parseFooter([]byte(test.footer))
+
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.want, footer)
+		})
+	}
+}
+
+// Should reject custom footers combined with the JSON footer
+func TestCreateWithCustomAndKeyFooter(t *testing.T) {
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	var options = certificateOptions("merchant.com", "merchant-key", keys["publicKey"], "")
+	options.SignOptions.Footer = []byte("order-1234")
+
+	_, err := builder.CreatePaymentInstruction(refundOriginal, keys["secretKey"], options)
+
+	assert.Nil(t, err)
+
+	options.KeyFooter = true
+
+	_, err = builder.CreatePaymentInstruction(refundOriginal, keys["secretKey"], options)
+
+	assert.EqualError(t, err, "custom footer cannot be combined with the key footer")
+
+	options.KeyFooter = false
+	options.KeyCertificate = "naspip;psp.com;root-1;v4.public.payload"
+
+	_, err = builder.CreatePaymentInstruction(refundOriginal, keys["secretKey"], options)
+
+	assert.EqualError(t, err, "custom footer cannot be combined with the key footer")
+}
@@ -0,0 +1,473 @@
+package protocol
+
+import (
+	"errors"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/encoding/protobuf"
+	"github.com/fluxisus/naspip-go/v3/utils"
+	"github.com/shopspring/decimal"
+	validator "github.com/tiendc/go-validator"
+)
+
+// Mandate period units.
+const (
+	MandateIntervalDay   = "day"
+	MandateIntervalWeek  = "week"
+	MandateIntervalMonth = "month"
+	MandateIntervalYear  = "year"
+)
+
+// MandatePayload represents a recurring payment mandate (e.g., a subscription).
+// The merchant signs the mandate and the payer countersigns it with a MandateAcceptance.
+// Each period the merchant may request one charge, as a regular InstructionPayload,
+// of at most MaxAmount in the mandate asset. The mandate ends after Count periods or at
+// EndAt, whichever comes first.
+type MandatePayload struct {
+	Id            string               `json:"id"`                    // Unique mandate identifier
+	UniqueAssetId string               `json:"unique_asset_id"`       // Asset identifier of the charges
+	MaxAmount     string               `json:"max_amount"`            // Maximum amount charged per period
+	Interval      string               `json:"interval"`              // Period unit: day, week, month or year
+	IntervalCount int32                `json:"interval_count"`        // Number of units per period (e.g., 3 months)
+	StartAt       int64                `json:"start_at"`              // Unix timestamp when the first period starts
+	Count         int32                `json:"count,omitempty"`       // Number of periods
+	EndAt         int64                `json:"end_at,omitempty"`      // Unix timestamp when the mandate ends
+	Merchant      *InstructionMerchant `json:"merchant,omitempty"`    // Merchant information
+	Description   string               `json:"description,omitempty"` // Mandate description
+}
+
+// MandateAcceptance represents the payer's countersignature of a mandate.
+// It is signed with the payer's key and references the merchant-signed mandate token.
+type MandateAcceptance struct {
+	MandateHash string `json:"mandate_hash"`    // SHA-256 hash (hex) of the mandate NASPIP token, see InstructionHash
+	MandateId   string `json:"mandate_id"`      // Identifier of the accepted mandate
+	Payer       string `json:"payer,omitempty"` // Payer identifier (e.g., account or address)
+	AcceptedAt  int64  `json:"accepted_at"`     // Unix timestamp when the mandate was accepted
+}
+
+// MandatePeriod represents one charge period of a mandate.
+type MandatePeriod struct {
+	Index int       // Zero-based period number
+	Start time.Time // Start of the period (inclusive)
+	End   time.Time // End of the period (exclusive)
+}
+
+// Clock provides the current time. It allows mandate schedules to be evaluated at a
+// given instant, for example in tests or when processing charges in batches.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is a Clock that returns the system time.
+type SystemClock struct{}
+
+// Now returns the current system time.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Period returns the charge period with the given index.
+// Periods are computed from StartAt so that month and year periods do not drift; days past
+// the end of a shorter month are moved to its last day.
+//
+// Parameters:
+//   - index: Zero-based period number
+//
+// Returns:
+//   - The period, regardless of whether it falls within the mandate limits
+func (m MandatePayload) Period(index int) MandatePeriod {
+	return MandatePeriod{Index: index, Start: m.periodStart(index), End: m.periodStart(index + 1)}
+}
+
+// DuePeriods computes the charge periods that have started by the clock's current time
+// and fall within the mandate limits. Callers remove the periods already charged to obtain
+// the ones still pending.
+//
+// Parameters:
+//   - clock: The clock used to get the current time, SystemClock when nil
+//
+// Returns:
+//   - The started periods, oldest first
+func (m MandatePayload) DuePeriods(clock Clock) []MandatePeriod {
+	if clock == nil {
+		clock = SystemClock{}
+	}
+
+	now := clock.Now()
+	periods := []MandatePeriod{}
+
+	for index := 0; m.inRange(index); index++ {
+		period := m.Period(index)
+
+		if period.Start.After(now) {
+			break
+		}
+
+		periods = append(periods, period)
+	}
+
+	return periods
+}
+
+// ValidateMandateCharge checks that a per-period charge stays within the mandate limits.
+// The charge must be requested during an active period, in the mandate asset, and for at
+// most MaxAmount (open amounts must set a MaxAmount within the limit).
+//
+// Parameters:
+//   - mandate: The mandate authorizing the charge
+//   - charge: The payment instruction for the period
+//   - clock: The clock used to get the current time, SystemClock when nil
+//
+// Returns:
+//   - The period being charged
+//   - An error if no period is active or the charge exceeds the mandate limits
+func ValidateMandateCharge(mandate MandatePayload, charge InstructionPayload, clock Clock) (MandatePeriod, error) {
+	if clock == nil {
+		clock = SystemClock{}
+	}
+
+	periods := mandate.DuePeriods(clock)
+
+	if len(periods) == 0 {
+		return MandatePeriod{}, errors.New("mandate not started")
+	}
+
+	period := periods[len(periods)-1]
+
+	if !clock.Now().Before(period.End) || (mandate.EndAt > 0 && clock.Now().UnixMilli() >= mandate.EndAt) {
+		return MandatePeriod{}, errors.New("mandate expired")
+	}
+
+	if charge.Payment.UniqueAssetId != mandate.UniqueAssetId {
+		return MandatePeriod{}, errors.New("mandate asset mismatch")
+	}
+
+	amount := charge.Payment.Amount
+
+	if charge.Payment.IsOpen {
+		amount = charge.Payment.MaxAmount
+	}
+
+	value, errValue := decimal.NewFromString(amount)
+	limit, errLimit := decimal.NewFromString(mandate.MaxAmount)
+
+	if errValue != nil || errLimit != nil || value.GreaterThan(limit) {
+		return MandatePeriod{}, errors.New("mandate amount exceeds period limit")
+	}
+
+	return period, nil
+}
+
+// CreateMandate creates a NASPIP token containing a recurring payment mandate signed by the merchant.
+//
+// Parameters:
+//   - data: The mandate payload to encode in the token
+//   - secretKey: The merchant private key (in raw or PASERK format) to sign the token
+//   - options: Options for token creation
+//
+// Returns:
+//   - A NASPIP token string if creation succeeds
+//   - An error if validation or creation fails
+func (p PaymentInstructionsBuilder) CreateMandate(data MandatePayload, secretKey string, options QrCriptoCreateOptions) (string, error) {
+
+	if data.IntervalCount == 0 {
+		data.IntervalCount = 1
+	}
+
+	isValid, err := validateMandatePayload(data)
+
+	if !isValid {
+		return "", err
+	}
+
+	if p.AssetRegistry != nil {
+		if err := p.AssetRegistry.ValidatePayment(PaymentInstruction{UniqueAssetId: data.UniqueAssetId, Amount: data.MaxAmount}); err != nil {
+			return "", err
+		}
+	}
+
+	protoPayload := &protobuf.MandatePayload{}
+	if err := protobuf.ConvertGoToProto(data, protoPayload); err != nil {
+		return "", err
+	}
+
+	var payload = &protobuf.PasetoTokenData{
+		Data: &protobuf.PasetoTokenData_MandatePayload{
+			MandatePayload: protoPayload,
+		},
+	}
+
+	return p.create(payload, secretKey, options)
+}
+
+// ReadMandate reads and verifies a NASPIP token containing a recurring payment mandate.
+//
+// Parameters:
+//   - qrPayment: A NASPIP mandate token to verify
+//   - publicKey: The merchant public key (in raw or PASERK format)
+//   - options: Options controlling verification behavior
+//
+// Returns:
+//   - The mandate payload if verification succeeds
+//   - An error if verification fails or the token does not contain a mandate
+func (p PaymentInstructionsBuilder) ReadMandate(qrPayment string, publicKey string, options QrCriptoReadOptions) (*MandatePayload, error) {
+	data, err := p.Read(qrPayment, publicKey, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := data.Payload.Data["interval"]; !ok {
+		return nil, errors.New("token does not contain a mandate payload")
+	}
+
+	var mandate MandatePayload
+
+	if err := PayloadOf(data.Payload.Data, &mandate); err != nil {
+		return nil, err
+	}
+
+	return &mandate, nil
+}
+
+// CountersignMandate creates the payer's acceptance of a merchant-signed mandate token.
+// The mandate is verified with ReadMandate first, so only mandates signed by the merchant key
+// are accepted, and mandates that have ended (past their last period or EndAt) are rejected.
+//
+// Parameters:
+//   - mandateToken: The merchant-signed NASPIP mandate token
+//   - merchantPublicKey: The merchant public key (in raw or PASERK format) to verify the mandate
+//   - payer: Payer identifier (e.g., account or address), may be empty
+//   - secretKey: The payer private key (in raw or PASERK format) to sign the token
+//   - readOptions: Options controlling the verification of the mandate; its Clock gives the acceptance time
+//   - options: Options for token creation
+//
+// Returns:
+//   - A NASPIP token string containing the acceptance if creation succeeds
+//   - An error if the mandate cannot be verified, has ended, or creation fails
+func (p PaymentInstructionsBuilder) CountersignMandate(mandateToken string, merchantPublicKey string, payer string, secretKey string, readOptions QrCriptoReadOptions, options QrCriptoCreateOptions) (string, error) {
+	mandate, err := p.ReadMandate(mandateToken, merchantPublicKey, readOptions)
+
+	if err != nil {
+		return "", err
+	}
+
+	clock := readOptions.Clock
+
+	if clock == nil {
+		clock = SystemClock{}
+	}
+
+	if mandate.ended(clock.Now()) {
+		return "", errors.New("mandate expired")
+	}
+
+	data := MandateAcceptance{
+		MandateHash: InstructionHash(mandateToken),
+		MandateId:   mandate.Id,
+		Payer:       payer,
+		AcceptedAt:  clock.Now().UnixMilli(),
+	}
+
+	protoPayload := &protobuf.MandateAcceptance{}
+	if err := protobuf.ConvertGoToProto(data, protoPayload); err != nil {
+		return "", err
+	}
+
+	var payload = &protobuf.PasetoTokenData{
+		Data: &protobuf.PasetoTokenData_MandateAcceptance{
+			MandateAcceptance: protoPayload,
+		},
+	}
+
+	return p.create(payload, secretKey, options)
+}
+
+// ReadMandateAcceptance reads and verifies the payer's acceptance of a mandate and checks
+// that it references the given mandate token.
+//
+// Parameters:
+//   - acceptanceToken: The NASPIP acceptance token to verify
+//   - publicKey: The payer public key (in raw or PASERK format)
+//   - mandateToken: The merchant-signed NASPIP mandate token
+//   - options: Options controlling verification behavior
+//
+// Returns:
+//   - The acceptance if verification succeeds
+//   - An error if verification fails or the acceptance references another mandate
+func (p PaymentInstructionsBuilder) ReadMandateAcceptance(acceptanceToken string, publicKey string, mandateToken string, options QrCriptoReadOptions) (*MandateAcceptance, error) {
+	data, err := p.Read(acceptanceToken, publicKey, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := data.Payload.Data["mandate_hash"]; !ok {
+		return nil, errors.New("token does not contain a mandate acceptance")
+	}
+
+	var acceptance MandateAcceptance
+
+	if err := PayloadOf(data.Payload.Data, &acceptance); err != nil {
+		return nil, err
+	}
+
+	mandate, err := decodeMandate(mandateToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if acceptance.MandateHash != InstructionHash(mandateToken) || acceptance.MandateId != mandate.Id {
+		return nil, errors.New("acceptance does not reference the mandate")
+	}
+
+	return &acceptance, nil
+}
+
+// periodStart returns the start time of the period with the given index.
+func (m MandatePayload) periodStart(index int) time.Time {
+	start := time.UnixMilli(m.StartAt).UTC()
+	units := index * int(max(m.IntervalCount, 1))
+
+	switch m.Interval {
+	case MandateIntervalDay:
+		return start.AddDate(0, 0, units)
+	case MandateIntervalWeek:
+		return start.AddDate(0, 0, 7*units)
+	case MandateIntervalMonth:
+		return addMonths(start, units)
+	default:
+		return addMonths(start, 12*units)
+	}
+}
+
+// addMonths adds months to t, clamping the day to the last day of the resulting month
+// (e.g., January 31 plus one month is February 28) instead of overflowing into the next one.
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+
+	return first.AddDate(0, 0, min(t.Day(), lastDay)-1)
+}
+
+// inRange reports whether the period with the given index falls within the mandate limits.
+func (m MandatePayload) inRange(index int) bool {
+	if m.Count > 0 && index >= int(m.Count) {
+		return false
+	}
+
+	if m.EndAt > 0 && m.periodStart(index).UnixMilli() >= m.EndAt {
+		return false
+	}
+
+	return m.Count > 0 || m.EndAt > 0
+}
+
+// ended reports whether the mandate has no period left at the given time.
+func (m MandatePayload) ended(now time.Time) bool {
+	if m.EndAt > 0 && now.UnixMilli() >= m.EndAt {
+		return true
+	}
+
+	return m.Count > 0 && !now.Before(m.periodStart(int(m.Count)))
+}
+
+// decodeMandate decodes a mandate token without verifying it.
+//
+// Parameters:
+//   - mandateToken: The NASPIP mandate token
+//
+// Returns:
+//   - The mandate payload
+//   - An error if the token cannot be decoded or does not contain a mandate
+func decodeMandate(mandateToken string) (MandatePayload, error) {
+	data, err := decodeTokenData(mandateToken)
+
+	if err != nil {
+		return MandatePayload{}, err
+	}
+
+	if _, ok := data["interval"]; !ok {
+		return MandatePayload{}, errors.New("token does not contain a mandate payload")
+	}
+
+	if startAt, ok := data["start_at"].(string); ok {
+		data["start_at"] = utils.FormatStringTimestampToUnixMilli(startAt)
+	}
+
+	if endAt, ok := data["end_at"].(string); ok {
+		data["end_at"] = utils.FormatStringTimestampToUnixMilli(endAt)
+	}
+
+	var mandate MandatePayload
+
+	if err := PayloadOf(data, &mandate); err != nil {
+		return MandatePayload{}, err
+	}
+
+	return mandate, nil
+}
+
+// validateMandatePayload performs validation on a mandate payload.
+//
+// Parameters:
+//   - payload: The mandate payload to validate
+//
+// Returns:
+//   - true if the payload passes all validation rules
+//   - false and an error describing the problem if validation fails
+func validateMandatePayload(payload MandatePayload) (bool, error) {
+	validations := []validator.Validator{
+		validator.StrLen(&payload.Id, 1, 1000).OnError(
+			validator.SetField("id", nil),
+		),
+		validator.StrLen(&payload.UniqueAssetId, 1, 100).OnError(
+			validator.SetField("unique_asset_id", nil),
+		),
+		validator.Must(utils.BiggerThanZero(payload.MaxAmount)).OnError(
+			validator.SetField("max_amount", nil),
+			validator.SetCustomKey("MANDATE_MAX_AMOUNT_INVALID"),
+		),
+		validator.StrIn(&payload.Interval, MandateIntervalDay, MandateIntervalWeek, MandateIntervalMonth, MandateIntervalYear).OnError(
+			validator.SetField("interval", nil),
+		),
+		validator.NumRange(&payload.IntervalCount, 1, 366).OnError(
+			validator.SetField("interval_count", nil),
+		),
+		validator.NumGT(&payload.StartAt, 0).OnError(
+			validator.SetField("start_at", nil),
+		),
+		validator.NumGTE(&payload.Count, 0).OnError(
+			validator.SetField("count", nil),
+		),
+		validator.Must(payload.Count > 0 || payload.EndAt > 0).OnError(
+			validator.SetField("count", nil),
+			validator.SetCustomKey("MANDATE_COUNT_OR_END_AT_REQUIRED"),
+		),
+		validator.When(payload.EndAt != 0).Then(
+			validator.NumGT(&payload.EndAt, payload.StartAt).OnError(
+				validator.SetField("end_at", nil),
+			)),
+		validator.When(payload.Description != "").Then(
+			validator.StrLen(&payload.Description, 1, 200).OnError(
+				validator.SetField("description", nil),
+			)),
+	}
+
+	if payload.Merchant != nil {
+		validations = append(validations,
+			validator.StrLen(&payload.Merchant.Name, 3, 100).OnError(
+				validator.SetField("merchant_name", nil),
+			),
+		)
+	}
+
+	errs := validator.Validate(validations...)
+
+	if len(errs) > 0 {
+		return false, errs[0]
+	}
+
+	return true, nil
+}
@@ -0,0 +1,271 @@
+package protocol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fixedClock is a Clock that always returns the same time
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+var mandateStart = time.Date(2025, time.January, 31, 12, 0, 0, 0, time.UTC)
+
+var mandate = MandatePayload{
+	Id:            "mandate-id",
+	UniqueAssetId: "ntrc20_tTR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+	MaxAmount:     "25",
+	Interval:      MandateIntervalMonth,
+	IntervalCount: 1,
+	StartAt:       mandateStart.UnixMilli(),
+	Count:         12,
+	Merchant:      &InstructionMerchant{Name: "SaaS Inc."},
+}
+
+// Should create, countersign and read a mandate
+func TestCreateAndCountersignMandate(t *testing.T) {
+	assert := assert.New(t)
+
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	var options = QrCriptoCreateOptions{
+		SignOptions:   paseto.PasetoSignOptions{KeyId: "key-id-one", ExpiresIn: "1d", Assertion: []byte(keys["publicKey"])},
+		KeyIssuer:     "payment-processor.com",
+		KeyExpiration: time.Now().Add(1e9).Format(utils.RFC3339Mili),
+	}
+
+	mandateToken, err := builder.CreateMandate(mandate, keys["secretKey"], options)
+
+	if err != nil {
+		t.Errorf("TestCreateAndCountersignMandate FAIL --> %v", err)
+	}
+
+	data, err := builder.ReadMandate(mandateToken, keys["publicKey"], QrCriptoReadOptions{KeyIssuer: "payment-processor.com"})
+
+	if err != nil {
+		t.Errorf("TestCreateAndCountersignMandate FAIL --> %v", err)
+	}
+
+	assert.Equal(mandate, *data)
+
+	var countersignOptions = QrCriptoReadOptions{KeyIssuer: "payment-processor.com", Clock: fixedClock(mandateStart)}
+
+	acceptanceToken, err := builder.CountersignMandate(mandateToken, keys["publicKey"], "payer-address", keys["secretKey"], countersignOptions, options)
+
+	if err != nil {
+		t.Errorf("TestCreateAndCountersignMandate FAIL --> %v", err)
+	}
+
+	acceptance, err := builder.ReadMandateAcceptance(acceptanceToken, keys["publicKey"], mandateToken, QrCriptoReadOptions{})
+
+	if err != nil {
+		t.Errorf("TestCreateAndCountersignMandate FAIL --> %v", err)
+	}
+
+	assert.Equal("mandate-id", acceptance.MandateId)
+	assert.Equal("payer-address", acceptance.Payer)
+	assert.Equal(InstructionHash(mandateToken), acceptance.MandateHash)
+	assert.Equal(mandateStart.UnixMilli(), acceptance.AcceptedAt)
+
+	other := mandate
+	other.Id = "other-mandate-id"
+
+	otherToken, _ := builder.CreateMandate(other, keys["secretKey"], options)
+
+	_, err = builder.ReadMandateAcceptance(acceptanceToken, keys["publicKey"], otherToken, QrCriptoReadOptions{})
+
+	assert.EqualError(err, "acceptance does not reference the mandate")
+
+	_, err = builder.ReadMandate(acceptanceToken, keys["publicKey"], QrCriptoReadOptions{})
+
+	assert.EqualError(err, "token does not contain a mandate payload")
+}
+
+// Should refuse to countersign forged or ended mandates
+func TestCountersignInvalidMandate(t *testing.T) {
+	assert := assert.New(t)
+
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	var options = QrCriptoCreateOptions{
+		SignOptions:   paseto.PasetoSignOptions{KeyId: "key-id-one", ExpiresIn: "1d", Assertion: []byte(keys["publicKey"])},
+		KeyIssuer:     "payment-processor.com",
+		KeyExpiration: mandateStart.AddDate(5, 0, 0).Format(utils.RFC3339Mili),
+	}
+
+	payerKeys, _ := paseto.GenerateKey("public", "paserk")
+	forgerKeys, _ := paseto.GenerateKey("public", "paserk")
+
+	forged, _ := builder.CreateMandate(mandate, forgerKeys["secretKey"], options)
+
+	_, err := builder.CountersignMandate(forged, keys["publicKey"], "payer-address", payerKeys["secretKey"], QrCriptoReadOptions{Clock: fixedClock(mandateStart)}, options)
+
+	assert.NotNil(err)
+
+	mandateToken, _ := builder.CreateMandate(mandate, keys["secretKey"], options)
+
+	tests := []struct {
+		name string
+		now  time.Time
+	}{
+		{name: "after the last period", now: time.Date(2026, time.January, 31, 12, 0, 0, 0, time.UTC)},
+		{name: "long after the last period", now: time.Date(2027, time.March, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		_, err := builder.CountersignMandate(mandateToken, keys["publicKey"], "payer-address", payerKeys["secretKey"], QrCriptoReadOptions{Clock: fixedClock(test.now)}, options)
+
+		assert.EqualError(err, "mandate expired", test.name)
+	}
+
+	ending := mandate
+	ending.Count = 0
+	ending.EndAt = mandateStart.Add(24 * time.Hour).UnixMilli()
+
+	endingToken, _ := builder.CreateMandate(ending, keys["secretKey"], options)
+
+	_, err = builder.CountersignMandate(endingToken, keys["publicKey"], "payer-address", payerKeys["secretKey"], QrCriptoReadOptions{Clock: fixedClock(mandateStart.Add(24 * time.Hour))}, options)
+
+	assert.EqualError(err, "mandate expired")
+
+	_, err = builder.CountersignMandate(endingToken, keys["publicKey"], "payer-address", payerKeys["secretKey"], QrCriptoReadOptions{Clock: fixedClock(mandateStart.Add(time.Hour))}, options)
+
+	assert.Nil(err)
+}
+
+// Should compute the due periods of a mandate
+func TestMandateDuePeriods(t *testing.T) {
+	tests := []struct {
+		name    string
+		mandate MandatePayload
+		now     time.Time
+		due     int
+		last    time.Time
+	}{
+		{
+			name:    "before start",
+			mandate: mandate,
+			now:     mandateStart.Add(-time.Hour),
+			due:     0,
+		},
+		{
+			name:    "third month",
+			mandate: mandate,
+			now:     time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC),
+			due:     3,
+			last:    time.Date(2025, time.March, 31, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "after count",
+			mandate: mandate,
+			now:     mandateStart.AddDate(5, 0, 0),
+			due:     12,
+			last:    time.Date(2025, time.December, 31, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "weekly until end date",
+			mandate: MandatePayload{
+				Interval: MandateIntervalWeek, IntervalCount: 2, StartAt: mandateStart.UnixMilli(),
+				EndAt: mandateStart.AddDate(0, 0, 43).UnixMilli(),
+			},
+			now:  mandateStart.AddDate(1, 0, 0),
+			due:  4,
+			last: mandateStart.AddDate(0, 0, 42),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			periods := test.mandate.DuePeriods(fixedClock(test.now))
+
+			assert.Len(t, periods, test.due)
+
+			if test.due > 0 {
+				assert.Equal(t, test.last, periods[len(periods)-1].Start)
+				assert.Equal(t, test.due-1, periods[len(periods)-1].Index)
+			}
+		})
+	}
+}
+
+// Should use the system clock when no clock is given
+func TestMandateWithoutClock(t *testing.T) {
+	var charge = InstructionPayload{
+		Payment: PaymentInstruction{Id: "charge-id", UniqueAssetId: mandate.UniqueAssetId, Address: "merchant-address", Amount: "25"},
+	}
+
+	var current = mandate
+	current.StartAt = time.Now().Add(-time.Hour).UnixMilli()
+
+	assert.Len(t, current.DuePeriods(nil), 1)
+
+	period, err := ValidateMandateCharge(current, charge, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, period.Index)
+}
+
+// Should validate per-period charges against the mandate limits
+func TestValidateMandateCharge(t *testing.T) {
+	var charge = InstructionPayload{
+		Payment: PaymentInstruction{
+			Id:            "charge-id",
+			UniqueAssetId: mandate.UniqueAssetId,
+			Address:       "merchant-address",
+			Amount:        "25",
+		},
+	}
+
+	var now = time.Date(2025, time.March, 5, 0, 0, 0, 0, time.UTC)
+
+	period, err := ValidateMandateCharge(mandate, charge, fixedClock(now))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, period.Index)
+	assert.Equal(t, time.Date(2025, time.February, 28, 12, 0, 0, 0, time.UTC), period.Start)
+	assert.Equal(t, time.Date(2025, time.March, 31, 12, 0, 0, 0, time.UTC), period.End)
+
+	tests := []struct {
+		name   string
+		update func(charge *InstructionPayload)
+		now    time.Time
+		err    string
+	}{
+		{name: "amount above limit", update: func(c *InstructionPayload) { c.Payment.Amount = "25.01" }, now: now, err: "mandate amount exceeds period limit"},
+		{name: "open amount without maximum", update: func(c *InstructionPayload) { c.Payment.IsOpen = true }, now: now, err: "mandate amount exceeds period limit"},
+		{name: "other asset", update: func(c *InstructionPayload) { c.Payment.UniqueAssetId = "other-asset" }, now: now, err: "mandate asset mismatch"},
+		{name: "not started", update: func(c *InstructionPayload) {}, now: mandateStart.Add(-time.Minute), err: "mandate not started"},
+		{name: "expired", update: func(c *InstructionPayload) {}, now: mandateStart.AddDate(1, 0, 1), err: "mandate expired"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			invalid := charge
+			test.update(&invalid)
+
+			_, err := ValidateMandateCharge(mandate, invalid, fixedClock(test.now))
+
+			assert.EqualError(t, err, test.err)
+		})
+	}
+}
+
+// Should fail to create a mandate without count or end date
+func TestCreateMandateInvalid(t *testing.T) {
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	invalid := mandate
+	invalid.Count = 0
+
+	_, err := builder.CreateMandate(invalid, keys["secretKey"], QrCriptoCreateOptions{})
+
+	assert.Contains(t, err.Error(), "count")
+}
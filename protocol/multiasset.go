@@ -0,0 +1,172 @@
+package protocol
+
+import (
+	"errors"
+
+	"github.com/fluxisus/naspip-go/v3/encoding/protobuf"
+	validator "github.com/tiendc/go-validator"
+)
+
+// MultiAssetPayload represents a payment instruction with several payment options.
+// The payer chooses one of the options (asset/network) to complete the payment,
+// so a merchant accepting the same asset on several networks can show a single QR.
+type MultiAssetPayload struct {
+	Payments []PaymentInstruction `json:"payments"`        // Available payment options, one per asset
+	Order    *InstructionOrder    `json:"order,omitempty"` // Optional order information
+}
+
+// SupportedPayments returns the payment options whose asset is in the given list.
+// Wallets use it to hide the options they cannot pay with.
+//
+// Parameters:
+//   - uniqueAssetIds: The asset IDs supported by the wallet
+//
+// Returns:
+//   - The supported payment options, in the order they appear in the payload
+func (m MultiAssetPayload) SupportedPayments(uniqueAssetIds []string) []PaymentInstruction {
+	supported := make(map[string]bool, len(uniqueAssetIds))
+
+	for _, id := range uniqueAssetIds {
+		supported[id] = true
+	}
+
+	payments := []PaymentInstruction{}
+
+	for _, payment := range m.Payments {
+		if supported[payment.UniqueAssetId] {
+			payments = append(payments, payment)
+		}
+	}
+
+	return payments
+}
+
+// SelectPayment returns the instruction for the payment option with the given asset.
+// The resulting InstructionPayload carries the order information of the multi-asset payload.
+//
+// Parameters:
+//   - uniqueAssetId: The asset chosen by the payer
+//
+// Returns:
+//   - The payment instruction for the chosen asset
+//   - An error if the payload has no option for the asset
+func (m MultiAssetPayload) SelectPayment(uniqueAssetId string) (InstructionPayload, error) {
+	for _, payment := range m.Payments {
+		if payment.UniqueAssetId == uniqueAssetId {
+			return InstructionPayload{Payment: payment, Order: m.Order}, nil
+		}
+	}
+
+	return InstructionPayload{}, errors.New("payment option not found")
+}
+
+// CreateMultiAssetPayment creates a NASPIP token containing several payment options.
+// Every option is validated as a payment instruction and all of them are signed together.
+//
+// Parameters:
+//   - data: The multi-asset payload to encode in the token
+//   - secretKey: The private key (in raw or PASERK format) to sign the token
+//   - options: Options for token creation
+//
+// Returns:
+//   - A NASPIP token string if creation succeeds
+//   - An error if validation or creation fails
+func (p PaymentInstructionsBuilder) CreateMultiAssetPayment(data MultiAssetPayload, secretKey string, options QrCriptoCreateOptions) (string, error) {
+
+	isValid, err := validateMultiAssetPayload(data)
+
+	if !isValid {
+		return "", err
+	}
+
+	if p.AssetRegistry != nil {
+		for _, payment := range data.Payments {
+			if err := p.AssetRegistry.ValidatePayment(payment); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if p.StrictOrder && data.Order != nil {
+		if err := ValidateOrderConsistency(*data.Order); err != nil {
+			return "", err
+		}
+	}
+
+	protoPayload := &protobuf.MultiAssetPayload{}
+	if err := protobuf.ConvertGoToProto(data, protoPayload); err != nil {
+		return "", err
+	}
+
+	var payload = &protobuf.PasetoTokenData{
+		Data: &protobuf.PasetoTokenData_MultiAssetPayload{
+			MultiAssetPayload: protoPayload,
+		},
+	}
+
+	return p.create(payload, secretKey, options)
+}
+
+// ReadMultiAssetPayment reads and verifies a NASPIP token containing several payment options.
+//
+// Parameters:
+//   - qrPayment: A NASPIP token string to verify
+//   - publicKey: The public key (in raw or PASERK format) to verify the token signature
+//   - options: Options controlling verification behavior
+//
+// Returns:
+//   - The multi-asset payload if verification succeeds
+//   - An error if verification fails or the token does not contain a multi-asset payload
+func (p PaymentInstructionsBuilder) ReadMultiAssetPayment(qrPayment string, publicKey string, options QrCriptoReadOptions) (*MultiAssetPayload, error) {
+	data, err := p.Read(qrPayment, publicKey, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := data.Payload.Data["payments"]; !ok {
+		return nil, errors.New("token does not contain a multi-asset payload")
+	}
+
+	var payload MultiAssetPayload
+
+	if err := PayloadOf(data.Payload.Data, &payload); err != nil {
+		return nil, err
+	}
+
+	return &payload, nil
+}
+
+// validateMultiAssetPayload performs validation on a multi-asset payload.
+// It checks the number of options, that no asset is offered twice, and validates each
+// option together with the order as a payment instruction payload.
+//
+// Parameters:
+//   - payload: The multi-asset payload to validate
+//
+// Returns:
+//   - true if the payload passes all validation rules
+//   - false and an error describing the problem if validation fails
+func validateMultiAssetPayload(payload MultiAssetPayload) (bool, error) {
+	errs := validator.Validate(
+		validator.SliceLen(payload.Payments, 1, 20).OnError(
+			validator.SetField("payments", nil),
+		),
+		validator.SliceUniqueBy(payload.Payments, func(elem PaymentInstruction) string { return elem.UniqueAssetId }).OnError(
+			validator.SetField("payments", nil),
+			validator.SetCustomKey("PAYMENTS_UNIQUE_ASSET_ID_DUPLICATED"),
+		),
+	)
+
+	if len(errs) > 0 {
+		return false, errs[0]
+	}
+
+	for _, payment := range payload.Payments {
+		if isValid, err := validatePaymentInstructionPayload(InstructionPayload{Payment: payment, Order: payload.Order}); !isValid {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
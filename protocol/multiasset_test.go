@@ -0,0 +1,132 @@
+package protocol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var multiAssetPayload = MultiAssetPayload{
+	Payments: []PaymentInstruction{
+		{
+			Id:            "payment-id",
+			UniqueAssetId: "ntrc20_tTR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+			Address:       "tron-address",
+			Amount:        "10.5",
+			ExpiresAt:     time.Now().Add(time.Hour).UnixMilli(),
+		},
+		{
+			Id:            "payment-id",
+			UniqueAssetId: "npolygon_t0xc2132D05D31c914a87C6611C10748AEb04B58e8F",
+			Address:       "polygon-address",
+			Amount:        "10.5",
+			ExpiresAt:     time.Now().Add(time.Hour).UnixMilli(),
+		},
+	},
+	Order: &InstructionOrder{Total: "10.5", CoinCode: "USD", Description: "T-Shirt"},
+}
+
+// Should create and read a multi-asset payment instruction token
+func TestCreateAndReadMultiAssetPayment(t *testing.T) {
+	assert := assert.New(t)
+
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	var options = paseto.PasetoSignOptions{
+		KeyId:     "key-id-one",
+		Issuer:    "qrCrypto.com",
+		ExpiresIn: "5m",
+		Assertion: []byte(keys["publicKey"]),
+	}
+
+	var keyExpiration = time.Now().Add(1e9).Format(utils.RFC3339Mili)
+
+	qrToken, err := builder.CreateMultiAssetPayment(multiAssetPayload,
+		keys["secretKey"],
+		QrCriptoCreateOptions{SignOptions: options, KeyIssuer: "payment-processor.com", KeyExpiration: keyExpiration},
+	)
+
+	if err != nil {
+		t.Errorf("TestCreateAndReadMultiAssetPayment FAIL --> %v, %v", err, qrToken)
+	}
+
+	data, errRead := builder.ReadMultiAssetPayment(qrToken, keys["publicKey"], QrCriptoReadOptions{KeyIssuer: "payment-processor.com"})
+
+	if errRead != nil {
+		t.Errorf("TestCreateAndReadMultiAssetPayment FAIL --> %v", errRead)
+	}
+
+	assert.Equal(multiAssetPayload.Payments, data.Payments)
+	assert.Equal("T-Shirt", data.Order.Description)
+}
+
+// Should fail to read a single payment instruction as a multi-asset payment
+func TestReadMultiAssetPaymentWrongPayload(t *testing.T) {
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	var keyExpiration = time.Now().Add(1e9).Format(utils.RFC3339Mili)
+
+	qrToken, _ := builder.CreatePaymentInstruction(InstructionPayload{Payment: multiAssetPayload.Payments[0]},
+		keys["secretKey"],
+		QrCriptoCreateOptions{SignOptions: paseto.PasetoSignOptions{KeyId: "key-id-one", ExpiresIn: "5m", Assertion: []byte(keys["publicKey"])}, KeyIssuer: "payment-processor.com", KeyExpiration: keyExpiration},
+	)
+
+	_, err := builder.ReadMultiAssetPayment(qrToken, keys["publicKey"], QrCriptoReadOptions{})
+
+	assert.EqualError(t, err, "token does not contain a multi-asset payload")
+}
+
+// Should fail to create a multi-asset payment with invalid options
+func TestCreateInvalidMultiAssetPayment(t *testing.T) {
+	assert := assert.New(t)
+
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	var createOptions = QrCriptoCreateOptions{
+		SignOptions:   paseto.PasetoSignOptions{KeyId: "key-id-one", ExpiresIn: "5m"},
+		KeyIssuer:     "payment-processor.com",
+		KeyExpiration: time.Now().Add(1e9).Format(utils.RFC3339Mili),
+	}
+
+	_, err := builder.CreateMultiAssetPayment(MultiAssetPayload{}, keys["secretKey"], createOptions)
+
+	assert.NotNil(err)
+
+	duplicated := MultiAssetPayload{Payments: []PaymentInstruction{multiAssetPayload.Payments[0], multiAssetPayload.Payments[0]}}
+
+	_, err = builder.CreateMultiAssetPayment(duplicated, keys["secretKey"], createOptions)
+
+	assert.NotNil(err)
+
+	invalid := MultiAssetPayload{Payments: []PaymentInstruction{multiAssetPayload.Payments[0], {Id: "payment-id", UniqueAssetId: "nbitcoin"}}}
+
+	_, err = builder.CreateMultiAssetPayment(invalid, keys["secretKey"], createOptions)
+
+	assert.NotNil(err)
+}
+
+// Should filter and select payment options supported by the wallet
+func TestSupportedPayments(t *testing.T) {
+	assert := assert.New(t)
+
+	supported := multiAssetPayload.SupportedPayments([]string{"npolygon_t0xc2132D05D31c914a87C6611C10748AEb04B58e8F", "nbitcoin"})
+
+	assert.Len(supported, 1)
+	assert.Equal("polygon-address", supported[0].Address)
+
+	assert.Len(multiAssetPayload.SupportedPayments(nil), 0)
+
+	selected, err := multiAssetPayload.SelectPayment("ntrc20_tTR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+
+	assert.Nil(err)
+	assert.Equal("tron-address", selected.Payment.Address)
+	assert.Equal("USD", selected.Order.CoinCode)
+
+	_, err = multiAssetPayload.SelectPayment("nbitcoin")
+
+	assert.EqualError(err, "payment option not found")
+}
@@ -0,0 +1,128 @@
+package protocol
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	validator "github.com/tiendc/go-validator"
+)
+
+// ValidateOrderConsistency checks that the order lines agree with each other.
+// It verifies that the sum of the item amounts, plus taxes not included in the item amounts
+// and shipping, minus discounts, equals the order total; that each item has at least one
+// unit; that each item's unit price multiplied by its quantity equals the item amount; and
+// that item coin codes match the order coin code. Empty optional fields are not checked.
+//
+// Parameters:
+//   - order: The order to check
+//
+// Returns:
+//   - nil if the order is consistent
+//   - A validator.Errors value with one error per offending field, identifying each item by index
+func ValidateOrderConsistency(order InstructionOrder) error {
+	validations := []validator.Validator{}
+
+	sum := decimal.Zero
+	sumIsValid := true
+
+	for index, item := range order.Items {
+		amount, errAmount := decimal.NewFromString(item.Amount)
+
+		if errAmount != nil {
+			sumIsValid = false
+		} else {
+			sum = sum.Add(amount)
+		}
+
+		validations = append(validations,
+			validator.Must(item.Quantity >= 1).OnError(
+				validator.SetField(fmt.Sprintf("order_item_[%d]_quantity", index), nil),
+				validator.SetCustomKey(fmt.Sprintf("ORDER_ITEM_[%d]_QUANTITY_INVALID", index)),
+			),
+			validator.Must(errAmount == nil).OnError(
+				validator.SetField(fmt.Sprintf("order_item_[%d]_amount", index), nil),
+				validator.SetCustomKey(fmt.Sprintf("ORDER_ITEM_[%d]_TOTAL_AMOUNT_INVALID", index)),
+			),
+			validator.When(errAmount == nil && item.UnitPrice != "").Then(
+				validator.Must(itemAmountMatchesUnitPrice(item, amount)).OnError(
+					validator.SetField(fmt.Sprintf("order_item_[%d]_unit_price", index), nil),
+					validator.SetCustomKey(fmt.Sprintf("ORDER_ITEM_[%d]_UNIT_PRICE_MISMATCH", index)),
+				)),
+			validator.When(item.CoinCode != "" && order.CoinCode != "").Then(
+				validator.Must(strings.EqualFold(item.CoinCode, order.CoinCode)).OnError(
+					validator.SetField(fmt.Sprintf("order_item_[%d]_coin_code", index), nil),
+					validator.SetCustomKey(fmt.Sprintf("ORDER_ITEM_[%d]_COIN_CODE_MISMATCH", index)),
+				)),
+		)
+	}
+
+	for _, tax := range order.Taxes {
+		if !tax.Included {
+			sum, sumIsValid = addAmount(sum, tax.Amount, sumIsValid)
+		}
+	}
+
+	discounts := decimal.Zero
+
+	for _, discount := range order.Discounts {
+		discounts, sumIsValid = addAmount(discounts, discount.Amount, sumIsValid)
+	}
+
+	sum = sum.Sub(discounts)
+
+	if order.Shipping != "" {
+		sum, sumIsValid = addAmount(sum, order.Shipping, sumIsValid)
+	}
+
+	validations = append(validations,
+		validator.When(order.Total != "" && len(order.Items) > 0 && sumIsValid).Then(
+			validator.Must(amountEquals(order.Total, sum)).OnError(
+				validator.SetField("order_total_amount", nil),
+				validator.SetCustomKey("ORDER_TOTAL_AMOUNT_MISMATCH"),
+			)),
+	)
+
+	errs := validator.Validate(validations...)
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// itemAmountMatchesUnitPrice reports whether unit price × quantity equals the item amount.
+// Items with less than one unit never match.
+func itemAmountMatchesUnitPrice(item InstructionItem, amount decimal.Decimal) bool {
+	unitPrice, err := decimal.NewFromString(item.UnitPrice)
+
+	if err != nil || item.Quantity < 1 {
+		return false
+	}
+
+	return unitPrice.Mul(decimal.NewFromInt(int64(item.Quantity))).Equal(amount)
+}
+
+// addAmount adds a decimal amount string to sum.
+// The returned boolean is false if the amount cannot be parsed or the sum was already invalid.
+func addAmount(sum decimal.Decimal, value string, isValid bool) (decimal.Decimal, bool) {
+	val, err := decimal.NewFromString(value)
+
+	if err != nil {
+		return sum, false
+	}
+
+	return sum.Add(val), isValid
+}
+
+// amountEquals reports whether a decimal amount string is numerically equal to the given value.
+func amountEquals(value string, expected decimal.Decimal) bool {
+	val, err := decimal.NewFromString(value)
+
+	if err != nil {
+		return false
+	}
+
+	return val.Equal(expected)
+}
@@ -0,0 +1,185 @@
+package protocol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/utils"
+
+	"github.com/stretchr/testify/assert"
+	validator "github.com/tiendc/go-validator"
+)
+
+// Should accept an order whose items add up to the total
+func TestValidateConsistentOrder(t *testing.T) {
+	var order = InstructionOrder{
+		Total:    "25.50",
+		CoinCode: "USD",
+		Items: []InstructionItem{
+			{Description: "T-Shirt", Amount: "20", CoinCode: "USD", UnitPrice: "10", Quantity: 2},
+			{Description: "Sticker", Amount: "5.5", CoinCode: "usd", UnitPrice: "0.55", Quantity: 10},
+		},
+	}
+
+	assert.Nil(t, ValidateOrderConsistency(order))
+	assert.Nil(t, ValidateOrderConsistency(InstructionOrder{Total: "10", CoinCode: "USD"}))
+}
+
+// Should report every offending item of an inconsistent order
+func TestValidateInconsistentOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	var order = InstructionOrder{
+		Total:    "100",
+		CoinCode: "USD",
+		Items: []InstructionItem{
+			{Description: "T-Shirt", Amount: "20", CoinCode: "USD", UnitPrice: "10", Quantity: 3},
+			{Description: "Sticker", Amount: "5", CoinCode: "EUR", Quantity: 1},
+			{Description: "Mug", Amount: "15", CoinCode: "USD", UnitPrice: "15", Quantity: 1},
+		},
+	}
+
+	err := ValidateOrderConsistency(order)
+
+	errs, ok := err.(validator.Errors)
+
+	assert.True(ok)
+	assert.Len(errs, 3)
+
+	var keys []any
+	for _, e := range errs {
+		keys = append(keys, e.CustomKey())
+	}
+
+	assert.Equal([]any{
+		"ORDER_ITEM_[0]_UNIT_PRICE_MISMATCH",
+		"ORDER_ITEM_[1]_COIN_CODE_MISMATCH",
+		"ORDER_TOTAL_AMOUNT_MISMATCH",
+	}, keys)
+}
+
+// Should fail to create payment instruction with inconsistent order only in strict mode
+func TestCreatePaymentWithStrictOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	var payload = InstructionPayload{
+		Payment: PaymentInstruction{
+			Id:            "payment-id",
+			UniqueAssetId: "ntrc20_tTR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+			Address:       "crypto-address",
+			Amount:        "100",
+			ExpiresAt:     time.Now().Add(time.Hour).UnixMilli(),
+		},
+		Order: &InstructionOrder{
+			Total:    "100",
+			CoinCode: "USD",
+			Items:    []InstructionItem{{Description: "T-Shirt", Amount: "90", CoinCode: "USD", Quantity: 1}},
+		},
+	}
+
+	var createOptions = QrCriptoCreateOptions{
+		SignOptions:   paseto.PasetoSignOptions{KeyId: "key-id-one", ExpiresIn: "5m"},
+		KeyIssuer:     "payment-processor.com",
+		KeyExpiration: time.Now().Add(1e9).Format(utils.RFC3339Mili),
+	}
+
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	_, err := builder.CreatePaymentInstruction(payload, keys["secretKey"], createOptions)
+
+	assert.Nil(err)
+
+	builder.StrictOrder = true
+
+	_, err = builder.CreatePaymentInstruction(payload, keys["secretKey"], createOptions)
+
+	assert.NotNil(err)
+
+	_, err = builder.CreateUrlPayload(UrlPayload{Url: "https://www.my-ecommerce.com/checkout", Order: payload.Order}, keys["secretKey"], createOptions)
+
+	assert.NotNil(err)
+}
+
+// Should take taxes, discounts and shipping into account when checking the order total
+func TestValidateOrderWithCharges(t *testing.T) {
+	assert := assert.New(t)
+
+	var order = InstructionOrder{
+		Total:    "123.00",
+		CoinCode: "EUR",
+		Items: []InstructionItem{
+			{Description: "Coffee beans", Amount: "100", CoinCode: "EUR", UnitPrice: "20", Quantity: 5, Sku: "CB-001", UnitOfMeasure: "kg"},
+		},
+		Taxes: []InstructionTax{
+			{TaxType: "VAT", Rate: "21", Amount: "21"},
+			{TaxType: "ECO", Amount: "1", Included: true},
+		},
+		Discounts: []InstructionDiscount{{Description: "WELCOME10", Amount: "10"}},
+		Shipping:  "12",
+	}
+
+	assert.Nil(ValidateOrderConsistency(order))
+
+	order.Shipping = "15"
+
+	assert.NotNil(ValidateOrderConsistency(order))
+}
+
+// Should not match unit prices of items with less than one unit
+func TestValidateOrderZeroQuantity(t *testing.T) {
+	var order = InstructionOrder{
+		Total: "0",
+		Items: []InstructionItem{{Description: "Free sample", Amount: "0", UnitPrice: "5", Quantity: 0}},
+	}
+
+	assert.ErrorContains(t, ValidateOrderConsistency(order), "order_item_[0]_unit_price")
+}
+
+// Should require at least one unit per item only in strict mode
+func TestCreatePaymentWithInvalidItemQuantity(t *testing.T) {
+	var createOptions = QrCriptoCreateOptions{
+		SignOptions:   paseto.PasetoSignOptions{KeyId: "key-id-one", ExpiresIn: "5m"},
+		KeyIssuer:     "payment-processor.com",
+		KeyExpiration: time.Now().Add(1e9).Format(utils.RFC3339Mili),
+	}
+
+	tests := []struct {
+		name  string
+		item  InstructionItem
+		loose bool // Whether the item is accepted without strict mode
+	}{
+		{name: "zero quantity and amount", item: InstructionItem{Description: "Free sample", Amount: "0", CoinCode: "EUR"}},
+		{name: "zero quantity without description", item: InstructionItem{Amount: "0", CoinCode: "EUR"}, loose: true},
+		{name: "negative quantity", item: InstructionItem{Description: "Returned mug", Amount: "0", CoinCode: "EUR", Quantity: -1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var payload = InstructionPayload{
+				Payment: PaymentInstruction{
+					Id:            "payment-id",
+					UniqueAssetId: "ntrc20_tTR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+					Address:       "crypto-address",
+					Amount:        "100",
+					ExpiresAt:     time.Now().Add(time.Hour * 3).UnixMilli(),
+				},
+				Order: &InstructionOrder{Total: "0", CoinCode: "EUR", Items: []InstructionItem{test.item}},
+			}
+
+			var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+			_, err := builder.CreatePaymentInstruction(payload, keys["secretKey"], createOptions)
+
+			if test.loose {
+				assert.Nil(t, err)
+			}
+
+			builder.StrictOrder = true
+
+			_, err = builder.CreatePaymentInstruction(payload, keys["secretKey"], createOptions)
+
+			assert.ErrorContains(t, err, "order_item_[0]_quantity")
+		})
+	}
+}
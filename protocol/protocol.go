@@ -4,6 +4,7 @@
 package protocol
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -15,6 +16,9 @@ import (
 	validator "github.com/tiendc/go-validator"
 )
 
+// ErrKeyExpired is returned when the key expiration (kep) of a token has passed.
+var ErrKeyExpired = errors.New("expired Key")
+
 // QrPaymentTokenData represents the structure of a decoded NASPIP token.
 // This is the result of splitting a NASPIP token string into its components.
 type QrPaymentTokenData struct {
@@ -44,6 +48,7 @@ type UrlPayload struct {
 type InstructionPayload struct {
 	Payment PaymentInstruction `json:"payment"`         // Payment details
 	Order   *InstructionOrder  `json:"order,omitempty"` // Optional order information
+	Quote   *ExchangeRateQuote `json:"quote,omitempty"` // Optional exchange rate used to price the order
 }
 
 // PaymentInstruction contains the essential details needed to make a payment.
@@ -63,11 +68,14 @@ type PaymentInstruction struct {
 // InstructionOrder contains additional information about the order.
 // This provides context for the payment such as merchant details and items purchased.
 type InstructionOrder struct {
-	Total       string               `json:"total"`                 // Total order amount
-	CoinCode    string               `json:"coin_code"`             // Currency code (e.g., USD, EUR)
-	Description string               `json:"description,omitempty"` // Order description
-	Merchant    *InstructionMerchant `json:"merchant,omitempty"`    // Merchant information
-	Items       []InstructionItem    `json:"items,omitempty"`       // Individual items in the order
+	Total       string                `json:"total"`                 // Total order amount
+	CoinCode    string                `json:"coin_code"`             // Currency code (e.g., USD, EUR)
+	Description string                `json:"description,omitempty"` // Order description
+	Merchant    *InstructionMerchant  `json:"merchant,omitempty"`    // Merchant information
+	Items       []InstructionItem     `json:"items,omitempty"`       // Individual items in the order
+	Taxes       []InstructionTax      `json:"taxes,omitempty"`       // Tax lines applied to the order
+	Discounts   []InstructionDiscount `json:"discounts,omitempty"`   // Discount lines applied to the order
+	Shipping    string                `json:"shipping,omitempty"`    // Shipping cost
 }
 
 // InstructionMerchant contains information about the merchant.
@@ -83,32 +91,70 @@ type InstructionMerchant struct {
 // InstructionItem represents an individual item in an order.
 // This provides details about a specific product or service being purchased.
 type InstructionItem struct {
-	Description string `json:"description"`          // Item description
-	Amount      string `json:"amount"`               // Total amount for this item
-	CoinCode    string `json:"coin_code"`            // Currency code for this item
-	UnitPrice   string `json:"unit_price,omitempty"` // Price per unit
-	Quantity    int    `json:"quantity,omitempty"`   // Number of units
+	Description   string `json:"description"`               // Item description
+	Amount        string `json:"amount"`                    // Total amount for this item
+	CoinCode      string `json:"coin_code"`                 // Currency code for this item
+	UnitPrice     string `json:"unit_price,omitempty"`      // Price per unit
+	Quantity      int    `json:"quantity,omitempty"`        // Number of units
+	Sku           string `json:"sku,omitempty"`             // Item SKU or product identifier
+	UnitOfMeasure string `json:"unit_of_measure,omitempty"` // Unit of measure (e.g., unit, kg, hour)
+}
+
+// InstructionTax represents a tax line applied to an order.
+// This allows wallets to display taxes such as VAT on the confirmation screen.
+type InstructionTax struct {
+	TaxType  string `json:"tax_type"`           // Tax type (e.g., VAT, GST, sales_tax)
+	Rate     string `json:"rate,omitempty"`     // Tax rate as a percentage (e.g., "21")
+	Amount   string `json:"amount"`             // Tax amount
+	Included bool   `json:"included,omitempty"` // Whether the tax is already included in item amounts
+}
+
+// InstructionDiscount represents a discount line applied to an order.
+type InstructionDiscount struct {
+	Description string `json:"description,omitempty"` // Discount description (e.g., coupon code)
+	Amount      string `json:"amount"`                // Discount amount
 }
 
 // QrCriptoReadOptions contains options for reading and verifying NASPIP tokens.
 type QrCriptoReadOptions struct {
-	VerifyOptions paseto.PasetoVerifyOptions // PASETO verification options
-	KeyId         string                     // Expected key ID
-	KeyIssuer     string                     // Expected key issuer
-	IgnoreKeyExp  bool                       // Whether to ignore key expiration
+	VerifyOptions     paseto.PasetoVerifyOptions // PASETO verification options
+	KeyId             string                     // Expected key ID
+	KeyIssuer         string                     // Expected key issuer
+	IgnoreKeyExp      bool                       // Whether to ignore key expiration
+	QuoteTolerance    string                     // Allowed difference (percentage) between quoted amount and order total
+	TrustAnchors      KeyResolver                // Root issuer keys used to verify key certificates carried in the footer
+	RevocationChecker RevocationChecker          // Optional lookup of revoked keys
+	Clock             Clock                      // Clock used to check the quote expiration, key expiration and key certificate validity, SystemClock when nil
 }
 
 // QrCriptoCreateOptions contains options for creating NASPIP tokens.
 type QrCriptoCreateOptions struct {
-	SignOptions   paseto.PasetoSignOptions // PASETO signing options
-	KeyIssuer     string                   // Key issuer identifier
-	KeyExpiration string                   // Key expiration date (RFC3339 format)
+	SignOptions    paseto.PasetoSignOptions // PASETO signing options
+	KeyIssuer      string                   // Key issuer identifier
+	KeyExpiration  string                   // Key expiration date (RFC3339 format)
+	QuoteTolerance string                   // Allowed difference (percentage) between quoted amount and order total
+	KeyCertificate string                   // Optional certificate of the signing key, carried in the JSON footer
+	KeyFooter      bool                     // Whether to write the key metadata in a JSON footer (see TokenFooter), implied by KeyCertificate
+	Clock          Clock                    // Clock used to check the quote expiration, SystemClock when nil
 }
 
 // PaymentInstructionsBuilder creates and validates NASPIP payment instructions.
 // It serves as the main entry point for interacting with the NASPIP protocol.
+//
+// With paseto.PasetoV4Handler, tokens are signed with the merchant private key and read with
+// its public key. With paseto.PasetoV4LocalHandler, tokens are encrypted with a symmetric key
+// shared between the PSP and the wallet, which is used both to create and to read them, so the
+// payment and order data cannot be read by anyone scanning the QR code without the key.
+//
+// Read only accepts tokens with the PASETO header of the configured handler. Issuers signing
+// with another public version (e.g., v3.public) are accepted by listing their header in
+// AcceptedHeaders; encrypted (local) tokens are only read by a builder configured with
+// paseto.PasetoV4LocalHandler.
 type PaymentInstructionsBuilder struct {
-	PasetoHandler paseto.PasetoV4 // Handler for PASETO operations
+	PasetoHandler   paseto.PasetoHandler // Handler for PASETO operations
+	AssetRegistry   *AssetRegistry       // Optional registry used to enforce asset amount precision
+	StrictOrder     bool                 // Whether to check that order items add up to the order total
+	AcceptedHeaders []string             // Other public PASETO headers read with their standard handler (e.g., "v3.public.")
 }
 
 // Decode splits a NASPIP token string into its components.
@@ -136,10 +182,29 @@ func (p PaymentInstructionsBuilder) Decode(qrPayment string) (QrPaymentTokenData
 
 // Read decodes and verifies a NASPIP token.
 // It validates the token signature and checks expiration dates and key information.
+// When the token carries an exchange rate quote, the quote is checked with ValidateExchangeRateQuote.
+//
+// When options.TrustAnchors is set and the token footer carries a key certificate, the
+// certificate chain is verified with VerifyKeyCertificate, the token is verified with the
+// certified key instead of publicKey, and the requested assets must be allowed by the certificate.
+//
+// The key ID and key issuer of the token prefix must match the signed claims and, when the
+// token has a JSON footer (see TokenFooter), the footer. A PASERK key identifier in the footer
+// must match the verification key.
+//
+// Tokens created with PasetoV4LocalHandler are decrypted with the shared key (k4.local) passed as
+// publicKey. Tokens of another PASETO version or purpose than the configured handler are
+// rejected, unless their public header is listed in AcceptedHeaders (for example v3.public
+// tokens read by a builder configured with PasetoV4Handler). The key must match the token
+// purpose: local tokens require a k4.local key and public tokens a public key.
+//
+// When options.RevocationChecker is set, tokens issued at or after the revocation time of
+// their key, or signed with a compromised key, are rejected with ErrKeyRevoked. The keys
+// that signed the certificates of a key certificate chain are checked the same way.
 //
 // Parameters:
 //   - qrPayment: A NASPIP token string to verify
-//   - publicKey: The public key (in raw or PASERK format) to verify the token signature
+//   - publicKey: The public key (in raw or PASERK format) to verify the token signature, or the shared key of an encrypted token
 //   - options: Options controlling verification behavior
 //
 // Returns:
@@ -152,11 +217,66 @@ func (p PaymentInstructionsBuilder) Read(qrPayment string, publicKey string, opt
 		return nil, errQr
 	}
 
+	var footer TokenFooter
+
+	// Malformed tokens are left to the PASETO handler, which reports why verification failed
+	if decoded, err := paseto.Decode(decodedQr.Token); err == nil && len(decoded.Footer) > 0 {
+		if footer, err = parseFooter(decoded.Footer); err != nil {
+			return nil, err
+		}
+
+		if err := checkFooterKey(footer, decodedQr); err != nil {
+			return nil, err
+		}
+
+		if options.VerifyOptions.Footer == nil {
+			options.VerifyOptions.Footer = decoded.Footer
+		}
+	}
+
+	var certificate *KeyCertificate
+
+	if options.TrustAnchors != nil && footer.KeyCertificate != "" {
+		var err error
+
+		certificate, err = p.VerifyKeyCertificate(footer.KeyCertificate, options.TrustAnchors, options.Clock, options.RevocationChecker)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if certificate.KeyIssuer != decodedQr.KeyIssuer || certificate.KeyId != decodedQr.KeyId {
+			return nil, errors.New("key certificate does not match token key")
+		}
+
+		publicKey = certificate.PublicKey
+	}
+
+	if footer.PublicKeyId != "" && footer.PublicKeyId != paseto.PublicKeyIdOf(publicKey) {
+		return nil, errors.New("public key id mismatch")
+	}
+
+	if footer.LocalKeyId != "" {
+		if localKey, err := paseto.GetLocalKey(publicKey); err != nil || footer.LocalKeyId != paseto.LocalKeyId(localKey) {
+			return nil, errors.New("local key id mismatch")
+		}
+	}
+
 	options.VerifyOptions.IgnoreExp = false
 	options.VerifyOptions.IgnoreIat = false
 	options.VerifyOptions.Assertion = []byte(publicKey)
 
-	var data, err = p.PasetoHandler.Verify(
+	handler, err := p.handlerFor(decodedQr.Token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkKeyPurpose(decodedQr.Token, publicKey); err != nil {
+		return nil, err
+	}
+
+	data, err := handler.Verify(
 		decodedQr.Token,
 		publicKey,
 		options.VerifyOptions,
@@ -166,6 +286,10 @@ func (p PaymentInstructionsBuilder) Read(qrPayment string, publicKey string, opt
 		return nil, err
 	}
 
+	if data.Payload.Kid != decodedQr.KeyId || data.Payload.Kis != decodedQr.KeyIssuer {
+		return nil, errors.New("token key metadata mismatch")
+	}
+
 	if options.KeyId != "" && data.Payload.Kid != options.KeyId {
 		return nil, errors.New("invalid Key ID")
 	}
@@ -181,8 +305,40 @@ func (p PaymentInstructionsBuilder) Read(qrPayment string, publicKey string, opt
 			return nil, errors.New("invalid key expiration")
 		}
 
-		if time.Now().After(keyExpiredAt) {
-			return nil, errors.New("expired Key")
+		clock := options.Clock
+
+		if clock == nil {
+			clock = SystemClock{}
+		}
+
+		if clock.Now().After(keyExpiredAt) {
+			return nil, ErrKeyExpired
+		}
+	}
+
+	if options.RevocationChecker != nil {
+		if err := checkRevocation(options.RevocationChecker, data.Payload.Kis, data.Payload.Kid, data.Payload.Iat); err != nil {
+			return nil, err
+		}
+	}
+
+	if certificate != nil {
+		for _, asset := range payloadAssets(data.Payload.Data) {
+			if !certificate.AllowsAsset(asset) {
+				return nil, errors.New("asset not allowed by key certificate")
+			}
+		}
+	}
+
+	if _, ok := data.Payload.Data["quote"]; ok {
+		var payload InstructionPayload
+
+		if err := PayloadOf(data.Payload.Data, &payload); err != nil {
+			return nil, err
+		}
+
+		if err := ValidateExchangeRateQuote(payload, options.QuoteTolerance, options.Clock); err != nil {
+			return nil, err
 		}
 	}
 
@@ -191,6 +347,7 @@ func (p PaymentInstructionsBuilder) Read(qrPayment string, publicKey string, opt
 
 // CreateUrlPayload creates a NASPIP token containing a URL payload.
 // This is used when redirecting to a payment service that will generate the actual payment instructions.
+// When the builder has StrictOrder enabled, the order lines are also checked with ValidateOrderConsistency.
 //
 // Parameters:
 //   - data: The URL payload to encode in the token
@@ -208,6 +365,12 @@ func (p PaymentInstructionsBuilder) CreateUrlPayload(data UrlPayload, secretKey
 		return "", err
 	}
 
+	if p.StrictOrder && data.Order != nil {
+		if err := ValidateOrderConsistency(*data.Order); err != nil {
+			return "", err
+		}
+	}
+
 	protoPayload := &protobuf.UrlPayload{}
 	if err := protobuf.ConvertGoToProto(data, protoPayload); err != nil {
 		return "", err
@@ -224,6 +387,9 @@ func (p PaymentInstructionsBuilder) CreateUrlPayload(data UrlPayload, secretKey
 
 // CreatePaymentInstruction creates a NASPIP token containing complete payment instructions.
 // This provides all the information needed to make a payment directly.
+// When the builder has an AssetRegistry, payment amounts are also checked against the asset precision,
+// and when StrictOrder is enabled, the order lines are checked with ValidateOrderConsistency.
+// An embedded exchange rate quote is checked with ValidateExchangeRateQuote.
 //
 // Parameters:
 //   - data: The payment instruction payload to encode in the token
@@ -240,6 +406,23 @@ func (p PaymentInstructionsBuilder) CreatePaymentInstruction(data InstructionPay
 	if !isValid {
 		return "", err
 	}
+
+	if p.AssetRegistry != nil {
+		if err := p.AssetRegistry.ValidatePayment(data.Payment); err != nil {
+			return "", err
+		}
+	}
+
+	if p.StrictOrder && data.Order != nil {
+		if err := ValidateOrderConsistency(*data.Order); err != nil {
+			return "", err
+		}
+	}
+
+	if err := ValidateExchangeRateQuote(data, options.QuoteTolerance, options.Clock); err != nil {
+		return "", err
+	}
+
 	protoPayload := &protobuf.InstructionPayload{}
 	if err := protobuf.ConvertGoToProto(data, protoPayload); err != nil {
 		return "", err
@@ -284,6 +467,14 @@ func (p PaymentInstructionsBuilder) create(data *protobuf.PasetoTokenData, secre
 		options.SignOptions.ExpiresIn = "10m"
 	}
 
+	footer, err := encodeFooter(p.PasetoHandler, secretKey, keyOptions, options)
+
+	if err != nil {
+		return "", err
+	}
+
+	options.SignOptions.Footer = footer
+
 	data.Kid = keyOptions.KeyId
 	data.Kis = keyOptions.KeyIssuer
 	data.Kep = keyOptions.KeyExpiration
@@ -305,6 +496,116 @@ func (p PaymentInstructionsBuilder) create(data *protobuf.PasetoTokenData, secre
 	return qrPayment, nil
 }
 
+// handlerFor returns the PASETO handler used to verify a token.
+// Standard handlers only verify tokens with their own header; tokens with another public
+// header listed in AcceptedHeaders are verified with the standard handler for it. Custom
+// handlers verify every token themselves.
+//
+// Parameters:
+//   - token: The PASETO token to verify
+//
+// Returns:
+//   - The handler to verify the token with
+//   - An error if the builder does not accept the token header
+func (p PaymentInstructionsBuilder) handlerFor(token string) (paseto.PasetoHandler, error) {
+	var header string
+
+	switch p.PasetoHandler.(type) {
+	case nil, paseto.PasetoV4Handler:
+		header = "v4.public."
+	case paseto.PasetoV4LocalHandler:
+		header = "v4.local."
+	case paseto.PasetoV3Handler:
+		header = "v3.public."
+	default:
+		return p.PasetoHandler, nil
+	}
+
+	if strings.HasPrefix(token, header) {
+		return paseto.HandlerFor(token)
+	}
+
+	for _, accepted := range p.AcceptedHeaders {
+		if strings.HasSuffix(accepted, ".public.") && strings.HasPrefix(token, accepted) {
+			return paseto.HandlerFor(token)
+		}
+	}
+
+	return nil, errors.New("unexpected PASETO header")
+}
+
+// checkKeyPurpose checks that the verification key has the kind required by the token
+// purpose, so that a public key is never used as the shared key of a local token.
+//
+// Parameters:
+//   - token: The PASETO token to verify
+//   - key: The verification key passed to Read
+//
+// Returns:
+//   - An error if the key does not match the token purpose
+func checkKeyPurpose(token string, key string) error {
+	local := strings.HasPrefix(key, "k4.local.")
+
+	if strings.HasPrefix(token, "v4.local.") != local {
+		return errors.New("key does not match token purpose")
+	}
+
+	if strings.HasPrefix(key, "k4.secret.") || strings.HasPrefix(key, "k3.secret.") {
+		return errors.New("key does not match token purpose")
+	}
+
+	return nil
+}
+
+// PayloadOf converts verified token data into a typed payload struct (e.g., the Data of
+// Read into an InstructionPayload). It uses JSON as an intermediate format, matching the
+// field names of the payload types.
+//
+// Parameters:
+//   - data: The token data returned by Read
+//   - target: Pointer to the payload struct to populate
+//
+// Returns:
+//   - An error if the data cannot be converted, nil on success
+func PayloadOf(data map[string]interface{}, target any) error {
+	jsonBytes, err := json.Marshal(data)
+
+	if err != nil {
+		return errors.New("invalid token data")
+	}
+
+	if err := json.Unmarshal(jsonBytes, target); err != nil {
+		return errors.New("invalid token data")
+	}
+
+	return nil
+}
+
+// decodeTokenData decodes a NASPIP token without verifying its signature and returns its data.
+// It is used to read the token a payload refers to, which is identified by its hash.
+//
+// Parameters:
+//   - qrPayment: A NASPIP token string
+//
+// Returns:
+//   - The token data
+//   - An error if the token cannot be decoded
+func decodeTokenData(qrPayment string) (map[string]interface{}, error) {
+	decodedQr, err := PaymentInstructionsBuilder{}.Decode(qrPayment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := paseto.Decode(decodedQr.Token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return decoded.Payload.Data, nil
+}
+
 // validateParameters verifies that the required key parameters are present and valid.
 // It checks that the secret key is provided and that the key information is complete and valid.
 //
@@ -336,7 +637,7 @@ func validateParameters(secretKey string, optionsKey TokenPublicKeyOptions) (boo
 	}
 
 	if time.Now().After(keyExpiredAt) {
-		return false, errors.New("expired Key")
+		return false, ErrKeyExpired
 	}
 
 	return true, nil
@@ -428,6 +729,7 @@ func validateUrlPayload(payload UrlPayload) (bool, error) {
 			})}
 
 		validations = append(validations, orderValidations...)
+		validations = append(validations, orderChargesValidations(payload.Order)...)
 	}
 
 	errs := validator.Validate(validations...)
@@ -542,6 +844,7 @@ func validatePaymentInstructionPayload(payload InstructionPayload) (bool, error)
 			})}
 
 		validations = append(validations, orderValidations...)
+		validations = append(validations, orderChargesValidations(payload.Order)...)
 	}
 
 	errs := validator.Validate(validations...)
@@ -552,3 +855,61 @@ func validatePaymentInstructionPayload(payload InstructionPayload) (bool, error)
 
 	return true, nil
 }
+
+// orderChargesValidations builds the validations for the order taxes, discounts, shipping
+// and the item identification fields. It is shared by the URL and payment instruction validators.
+//
+// Parameters:
+//   - order: The order to validate
+//
+// Returns:
+//   - The list of validators to run for the order
+func orderChargesValidations(order *InstructionOrder) []validator.Validator {
+	return []validator.Validator{
+		validator.When(order.Shipping != "").Then(
+			validator.Must(utils.BiggerThanOrEqualZero(order.Shipping)).OnError(
+				validator.SetField("order_shipping", nil),
+				validator.SetCustomKey("ORDER_SHIPPING_AMOUNT_INVALID"),
+			)),
+		validator.Slice(order.Taxes).ForEach(func(elem InstructionTax, index int, vld validator.ItemValidator) {
+			vld.Validate(
+				validator.StrLen(&elem.TaxType, 2, 50).OnError(
+					validator.SetField(fmt.Sprintf("order_tax_[%d]_tax_type", index), nil),
+				),
+				validator.When(elem.Rate != "").Then(
+					validator.Must(utils.BiggerThanOrEqualZero(elem.Rate)).OnError(
+						validator.SetField(fmt.Sprintf("order_tax_[%d]_rate", index), nil),
+						validator.SetCustomKey(fmt.Sprintf("ORDER_TAX_[%d]_RATE_INVALID", index)),
+					)),
+				validator.Must(utils.BiggerThanOrEqualZero(elem.Amount)).OnError(
+					validator.SetField(fmt.Sprintf("order_tax_[%d]_amount", index), nil),
+					validator.SetCustomKey(fmt.Sprintf("ORDER_TAX_[%d]_AMOUNT_INVALID", index)),
+				),
+			)
+		}),
+		validator.Slice(order.Discounts).ForEach(func(elem InstructionDiscount, index int, vld validator.ItemValidator) {
+			vld.Validate(
+				validator.When(elem.Description != "").Then(
+					validator.StrLen(&elem.Description, 1, 100).OnError(
+						validator.SetField(fmt.Sprintf("order_discount_[%d]_description", index), nil),
+					)),
+				validator.Must(utils.BiggerThanOrEqualZero(elem.Amount)).OnError(
+					validator.SetField(fmt.Sprintf("order_discount_[%d]_amount", index), nil),
+					validator.SetCustomKey(fmt.Sprintf("ORDER_DISCOUNT_[%d]_AMOUNT_INVALID", index)),
+				),
+			)
+		}),
+		validator.Slice(order.Items).ForEach(func(elem InstructionItem, index int, vld validator.ItemValidator) {
+			vld.Validate(
+				validator.When(elem.Sku != "").Then(
+					validator.StrLen(&elem.Sku, 1, 100).OnError(
+						validator.SetField(fmt.Sprintf("order_item_[%d]_sku", index), nil),
+					)),
+				validator.When(elem.UnitOfMeasure != "").Then(
+					validator.StrLen(&elem.UnitOfMeasure, 1, 20).OnError(
+						validator.SetField(fmt.Sprintf("order_item_[%d]_unit_of_measure", index), nil),
+					)),
+			)
+		}),
+	}
+}
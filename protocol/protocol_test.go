@@ -423,3 +423,278 @@ func TestReadUrlPaymentInvaliKeyIssuer(t *testing.T) {
 
 	assert.EqualError(errRead, "invalid Key Issuer")
 }
+
+// Should check the key expiration against the read clock
+func TestReadWithExpiredKeyAtClockTime(t *testing.T) {
+	assert := assert.New(t)
+
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	var payload = InstructionPayload{
+		Payment: PaymentInstruction{
+			Id:            "payment-id",
+			UniqueAssetId: "ntrc20_tTR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+			Address:       "crypto-address",
+			Amount:        "100",
+			ExpiresAt:     time.Now().Add(time.Hour * 3).UnixMilli(),
+		},
+	}
+
+	var now = time.Now()
+
+	qrToken, err := builder.CreatePaymentInstruction(payload,
+		keys["secretKey"],
+		QrCriptoCreateOptions{
+			SignOptions:   paseto.PasetoSignOptions{KeyId: "key-id-one", ExpiresIn: "5m", Assertion: []byte(keys["publicKey"])},
+			KeyIssuer:     "payment-processor.com",
+			KeyExpiration: now.Add(time.Hour).Format(utils.RFC3339Mili),
+		},
+	)
+
+	assert.Nil(err)
+
+	_, err = builder.Read(qrToken, keys["publicKey"], QrCriptoReadOptions{Clock: fixedClock(now.Add(30 * time.Minute))})
+
+	assert.Nil(err)
+
+	_, err = builder.Read(qrToken, keys["publicKey"], QrCriptoReadOptions{Clock: fixedClock(now.Add(2 * time.Hour))})
+
+	assert.ErrorIs(err, ErrKeyExpired)
+}
+
+// Should create payment instruction token with taxes, discounts and shipping and read them back
+func TestCreateAndReadPaymentWithOrderCharges(t *testing.T) {
+	assert := assert.New(t)
+
+	var handler = paseto.PasetoV4Handler{}
+
+	var builder = PaymentInstructionsBuilder{PasetoHandler: handler}
+
+	var payload = InstructionPayload{
+		Payment: PaymentInstruction{
+			Id:            "payment-id",
+			UniqueAssetId: "ntrc20_tTR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+			Address:       "crypto-address",
+			IsOpen:        false,
+			Amount:        "123",
+			ExpiresAt:     time.Now().Add(time.Hour * 3).UnixMilli(),
+		},
+		Order: &InstructionOrder{
+			Total:    "123",
+			CoinCode: "EUR",
+			Items: []InstructionItem{
+				{Description: "Coffee beans", Amount: "100", CoinCode: "EUR", UnitPrice: "20", Quantity: 5, Sku: "CB-001", UnitOfMeasure: "kg"},
+			},
+			Taxes:     []InstructionTax{{TaxType: "VAT", Rate: "21", Amount: "21"}},
+			Discounts: []InstructionDiscount{{Description: "WELCOME10", Amount: "10"}},
+			Shipping:  "12",
+		},
+	}
+
+	var options = paseto.PasetoSignOptions{
+		KeyId:     "key-id-one",
+		Issuer:    "qrCrypto.com",
+		ExpiresIn: "5m",
+		Assertion: []byte(keys["publicKey"]),
+	}
+
+	var keyExpiration = time.Now().Add(1e9).Format(utils.RFC3339Mili)
+
+	qrToken, err := builder.CreatePaymentInstruction(payload,
+		keys["secretKey"],
+		QrCriptoCreateOptions{SignOptions: options, KeyIssuer: "payment-processor.com", KeyExpiration: keyExpiration},
+	)
+
+	if err != nil {
+		t.Errorf("TestCreateAndReadPaymentWithOrderCharges FAIL --> %v, %v", err, qrToken)
+	}
+
+	data, errRead := builder.Read(qrToken, keys["publicKey"], QrCriptoReadOptions{})
+
+	if errRead != nil {
+		t.Errorf("TestCreateAndReadPaymentWithOrderCharges FAIL --> %v", errRead)
+	}
+
+	order := data.Payload.Data["order"].(map[string]interface{})
+
+	assert.Equal("12", order["shipping"])
+	assert.Equal("VAT", order["taxes"].([]interface{})[0].(map[string]interface{})["tax_type"])
+	assert.Equal("10", order["discounts"].([]interface{})[0].(map[string]interface{})["amount"])
+	assert.Equal("CB-001", order["items"].([]interface{})[0].(map[string]interface{})["sku"])
+	assert.Equal("kg", order["items"].([]interface{})[0].(map[string]interface{})["unit_of_measure"])
+}
+
+// Should fail to create payment instruction with invalid tax line
+func TestCreatePaymentWithInvalidTax(t *testing.T) {
+	assert := assert.New(t)
+
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	var payload = InstructionPayload{
+		Payment: PaymentInstruction{
+			Id:            "payment-id",
+			UniqueAssetId: "ntrc20_tTR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+			Address:       "crypto-address",
+			Amount:        "100",
+			ExpiresAt:     time.Now().Add(time.Hour * 3).UnixMilli(),
+		},
+		Order: &InstructionOrder{
+			Total:    "100",
+			CoinCode: "EUR",
+			Taxes:    []InstructionTax{{TaxType: "VAT", Amount: "-21"}},
+		},
+	}
+
+	var keyExpiration = time.Now().Add(1e9).Format(utils.RFC3339Mili)
+
+	_, err := builder.CreatePaymentInstruction(payload,
+		keys["secretKey"],
+		QrCriptoCreateOptions{SignOptions: paseto.PasetoSignOptions{KeyId: "key-id-one", ExpiresIn: "5m"}, KeyIssuer: "payment-processor.com", KeyExpiration: keyExpiration},
+	)
+
+	assert.NotNil(err)
+}
+
+// Should encrypt payment instructions with a shared key
+func TestCreateAndReadEncryptedPayment(t *testing.T) {
+	assert := assert.New(t)
+
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4LocalHandler{}}
+
+	localKeys, _ := paseto.GenerateKey("local", "paserk")
+	otherKeys, _ := paseto.GenerateKey("local", "paserk")
+
+	var payload = refundOriginal
+	payload.Order = &InstructionOrder{
+		Total:    "10",
+		CoinCode: "USD",
+		Merchant: &InstructionMerchant{Name: "Merchant", TaxId: "30-12345678-9"},
+	}
+
+	var options = certificateOptions("psp.com", "shared-key", localKeys["localKey"], "")
+	options.KeyFooter = true
+
+	qrToken, err := builder.CreatePaymentInstruction(payload, localKeys["localKey"], options)
+
+	assert.Nil(err)
+
+	decoded, _ := builder.Decode(qrToken)
+	pasetoToken, _ := paseto.DecodeV4(decoded.Token)
+
+	assert.Equal("local", pasetoToken.Purpose)
+	assert.Nil(pasetoToken.Payload.Data)
+
+	footer, _ := builder.ReadFooter(qrToken)
+	localKey, _ := paseto.GetLocalKey(localKeys["localKey"])
+
+	assert.Equal(paseto.LocalKeyId(localKey), footer.LocalKeyId)
+
+	data, err := builder.Read(qrToken, localKeys["localKey"], QrCriptoReadOptions{})
+
+	assert.Nil(err)
+	assert.Equal("30-12345678-9", data.Payload.Data["order"].(map[string]interface{})["merchant"].(map[string]interface{})["tax_id"])
+
+	_, err = builder.Read(qrToken, otherKeys["localKey"], QrCriptoReadOptions{})
+
+	assert.EqualError(err, "local key id mismatch")
+}
+
+// Should reject v4.local tokens encrypted with the bytes of the merchant public key
+func TestReadLocalTokenKeyedWithPublicKey(t *testing.T) {
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+	var localBuilder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4LocalHandler{}}
+
+	// Anyone knowing the public key can use its 32 bytes as a shared key
+	publicKey := paseto.GetPublicKey(keys["publicKey"])
+	forgedKey := "k4.local." + utils.EncodeRawURLBase64(publicKey)
+
+	forged, err := localBuilder.CreatePaymentInstruction(refundOriginal, forgedKey, certificateOptions("merchant.com", "merchant-key", forgedKey, ""))
+
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name    string
+		builder PaymentInstructionsBuilder
+		key     string
+		err     string
+	}{
+		{name: "public builder, paserk key", builder: builder, key: keys["publicKey"], err: "unexpected PASETO header"},
+		{name: "public builder, raw key", builder: builder, key: string(publicKey), err: "unexpected PASETO header"},
+		{name: "public builder accepting local", builder: PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}, AcceptedHeaders: []string{"v4.local."}}, key: keys["publicKey"], err: "unexpected PASETO header"},
+		{name: "local builder, paserk key", builder: localBuilder, key: keys["publicKey"], err: "key does not match token purpose"},
+		{name: "local builder, raw key", builder: localBuilder, key: string(publicKey), err: "key does not match token purpose"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.builder.Read(forged, test.key, QrCriptoReadOptions{})
+
+			assert.EqualError(t, err, test.err)
+		})
+	}
+}
+
+// Should read v3.public tokens with a builder configured for v4 that accepts them
+func TestReadV3PaymentInstruction(t *testing.T) {
+	assert := assert.New(t)
+
+	var v3Builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV3Handler{}}
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}, AcceptedHeaders: []string{"v3.public."}}
+
+	v3Keys, _ := paseto.GenerateV3Key("public", "paserk")
+
+	var options = certificateOptions("bank.com", "hsm-key", v3Keys["publicKey"], "")
+	options.KeyFooter = true
+
+	qrToken, err := v3Builder.CreatePaymentInstruction(refundOriginal, v3Keys["secretKey"], options)
+
+	assert.Nil(err)
+
+	footer, _ := builder.ReadFooter(qrToken)
+
+	assert.Equal(paseto.PublicKeyIdOf(v3Keys["publicKey"]), footer.PublicKeyId)
+
+	data, err := builder.Read(qrToken, v3Keys["publicKey"], QrCriptoReadOptions{KeyIssuer: "bank.com"})
+
+	assert.Nil(err)
+	assert.Equal(refundOriginal.Payment.Id, data.Payload.Data["payment"].(map[string]interface{})["id"])
+
+	// v4 tokens are only read by a builder configured for v3 that accepts them
+	v4Token, _ := builder.CreatePaymentInstruction(refundOriginal, keys["secretKey"], certificateOptions("psp.com", "key", keys["publicKey"], ""))
+
+	_, err = v3Builder.Read(v4Token, keys["publicKey"], QrCriptoReadOptions{})
+
+	assert.EqualError(err, "unexpected PASETO header")
+
+	v3Builder.AcceptedHeaders = []string{"v4.public."}
+
+	_, err = v3Builder.Read(v4Token, keys["publicKey"], QrCriptoReadOptions{})
+
+	assert.Nil(err)
+
+	_, err = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}.Read(qrToken, v3Keys["publicKey"], QrCriptoReadOptions{})
+
+	assert.EqualError(err, "unexpected PASETO header")
+
+	_, err = builder.Read(qrToken, keys["publicKey"], QrCriptoReadOptions{})
+
+	assert.EqualError(err, "public key id mismatch")
+}
+
+// Should convert verified token data into its payload struct
+func TestPayloadOf(t *testing.T) {
+	assert := assert.New(t)
+
+	qrToken, _ := PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}.CreatePaymentInstruction(refundOriginal, keys["secretKey"], certificateOptions("merchant.com", "merchant-key", keys["publicKey"], ""))
+
+	data, err := PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}.Read(qrToken, keys["publicKey"], QrCriptoReadOptions{})
+
+	assert.Nil(err)
+
+	var payload InstructionPayload
+
+	assert.Nil(PayloadOf(data.Payload.Data, &payload))
+	assert.Equal(refundOriginal.Payment, payload.Payment)
+
+	assert.EqualError(PayloadOf(map[string]interface{}{"payment": "not an object"}, &payload), "invalid token data")
+}
@@ -0,0 +1,121 @@
+package protocol
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/fluxisus/naspip-go/v3/utils"
+	"github.com/shopspring/decimal"
+	validator "github.com/tiendc/go-validator"
+)
+
+// ExchangeRateQuote represents the exchange rate used to convert a fiat-denominated order
+// into the crypto amount requested in the payment. It is embedded in the instruction and
+// therefore signed together with it, so the payer can see which rate was applied.
+type ExchangeRateQuote struct {
+	BaseCurrency  string `json:"base_currency"`    // Currency being priced (e.g., USDT)
+	QuoteCurrency string `json:"quote_currency"`   // Currency the rate is expressed in (e.g., USD, EUR)
+	Rate          string `json:"rate"`             // Units of quote currency per unit of base currency
+	Source        string `json:"source,omitempty"` // Rate provider
+	ExpiresAt     int64  `json:"expires_at"`       // Unix timestamp when the quote expires
+}
+
+// ValidateExchangeRateQuote checks that the quote embedded in a payment instruction agrees
+// with the payment and the order. The quote currency must match the order coin code, the
+// quote must not expire before the payment does, and, for fixed amounts, the payment amount
+// converted with the rate must match the order total.
+//
+// The quote expiration is checked against the clock, so it can be evaluated at a fixed time.
+//
+// Parameters:
+//   - payload: The payment instruction containing the quote
+//   - tolerance: Maximum relative difference allowed between the converted amount and the
+//     order total, as a percentage (e.g., "0.5"). When empty, the converted amount must equal
+//     the order total once rounded to the order total's decimal places.
+//   - clock: Source of the current time, SystemClock when nil
+//
+// Returns:
+//   - nil if the payload has no quote or the quote is consistent
+//   - An error describing the inconsistency otherwise
+func ValidateExchangeRateQuote(payload InstructionPayload, tolerance string, clock Clock) error {
+	if payload.Quote == nil {
+		return nil
+	}
+
+	quote := payload.Quote
+
+	errs := validator.Validate(
+		validator.StrLen(&quote.BaseCurrency, 2, 50).OnError(
+			validator.SetField("quote_base_currency", nil),
+		),
+		validator.StrLen(&quote.QuoteCurrency, 2, 50).OnError(
+			validator.SetField("quote_quote_currency", nil),
+		),
+		validator.Must(utils.BiggerThanZero(quote.Rate)).OnError(
+			validator.SetField("quote_rate", nil),
+			validator.SetCustomKey("QUOTE_RATE_INVALID"),
+		),
+		validator.When(quote.Source != "").Then(
+			validator.StrLen(&quote.Source, 1, 100).OnError(
+				validator.SetField("quote_source", nil),
+			)),
+		validator.When(tolerance != "").Then(
+			validator.Must(utils.BiggerThanOrEqualZero(tolerance)).OnError(
+				validator.SetField("quote_tolerance", nil),
+				validator.SetCustomKey("QUOTE_TOLERANCE_INVALID"),
+			)),
+	)
+
+	if len(errs) > 0 {
+		return errs[0]
+	}
+
+	if payload.Order == nil || payload.Order.CoinCode == "" {
+		return errors.New("quote requires order coin code")
+	}
+
+	if !strings.EqualFold(quote.QuoteCurrency, payload.Order.CoinCode) {
+		return errors.New("quote currency mismatch")
+	}
+
+	if clock == nil {
+		clock = SystemClock{}
+	}
+
+	if clock.Now().UnixMilli() > quote.ExpiresAt {
+		return errors.New("quote expired")
+	}
+
+	if quote.ExpiresAt < payload.Payment.ExpiresAt {
+		return errors.New("quote expires before payment")
+	}
+
+	if payload.Payment.IsOpen || payload.Order.Total == "" {
+		return nil
+	}
+
+	amount, errAmount := decimal.NewFromString(payload.Payment.Amount)
+	total, errTotal := decimal.NewFromString(payload.Order.Total)
+
+	if errAmount != nil || errTotal != nil {
+		return errors.New("quote amount mismatch")
+	}
+
+	rate, _ := decimal.NewFromString(quote.Rate)
+	converted := amount.Mul(rate)
+
+	if converted.Round(-total.Exponent()).Equal(total) {
+		return nil
+	}
+
+	if tolerance != "" {
+		percentage, _ := decimal.NewFromString(tolerance)
+		allowed := total.Mul(percentage).Div(decimal.NewFromInt(100))
+
+		if converted.Sub(total).Abs().LessThanOrEqual(allowed) {
+			return nil
+		}
+	}
+
+	return errors.New("quote amount mismatch")
+}
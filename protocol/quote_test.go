@@ -0,0 +1,119 @@
+package protocol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// quoteTime is the time at which quotes are validated
+var quoteTime = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func quotedPayload() InstructionPayload {
+	return InstructionPayload{
+		Payment: PaymentInstruction{
+			Id:            "payment-id",
+			UniqueAssetId: "ntrc20_tTR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+			Address:       "crypto-address",
+			Amount:        "9.2593",
+			ExpiresAt:     quoteTime.Add(time.Minute * 10).UnixMilli(),
+		},
+		Order: &InstructionOrder{Total: "10.00", CoinCode: "EUR"},
+		Quote: &ExchangeRateQuote{
+			BaseCurrency:  "USDT",
+			QuoteCurrency: "EUR",
+			Rate:          "1.08",
+			Source:        "rates.example.com",
+			ExpiresAt:     quoteTime.Add(time.Minute * 15).UnixMilli(),
+		},
+	}
+}
+
+// Should validate a quote consistent with the payment and the order
+func TestValidateExchangeRateQuote(t *testing.T) {
+	assert := assert.New(t)
+
+	payload := quotedPayload()
+
+	assert.Nil(ValidateExchangeRateQuote(payload, "", fixedClock(quoteTime)))
+
+	payload.Payment.Amount = "9.2"
+
+	assert.EqualError(ValidateExchangeRateQuote(payload, "", fixedClock(quoteTime)), "quote amount mismatch")
+	assert.EqualError(ValidateExchangeRateQuote(payload, "0.5", fixedClock(quoteTime)), "quote amount mismatch")
+	assert.Nil(ValidateExchangeRateQuote(payload, "1", fixedClock(quoteTime)))
+}
+
+// Should fail to validate an inconsistent quote
+func TestValidateInvalidExchangeRateQuote(t *testing.T) {
+	assert := assert.New(t)
+
+	payload := quotedPayload()
+	payload.Order.CoinCode = "USD"
+
+	assert.EqualError(ValidateExchangeRateQuote(payload, "", fixedClock(quoteTime)), "quote currency mismatch")
+
+	payload = quotedPayload()
+	payload.Quote.ExpiresAt = quoteTime.Add(time.Minute * 5).UnixMilli()
+
+	assert.EqualError(ValidateExchangeRateQuote(payload, "", fixedClock(quoteTime)), "quote expires before payment")
+
+	payload = quotedPayload()
+
+	assert.EqualError(ValidateExchangeRateQuote(payload, "", fixedClock(quoteTime.Add(time.Minute*16))), "quote expired")
+
+	payload = quotedPayload()
+	payload.Order = nil
+
+	assert.EqualError(ValidateExchangeRateQuote(payload, "", fixedClock(quoteTime)), "quote requires order coin code")
+
+	payload = quotedPayload()
+	payload.Quote.Rate = "0"
+
+	assert.NotNil(ValidateExchangeRateQuote(payload, "", fixedClock(quoteTime)))
+}
+
+// Should create and read a payment instruction priced with a quote
+func TestCreateAndReadQuotedPayment(t *testing.T) {
+	assert := assert.New(t)
+
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	var createOptions = QrCriptoCreateOptions{
+		SignOptions:   paseto.PasetoSignOptions{KeyId: "key-id-one", ExpiresIn: "5m", Assertion: []byte(keys["publicKey"])},
+		KeyIssuer:     "payment-processor.com",
+		KeyExpiration: time.Now().Add(1e9).Format(utils.RFC3339Mili),
+		Clock:         fixedClock(quoteTime),
+	}
+
+	payload := quotedPayload()
+	payload.Payment.Amount = "9.25"
+
+	_, err := builder.CreatePaymentInstruction(payload, keys["secretKey"], createOptions)
+
+	assert.EqualError(err, "quote amount mismatch")
+
+	createOptions.QuoteTolerance = "0.5"
+
+	qrToken, err := builder.CreatePaymentInstruction(payload, keys["secretKey"], createOptions)
+
+	assert.Nil(err)
+
+	_, err = builder.Read(qrToken, keys["publicKey"], QrCriptoReadOptions{Clock: fixedClock(quoteTime)})
+
+	assert.EqualError(err, "quote amount mismatch")
+
+	_, err = builder.Read(qrToken, keys["publicKey"], QrCriptoReadOptions{QuoteTolerance: "0.5", Clock: fixedClock(quoteTime.Add(time.Hour))})
+
+	assert.EqualError(err, "quote expired")
+
+	data, err := builder.Read(qrToken, keys["publicKey"], QrCriptoReadOptions{QuoteTolerance: "0.5", Clock: fixedClock(quoteTime)})
+
+	assert.Nil(err)
+	assert.Equal("1.08", data.Payload.Data["quote"].(map[string]interface{})["rate"])
+	assert.Equal(payload.Quote.ExpiresAt, data.Payload.Data["quote"].(map[string]interface{})["expires_at"])
+}
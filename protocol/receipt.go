@@ -0,0 +1,266 @@
+package protocol
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"slices"
+
+	"github.com/fluxisus/naspip-go/v3/encoding/protobuf"
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/utils"
+	validator "github.com/tiendc/go-validator"
+)
+
+// ReceiptPayload represents a signed acknowledgement that a payment instruction was paid.
+// It is signed by the payer's wallet or the PSP after the transaction is broadcast and
+// references the instruction token it fulfills, so the receipt cannot be reused for
+// another instruction.
+type ReceiptPayload struct {
+	InstructionHash string `json:"instruction_hash"`          // SHA-256 hash (hex) of the instruction NASPIP token
+	InstructionId   string `json:"instruction_id"`            // Payment identifier of the instruction
+	InstructionJti  string `json:"instruction_jti,omitempty"` // Token identifier (jti) of the instruction
+	TxHash          string `json:"tx_hash"`                   // Transaction hash
+	Network         string `json:"network"`                   // Network where the transaction was broadcast
+	UniqueAssetId   string `json:"unique_asset_id,omitempty"` // Asset identifier of the paid amount
+	Amount          string `json:"amount"`                    // Amount paid
+	PaidAt          int64  `json:"paid_at"`                   // Unix timestamp when the payment was made
+}
+
+// InstructionHash computes the hash used by receipts to reference an instruction token.
+//
+// Parameters:
+//   - qrPayment: The NASPIP instruction token
+//
+// Returns:
+//   - The hex encoded SHA-256 hash of the token
+func InstructionHash(qrPayment string) string {
+	hash := sha256.Sum256([]byte(qrPayment))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// VerifyReceiptReference checks that a receipt references the given instruction token.
+// The instruction hash must match, and the instruction id and jti must match the
+// instruction content. The instruction signature is not verified here.
+//
+// Parameters:
+//   - receipt: The receipt to check
+//   - instructionToken: The NASPIP instruction token the receipt should fulfill
+//
+// Returns:
+//   - nil if the receipt references the instruction
+//   - An error if the instruction cannot be decoded or the receipt references another one
+func VerifyReceiptReference(receipt ReceiptPayload, instructionToken string) error {
+	reference, err := instructionReference(instructionToken)
+
+	if err != nil {
+		return err
+	}
+
+	if receipt.InstructionHash != reference.hash {
+		return errors.New("receipt does not reference the instruction")
+	}
+
+	if !slices.Contains(reference.ids, receipt.InstructionId) {
+		return errors.New("receipt does not reference the instruction")
+	}
+
+	if receipt.InstructionJti != "" && receipt.InstructionJti != reference.jti {
+		return errors.New("receipt does not reference the instruction")
+	}
+
+	return nil
+}
+
+// CreatePaymentReceipt creates a NASPIP token containing a payment receipt.
+// When an instruction token is given, the instruction hash, id and jti are filled in from it
+// if empty, and checked against it otherwise.
+//
+// Parameters:
+//   - data: The receipt payload to encode in the token
+//   - instructionToken: The NASPIP instruction token being acknowledged, may be empty
+//   - secretKey: The private key (in raw or PASERK format) to sign the token
+//   - options: Options for token creation
+//
+// Returns:
+//   - A NASPIP token string if creation succeeds
+//   - An error if validation or creation fails
+func (p PaymentInstructionsBuilder) CreatePaymentReceipt(data ReceiptPayload, instructionToken string, secretKey string, options QrCriptoCreateOptions) (string, error) {
+
+	if instructionToken != "" {
+		reference, err := instructionReference(instructionToken)
+
+		if err != nil {
+			return "", err
+		}
+
+		if data.InstructionHash == "" {
+			data.InstructionHash = reference.hash
+		}
+
+		if data.InstructionId == "" && len(reference.ids) == 1 {
+			data.InstructionId = reference.ids[0]
+		}
+
+		if data.InstructionJti == "" {
+			data.InstructionJti = reference.jti
+		}
+
+		if err := VerifyReceiptReference(data, instructionToken); err != nil {
+			return "", err
+		}
+	}
+
+	isValid, err := validateReceiptPayload(data)
+
+	if !isValid {
+		return "", err
+	}
+
+	protoPayload := &protobuf.ReceiptPayload{}
+	if err := protobuf.ConvertGoToProto(data, protoPayload); err != nil {
+		return "", err
+	}
+
+	var payload = &protobuf.PasetoTokenData{
+		Data: &protobuf.PasetoTokenData_ReceiptPayload{
+			ReceiptPayload: protoPayload,
+		},
+	}
+
+	return p.create(payload, secretKey, options)
+}
+
+// ReadPaymentReceipt reads and verifies a NASPIP token containing a payment receipt.
+// When an instruction token is given, the receipt must reference it (see VerifyReceiptReference).
+//
+// Parameters:
+//   - receiptToken: A NASPIP receipt token to verify
+//   - publicKey: The public key (in raw or PASERK format) of the receipt signer
+//   - instructionToken: The NASPIP instruction token the receipt should fulfill, may be empty
+//   - options: Options controlling verification behavior
+//
+// Returns:
+//   - The receipt payload if verification succeeds
+//   - An error if verification fails, the token does not contain a receipt, or the
+//     receipt references another instruction
+func (p PaymentInstructionsBuilder) ReadPaymentReceipt(receiptToken string, publicKey string, instructionToken string, options QrCriptoReadOptions) (*ReceiptPayload, error) {
+	data, err := p.Read(receiptToken, publicKey, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := data.Payload.Data["tx_hash"]; !ok {
+		return nil, errors.New("token does not contain a receipt payload")
+	}
+
+	var receipt ReceiptPayload
+
+	if err := PayloadOf(data.Payload.Data, &receipt); err != nil {
+		return nil, err
+	}
+
+	if instructionToken != "" {
+		if err := VerifyReceiptReference(receipt, instructionToken); err != nil {
+			return nil, err
+		}
+	}
+
+	return &receipt, nil
+}
+
+// receiptReference holds the values of an instruction token that a receipt refers to.
+type receiptReference struct {
+	hash string   // Hash of the instruction token
+	jti  string   // Token identifier of the instruction
+	ids  []string // Payment ids of the instruction, one per payment option
+}
+
+// instructionReference decodes an instruction token, without verifying it, and extracts
+// the values a receipt refers to.
+//
+// Parameters:
+//   - instructionToken: The NASPIP instruction token
+//
+// Returns:
+//   - The instruction reference
+//   - An error if the token cannot be decoded or is not a payment instruction
+func instructionReference(instructionToken string) (receiptReference, error) {
+	decodedQr, err := PaymentInstructionsBuilder{}.Decode(instructionToken)
+
+	if err != nil {
+		return receiptReference{}, err
+	}
+
+	decoded, err := paseto.Decode(decodedQr.Token)
+
+	if err != nil {
+		return receiptReference{}, err
+	}
+
+	reference := receiptReference{hash: InstructionHash(instructionToken), jti: decoded.Payload.Jti}
+
+	if payment, ok := decoded.Payload.Data["payment"].(map[string]interface{}); ok {
+		if id, ok := payment["id"].(string); ok {
+			reference.ids = append(reference.ids, id)
+		}
+	}
+
+	if payments, ok := decoded.Payload.Data["payments"].([]interface{}); ok {
+		for _, payment := range payments {
+			if id, ok := payment.(map[string]interface{})["id"].(string); ok && !slices.Contains(reference.ids, id) {
+				reference.ids = append(reference.ids, id)
+			}
+		}
+	}
+
+	if len(reference.ids) == 0 {
+		return receiptReference{}, errors.New("token does not contain a payment instruction")
+	}
+
+	return reference, nil
+}
+
+// validateReceiptPayload performs validation on a receipt payload.
+//
+// Parameters:
+//   - payload: The receipt payload to validate
+//
+// Returns:
+//   - true if the payload passes all validation rules
+//   - false and an error describing the problem if validation fails
+func validateReceiptPayload(payload ReceiptPayload) (bool, error) {
+	errs := validator.Validate(
+		validator.StrLen(&payload.InstructionHash, 64, 64).OnError(
+			validator.SetField("instruction_hash", nil),
+		),
+		validator.StrLen(&payload.InstructionId, 1, 1000).OnError(
+			validator.SetField("instruction_id", nil),
+		),
+		validator.StrLen(&payload.TxHash, 1, 200).OnError(
+			validator.SetField("tx_hash", nil),
+		),
+		validator.StrLen(&payload.Network, 1, 100).OnError(
+			validator.SetField("network", nil),
+		),
+		validator.When(payload.UniqueAssetId != "").Then(
+			validator.StrLen(&payload.UniqueAssetId, 1, 100).OnError(
+				validator.SetField("unique_asset_id", nil),
+			)),
+		validator.Must(utils.BiggerThanZero(payload.Amount)).OnError(
+			validator.SetField("amount", nil),
+			validator.SetCustomKey("RECEIPT_AMOUNT_INVALID"),
+		),
+		validator.NumGT(&payload.PaidAt, 0).OnError(
+			validator.SetField("paid_at", nil),
+		),
+	)
+
+	if len(errs) > 0 {
+		return false, errs[0]
+	}
+
+	return true, nil
+}
@@ -0,0 +1,135 @@
+package protocol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/utils"
+
+	"github.com/stretchr/testify/assert"
+	validator "github.com/tiendc/go-validator"
+)
+
+// createReceiptInstruction creates the instruction token used by the receipt tests
+func createReceiptInstruction(t *testing.T, builder PaymentInstructionsBuilder, id string) string {
+	var keyExpiration = time.Now().Add(1e9).Format(utils.RFC3339Mili)
+
+	qrToken, err := builder.CreatePaymentInstruction(InstructionPayload{
+		Payment: PaymentInstruction{
+			Id:            id,
+			UniqueAssetId: "ntrc20_tTR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+			Address:       "tron-address",
+			Amount:        "10.5",
+			ExpiresAt:     time.Now().Add(time.Hour).UnixMilli(),
+		},
+	}, keys["secretKey"], QrCriptoCreateOptions{
+		SignOptions:   paseto.PasetoSignOptions{KeyId: "key-id-one", Jti: "jti-" + id, ExpiresIn: "5m", Assertion: []byte(keys["publicKey"])},
+		KeyIssuer:     "payment-processor.com",
+		KeyExpiration: keyExpiration,
+	})
+
+	if err != nil {
+		t.Fatalf("createReceiptInstruction FAIL --> %v", err)
+	}
+
+	return qrToken
+}
+
+// Should create and read a receipt linked to an instruction token
+func TestCreateAndReadPaymentReceipt(t *testing.T) {
+	assert := assert.New(t)
+
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	instruction := createReceiptInstruction(t, builder, "payment-id")
+
+	var options = QrCriptoCreateOptions{
+		SignOptions:   paseto.PasetoSignOptions{KeyId: "wallet-key", ExpiresIn: "1d", Assertion: []byte(keys["publicKey"])},
+		KeyIssuer:     "wallet.com",
+		KeyExpiration: time.Now().Add(1e9).Format(utils.RFC3339Mili),
+	}
+
+	var paidAt = time.Now().UnixMilli()
+
+	receiptToken, err := builder.CreatePaymentReceipt(ReceiptPayload{
+		TxHash:        "0xabc123",
+		Network:       "tron",
+		UniqueAssetId: "ntrc20_tTR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+		Amount:        "10.5",
+		PaidAt:        paidAt,
+	}, instruction, keys["secretKey"], options)
+
+	if err != nil {
+		t.Errorf("TestCreateAndReadPaymentReceipt FAIL --> %v", err)
+	}
+
+	receipt, err := builder.ReadPaymentReceipt(receiptToken, keys["publicKey"], instruction, QrCriptoReadOptions{KeyIssuer: "wallet.com"})
+
+	if err != nil {
+		t.Errorf("TestCreateAndReadPaymentReceipt FAIL --> %v", err)
+	}
+
+	assert.Equal(InstructionHash(instruction), receipt.InstructionHash)
+	assert.Equal("payment-id", receipt.InstructionId)
+	assert.Equal("jti-payment-id", receipt.InstructionJti)
+	assert.Equal("0xabc123", receipt.TxHash)
+	assert.Equal("10.5", receipt.Amount)
+	assert.Equal(paidAt, receipt.PaidAt)
+
+	other := createReceiptInstruction(t, builder, "other-payment-id")
+
+	_, err = builder.ReadPaymentReceipt(receiptToken, keys["publicKey"], other, QrCriptoReadOptions{})
+
+	assert.EqualError(err, "receipt does not reference the instruction")
+}
+
+// Should reject receipts that reference another instruction or are incomplete
+func TestCreatePaymentReceiptInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	instruction := createReceiptInstruction(t, builder, "payment-id")
+
+	var options = QrCriptoCreateOptions{
+		SignOptions:   paseto.PasetoSignOptions{KeyId: "wallet-key", ExpiresIn: "1d"},
+		KeyIssuer:     "wallet.com",
+		KeyExpiration: time.Now().Add(1e9).Format(utils.RFC3339Mili),
+	}
+
+	var receipt = ReceiptPayload{TxHash: "0xabc123", Network: "tron", Amount: "10.5", PaidAt: time.Now().UnixMilli()}
+
+	mismatch := receipt
+	mismatch.InstructionId = "other-payment-id"
+
+	_, err := builder.CreatePaymentReceipt(mismatch, instruction, keys["secretKey"], options)
+
+	assert.EqualError(err, "receipt does not reference the instruction")
+
+	_, err = builder.CreatePaymentReceipt(receipt, "naspip;kis;kid", keys["secretKey"], options)
+
+	assert.EqualError(err, "invalid naspip token prefix")
+
+	_, err = builder.CreatePaymentReceipt(receipt, "", keys["secretKey"], options)
+
+	assert.Contains(err.Error(), "instruction_hash")
+
+	invalid := receipt
+	invalid.Amount = "0"
+
+	_, err = builder.CreatePaymentReceipt(invalid, instruction, keys["secretKey"], options)
+
+	assert.Equal("RECEIPT_AMOUNT_INVALID", err.(validator.Error).CustomKey())
+}
+
+// Should fail to read an instruction token as a receipt
+func TestReadPaymentReceiptWrongPayload(t *testing.T) {
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	instruction := createReceiptInstruction(t, builder, "payment-id")
+
+	_, err := builder.ReadPaymentReceipt(instruction, keys["publicKey"], "", QrCriptoReadOptions{})
+
+	assert.EqualError(t, err, "token does not contain a receipt payload")
+}
@@ -0,0 +1,215 @@
+package protocol
+
+import (
+	"errors"
+
+	"github.com/fluxisus/naspip-go/v3/encoding/protobuf"
+	"github.com/fluxisus/naspip-go/v3/utils"
+	"github.com/shopspring/decimal"
+	validator "github.com/tiendc/go-validator"
+)
+
+// RefundPayload represents an instruction to return the funds of a previous payment.
+// Unlike the other payloads it flows from the merchant to the payer: the merchant signs it
+// and pays the amount to the address supplied by the original payer. It can be used both
+// for partial refunds and for full reversals of the original payment.
+type RefundPayload struct {
+	OriginalId    string `json:"original_id"`            // Payment identifier of the original instruction
+	OriginalJti   string `json:"original_jti,omitempty"` // Token identifier (jti) of the original instruction
+	UniqueAssetId string `json:"unique_asset_id"`        // Asset identifier of the refunded amount
+	Amount        string `json:"amount"`                 // Amount to refund
+	Address       string `json:"address"`                // Destination address supplied by the original payer
+	AddressTag    string `json:"address_tag,omitempty"`  // Optional tag/memo of the destination address
+	Reason        string `json:"reason,omitempty"`       // Reason for the refund
+}
+
+// IsFullRefund reports whether the refund returns the whole amount of the original payment.
+// Refunds of open-amount instructions are never considered full, since the amount paid is not
+// part of the instruction.
+//
+// Parameters:
+//   - original: The original payment instruction
+//
+// Returns:
+//   - true if the refund amount equals the original fixed amount
+func (r RefundPayload) IsFullRefund(original InstructionPayload) bool {
+	if original.Payment.IsOpen {
+		return false
+	}
+
+	amount, err := decimal.NewFromString(original.Payment.Amount)
+
+	if err != nil {
+		return false
+	}
+
+	return amountEquals(r.Amount, amount)
+}
+
+// ValidateRefund checks a refund against the original payment instruction.
+// The refund must reference the original payment id, use the same asset and not exceed the
+// original amount. For open-amount instructions the amount is limited by MaxAmount, if set.
+//
+// Parameters:
+//   - refund: The refund to check
+//   - original: The original payment instruction
+//
+// Returns:
+//   - nil if the refund is consistent with the original instruction
+//   - An error describing the inconsistency otherwise
+func ValidateRefund(refund RefundPayload, original InstructionPayload) error {
+	if refund.OriginalId != original.Payment.Id {
+		return errors.New("refund does not reference the original payment")
+	}
+
+	if refund.UniqueAssetId != original.Payment.UniqueAssetId {
+		return errors.New("refund asset mismatch")
+	}
+
+	limit := original.Payment.Amount
+
+	if original.Payment.IsOpen {
+		limit = original.Payment.MaxAmount
+	}
+
+	if limit == "" {
+		return nil
+	}
+
+	amount, errAmount := decimal.NewFromString(refund.Amount)
+	maximum, errMaximum := decimal.NewFromString(limit)
+
+	if errAmount != nil || errMaximum != nil {
+		return errors.New("invalid refund amount")
+	}
+
+	if amount.GreaterThan(maximum) {
+		return errors.New("refund amount exceeds original amount")
+	}
+
+	return nil
+}
+
+// CreateRefund creates a NASPIP token containing a refund instruction.
+// When the original instruction is given, the refund is checked with ValidateRefund.
+//
+// Parameters:
+//   - data: The refund payload to encode in the token
+//   - original: The original payment instruction, may be nil
+//   - secretKey: The private key (in raw or PASERK format) to sign the token
+//   - options: Options for token creation
+//
+// Returns:
+//   - A NASPIP token string if creation succeeds
+//   - An error if validation or creation fails
+func (p PaymentInstructionsBuilder) CreateRefund(data RefundPayload, original *InstructionPayload, secretKey string, options QrCriptoCreateOptions) (string, error) {
+
+	isValid, err := validateRefundPayload(data)
+
+	if !isValid {
+		return "", err
+	}
+
+	if original != nil {
+		if err := ValidateRefund(data, *original); err != nil {
+			return "", err
+		}
+	}
+
+	if p.AssetRegistry != nil {
+		if err := p.AssetRegistry.ValidatePayment(PaymentInstruction{UniqueAssetId: data.UniqueAssetId, Amount: data.Amount}); err != nil {
+			return "", err
+		}
+	}
+
+	protoPayload := &protobuf.RefundPayload{}
+	if err := protobuf.ConvertGoToProto(data, protoPayload); err != nil {
+		return "", err
+	}
+
+	var payload = &protobuf.PasetoTokenData{
+		Data: &protobuf.PasetoTokenData_RefundPayload{
+			RefundPayload: protoPayload,
+		},
+	}
+
+	return p.create(payload, secretKey, options)
+}
+
+// ReadRefund reads and verifies a NASPIP token containing a refund instruction.
+// When the original instruction is given, the refund is checked with ValidateRefund.
+//
+// Parameters:
+//   - qrPayment: A NASPIP refund token to verify
+//   - publicKey: The public key (in raw or PASERK format) to verify the token signature
+//   - original: The original payment instruction, may be nil
+//   - options: Options controlling verification behavior
+//
+// Returns:
+//   - The refund payload if verification succeeds
+//   - An error if verification fails, the token does not contain a refund, or the refund
+//     is inconsistent with the original instruction
+func (p PaymentInstructionsBuilder) ReadRefund(qrPayment string, publicKey string, original *InstructionPayload, options QrCriptoReadOptions) (*RefundPayload, error) {
+	data, err := p.Read(qrPayment, publicKey, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := data.Payload.Data["original_id"]; !ok {
+		return nil, errors.New("token does not contain a refund payload")
+	}
+
+	var refund RefundPayload
+
+	if err := PayloadOf(data.Payload.Data, &refund); err != nil {
+		return nil, err
+	}
+
+	if original != nil {
+		if err := ValidateRefund(refund, *original); err != nil {
+			return nil, err
+		}
+	}
+
+	return &refund, nil
+}
+
+// validateRefundPayload performs validation on a refund payload.
+//
+// Parameters:
+//   - payload: The refund payload to validate
+//
+// Returns:
+//   - true if the payload passes all validation rules
+//   - false and an error describing the problem if validation fails
+func validateRefundPayload(payload RefundPayload) (bool, error) {
+	errs := validator.Validate(
+		validator.StrLen(&payload.OriginalId, 1, 1000).OnError(
+			validator.SetField("original_id", nil),
+		),
+		validator.StrLen(&payload.UniqueAssetId, 1, 100).OnError(
+			validator.SetField("unique_asset_id", nil),
+		),
+		validator.StrLen(&payload.Address, 1, 1000).OnError(
+			validator.SetField("address", nil),
+		),
+		validator.StrLen(&payload.AddressTag, 0, 100).OnError(
+			validator.SetField("address_tag", nil),
+		),
+		validator.Must(utils.BiggerThanZero(payload.Amount)).OnError(
+			validator.SetField("amount", nil),
+			validator.SetCustomKey("REFUND_AMOUNT_INVALID"),
+		),
+		validator.When(payload.Reason != "").Then(
+			validator.StrLen(&payload.Reason, 1, 200).OnError(
+				validator.SetField("reason", nil),
+			)),
+	)
+
+	if len(errs) > 0 {
+		return false, errs[0]
+	}
+
+	return true, nil
+}
@@ -0,0 +1,162 @@
+package protocol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var refundOriginal = InstructionPayload{
+	Payment: PaymentInstruction{
+		Id:            "payment-id",
+		UniqueAssetId: "ntrc20_tTR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+		Address:       "merchant-address",
+		Amount:        "10.5",
+		ExpiresAt:     time.Now().Add(time.Hour).UnixMilli(),
+	},
+}
+
+var refundCreateOptions = QrCriptoCreateOptions{
+	SignOptions:   paseto.PasetoSignOptions{KeyId: "key-id-one", ExpiresIn: "1d", Assertion: []byte(keys["publicKey"])},
+	KeyIssuer:     "payment-processor.com",
+	KeyExpiration: time.Now().Add(1e9).Format(utils.RFC3339Mili),
+}
+
+// Should create and read partial and full refunds of a payment instruction
+func TestCreateAndReadRefund(t *testing.T) {
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	tests := []struct {
+		name   string
+		amount string
+		full   bool
+	}{
+		{name: "partial refund", amount: "4.25", full: false},
+		{name: "full refund", amount: "10.50", full: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var refund = RefundPayload{
+				OriginalId:    "payment-id",
+				UniqueAssetId: "ntrc20_tTR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+				Amount:        test.amount,
+				Address:       "payer-address",
+				Reason:        "Returned item",
+			}
+
+			qrToken, err := builder.CreateRefund(refund, &refundOriginal, keys["secretKey"], refundCreateOptions)
+
+			if err != nil {
+				t.Errorf("TestCreateAndReadRefund FAIL --> %v", err)
+			}
+
+			data, err := builder.ReadRefund(qrToken, keys["publicKey"], &refundOriginal, QrCriptoReadOptions{KeyIssuer: "payment-processor.com"})
+
+			if err != nil {
+				t.Errorf("TestCreateAndReadRefund FAIL --> %v", err)
+			}
+
+			assert.Equal(refund, *data)
+			assert.Equal(test.full, data.IsFullRefund(refundOriginal))
+		})
+	}
+}
+
+// Should reject refunds inconsistent with the original instruction
+func TestValidateRefund(t *testing.T) {
+	var openOriginal = refundOriginal
+	openOriginal.Payment.IsOpen = true
+	openOriginal.Payment.Amount = ""
+	openOriginal.Payment.MaxAmount = "100"
+
+	tests := []struct {
+		name     string
+		refund   RefundPayload
+		original InstructionPayload
+		err      string
+	}{
+		{
+			name:     "amount above original",
+			refund:   RefundPayload{OriginalId: "payment-id", UniqueAssetId: refundOriginal.Payment.UniqueAssetId, Amount: "10.51"},
+			original: refundOriginal,
+			err:      "refund amount exceeds original amount",
+		},
+		{
+			name:     "other payment",
+			refund:   RefundPayload{OriginalId: "other-id", UniqueAssetId: refundOriginal.Payment.UniqueAssetId, Amount: "1"},
+			original: refundOriginal,
+			err:      "refund does not reference the original payment",
+		},
+		{
+			name:     "other asset",
+			refund:   RefundPayload{OriginalId: "payment-id", UniqueAssetId: "nbsc_t0x55d398326f99059fF775485246999027B3197955", Amount: "1"},
+			original: refundOriginal,
+			err:      "refund asset mismatch",
+		},
+		{
+			name:     "open amount within maximum",
+			refund:   RefundPayload{OriginalId: "payment-id", UniqueAssetId: refundOriginal.Payment.UniqueAssetId, Amount: "50"},
+			original: openOriginal,
+		},
+		{
+			name:     "open amount above maximum",
+			refund:   RefundPayload{OriginalId: "payment-id", UniqueAssetId: refundOriginal.Payment.UniqueAssetId, Amount: "100.01"},
+			original: openOriginal,
+			err:      "refund amount exceeds original amount",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateRefund(test.refund, test.original)
+
+			if test.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
+
+// Should fail to create a refund above the original amount or without destination
+func TestCreateRefundInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	var refund = RefundPayload{
+		OriginalId:    "payment-id",
+		UniqueAssetId: "ntrc20_tTR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+		Amount:        "11",
+		Address:       "payer-address",
+	}
+
+	_, err := builder.CreateRefund(refund, &refundOriginal, keys["secretKey"], refundCreateOptions)
+
+	assert.EqualError(err, "refund amount exceeds original amount")
+
+	refund.Address = ""
+
+	_, err = builder.CreateRefund(refund, nil, keys["secretKey"], refundCreateOptions)
+
+	assert.Contains(err.Error(), "address")
+}
+
+// Should fail to read a payment instruction as a refund
+func TestReadRefundWrongPayload(t *testing.T) {
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	qrToken, _ := builder.CreatePaymentInstruction(refundOriginal, keys["secretKey"], refundCreateOptions)
+
+	_, err := builder.ReadRefund(qrToken, keys["publicKey"], nil, QrCriptoReadOptions{})
+
+	assert.EqualError(t, err, "token does not contain a refund payload")
+}
@@ -0,0 +1,38 @@
+package protocol
+
+import "errors"
+
+// KeyResolver finds the public key used to verify a NASPIP token.
+// Implementations look the key up by the key issuer and key ID found in the token prefix,
+// for example in a local key store or by querying the issuer.
+type KeyResolver interface {
+	// ResolveKey returns the public key (in raw or PASERK format) for the given issuer and key ID.
+	ResolveKey(keyIssuer string, keyId string) (string, error)
+}
+
+// KeyResolverFunc adapts a function to the KeyResolver interface.
+type KeyResolverFunc func(keyIssuer string, keyId string) (string, error)
+
+// ResolveKey calls f(keyIssuer, keyId).
+func (f KeyResolverFunc) ResolveKey(keyIssuer string, keyId string) (string, error) {
+	return f(keyIssuer, keyId)
+}
+
+// StaticKeyResolver is a KeyResolver backed by a fixed set of keys,
+// indexed by key issuer and then by key ID.
+type StaticKeyResolver map[string]map[string]string
+
+// ResolveKey returns the key registered for the given issuer and key ID.
+//
+// Returns:
+//   - The public key
+//   - An error if no key is registered for the issuer and key ID
+func (r StaticKeyResolver) ResolveKey(keyIssuer string, keyId string) (string, error) {
+	key, ok := r[keyIssuer][keyId]
+
+	if !ok {
+		return "", errors.New("public key not found")
+	}
+
+	return key, nil
+}
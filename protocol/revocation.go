@@ -0,0 +1,320 @@
+package protocol
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/encoding/protobuf"
+	"github.com/fluxisus/naspip-go/v3/utils"
+	validator "github.com/tiendc/go-validator"
+)
+
+// ErrKeyRevoked is returned by Read when the token was signed with a revoked key.
+var ErrKeyRevoked = errors.New("key revoked")
+
+// RevocationReasonCompromised is the revocation reason of keys known to be compromised.
+// The iat claim is chosen by the signer, so whoever holds a compromised key can backdate
+// tokens: every token signed with a compromised key is rejected, whatever its iat.
+const RevocationReasonCompromised = "compromised"
+
+// RevokedKey identifies a key that must no longer be trusted.
+// Tokens issued at or after RevokedAt with the key are rejected; tokens issued before remain
+// valid, unless the key was revoked with RevocationReasonCompromised.
+type RevokedKey struct {
+	KeyId     string `json:"kid"`              // Revoked key ID
+	KeyIssuer string `json:"kis"`              // Issuer of the revoked key
+	RevokedAt int64  `json:"revoked_at"`       // Unix timestamp from which tokens signed with the key are rejected
+	Reason    string `json:"reason,omitempty"` // Reason for the revocation (e.g., RevocationReasonCompromised, superseded)
+}
+
+// RevocationList represents a signed list of revoked keys, published by a key issuer.
+// A list contains at least one revoked key.
+type RevocationList struct {
+	Revocations []RevokedKey `json:"revocations"` // Revoked keys
+}
+
+// RevocationChecker looks up whether a key has been revoked.
+// It is used by Read, through QrCriptoReadOptions.RevocationChecker, to reject tokens
+// signed with a revoked key.
+type RevocationChecker interface {
+	// Revocation returns the revocation of the key, or nil if the key is not revoked.
+	Revocation(keyIssuer string, keyId string) (*RevokedKey, error)
+}
+
+// CreateRevocationList creates a NASPIP token containing a signed revocation list.
+//
+// Parameters:
+//   - data: The revocation list to encode in the token
+//   - secretKey: The issuer private key (in raw or PASERK format) to sign the token
+//   - options: Options for token creation
+//
+// Returns:
+//   - A NASPIP token string if creation succeeds
+//   - An error if validation or creation fails
+func (p PaymentInstructionsBuilder) CreateRevocationList(data RevocationList, secretKey string, options QrCriptoCreateOptions) (string, error) {
+
+	isValid, err := validateRevocationList(data)
+
+	if !isValid {
+		return "", err
+	}
+
+	protoPayload := &protobuf.RevocationList{}
+	if err := protobuf.ConvertGoToProto(data, protoPayload); err != nil {
+		return "", err
+	}
+
+	var payload = &protobuf.PasetoTokenData{
+		Data: &protobuf.PasetoTokenData_RevocationList{
+			RevocationList: protoPayload,
+		},
+	}
+
+	return p.create(payload, secretKey, options)
+}
+
+// ReadRevocationList reads and verifies a NASPIP token containing a revocation list.
+// An issuer can only revoke its own keys, so lists with a revoked key of another issuer than
+// the token key issuer (kis) are rejected.
+//
+// Parameters:
+//   - qrPayment: A NASPIP revocation list token to verify
+//   - publicKey: The issuer public key (in raw or PASERK format)
+//   - options: Options controlling verification behavior
+//
+// Returns:
+//   - The revocation list if verification succeeds
+//   - An error if verification fails, the token does not contain a revocation list or the list
+//     revokes keys of another issuer
+func (p PaymentInstructionsBuilder) ReadRevocationList(qrPayment string, publicKey string, options QrCriptoReadOptions) (*RevocationList, error) {
+	data, err := p.Read(qrPayment, publicKey, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := data.Payload.Data["revocations"]; !ok {
+		return nil, errors.New("token does not contain a revocation list")
+	}
+
+	var list RevocationList
+
+	if err := PayloadOf(data.Payload.Data, &list); err != nil {
+		return nil, err
+	}
+
+	for _, revocation := range list.Revocations {
+		if revocation.KeyIssuer != data.Payload.Kis {
+			return nil, errors.New("revocation list key issuer mismatch")
+		}
+	}
+
+	return &list, nil
+}
+
+// MemoryRevocationChecker is a RevocationChecker that holds the revoked keys in memory.
+// A MemoryRevocationChecker is safe for concurrent use.
+type MemoryRevocationChecker struct {
+	mu      sync.RWMutex
+	revoked map[string]RevokedKey
+}
+
+// NewMemoryRevocationChecker creates a revocation checker containing the given revoked keys.
+func NewMemoryRevocationChecker(revocations ...RevokedKey) *MemoryRevocationChecker {
+	checker := &MemoryRevocationChecker{revoked: make(map[string]RevokedKey)}
+	checker.Add(revocations...)
+
+	return checker
+}
+
+// Add registers revoked keys. When a key is revoked more than once, the earliest
+// revocation is kept, and the key stays compromised if any of its revocations says so.
+func (c *MemoryRevocationChecker) Add(revocations ...RevokedKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, revocation := range revocations {
+		key := revocationKey(revocation.KeyIssuer, revocation.KeyId)
+
+		if current, ok := c.revoked[key]; ok {
+			earliest, other := revocation, current
+
+			if current.RevokedAt <= revocation.RevokedAt {
+				earliest, other = current, revocation
+			}
+
+			if other.Reason == RevocationReasonCompromised {
+				earliest.Reason = other.Reason
+			}
+
+			revocation = earliest
+		}
+
+		c.revoked[key] = revocation
+	}
+}
+
+// Revocation returns the revocation of the key, or nil if the key is not revoked.
+func (c *MemoryRevocationChecker) Revocation(keyIssuer string, keyId string) (*RevokedKey, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	revocation, ok := c.revoked[revocationKey(keyIssuer, keyId)]
+
+	if !ok {
+		return nil, nil
+	}
+
+	return &revocation, nil
+}
+
+// replace swaps the revoked keys for the given ones.
+func (c *MemoryRevocationChecker) replace(revocations []RevokedKey) {
+	loaded := NewMemoryRevocationChecker(revocations...)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.revoked = loaded.revoked
+}
+
+// FileRevocationChecker is a RevocationChecker backed by a file containing a signed
+// revocation list token. The list is verified when loaded; call Reload to pick up a
+// newly published list.
+type FileRevocationChecker struct {
+	*MemoryRevocationChecker
+
+	path      string
+	builder   PaymentInstructionsBuilder
+	publicKey string
+	options   QrCriptoReadOptions
+}
+
+// NewFileRevocationChecker creates a revocation checker from the revocation list token stored at path.
+//
+// Parameters:
+//   - path: Path of the file containing the revocation list token
+//   - builder: The builder used to verify the token
+//   - publicKey: The public key (in raw or PASERK format) of the revocation list issuer
+//   - options: Options controlling verification of the token
+//
+// Returns:
+//   - The revocation checker if the file can be read and the list verified
+//   - An error otherwise
+func NewFileRevocationChecker(path string, builder PaymentInstructionsBuilder, publicKey string, options QrCriptoReadOptions) (*FileRevocationChecker, error) {
+	checker := &FileRevocationChecker{
+		MemoryRevocationChecker: NewMemoryRevocationChecker(),
+		path:                    path,
+		builder:                 builder,
+		publicKey:               publicKey,
+		options:                 options,
+	}
+
+	if err := checker.Reload(); err != nil {
+		return nil, err
+	}
+
+	return checker, nil
+}
+
+// Reload reads and verifies the revocation list file again.
+// On error, the previously loaded revocations are kept.
+func (c *FileRevocationChecker) Reload() error {
+	content, err := os.ReadFile(c.path)
+
+	if err != nil {
+		return errors.New("unable to read revocation list file")
+	}
+
+	list, err := c.builder.ReadRevocationList(strings.TrimSpace(string(content)), c.publicKey, c.options)
+
+	if err != nil {
+		return err
+	}
+
+	c.replace(list.Revocations)
+
+	return nil
+}
+
+// checkRevocation rejects tokens issued with a revoked key at or after its revocation time,
+// and every token issued with a compromised key.
+//
+// Parameters:
+//   - checker: The revocation checker
+//   - keyIssuer: Issuer of the key that signed the token
+//   - keyId: ID of the key that signed the token
+//   - issuedAt: The token iat claim (RFC3339Mili format)
+//
+// Returns:
+//   - ErrKeyRevoked if the key is compromised or the token was issued after it was revoked
+//   - An error if the lookup fails, nil otherwise
+func checkRevocation(checker RevocationChecker, keyIssuer string, keyId string, issuedAt string) error {
+	revocation, err := checker.Revocation(keyIssuer, keyId)
+
+	if err != nil {
+		return err
+	}
+
+	if revocation == nil {
+		return nil
+	}
+
+	if revocation.Reason == RevocationReasonCompromised {
+		return ErrKeyRevoked
+	}
+
+	issued, err := time.Parse(utils.RFC3339Mili, issuedAt)
+
+	if err != nil || issued.UnixMilli() >= revocation.RevokedAt {
+		return ErrKeyRevoked
+	}
+
+	return nil
+}
+
+// revocationKey builds the map key of a revoked key.
+func revocationKey(keyIssuer string, keyId string) string {
+	return keyIssuer + ";" + keyId
+}
+
+// validateRevocationList performs validation on a revocation list.
+//
+// Parameters:
+//   - payload: The revocation list to validate
+//
+// Returns:
+//   - true if the list passes all validation rules
+//   - false and an error describing the problem if validation fails
+func validateRevocationList(payload RevocationList) (bool, error) {
+	errs := validator.Validate(
+		validator.SliceLen(payload.Revocations, 1, 10000).OnError(
+			validator.SetField("revocations", nil),
+		),
+		validator.Slice(payload.Revocations).ForEach(func(elem RevokedKey, index int, vld validator.ItemValidator) {
+			vld.Validate(
+				validator.StrLen(&elem.KeyId, 1, 100).OnError(
+					validator.SetField("revocations_kid", nil),
+				),
+				validator.StrLen(&elem.KeyIssuer, 1, 100).OnError(
+					validator.SetField("revocations_kis", nil),
+				),
+				validator.NumGT(&elem.RevokedAt, 0).OnError(
+					validator.SetField("revocations_revoked_at", nil),
+				),
+				validator.StrLen(&elem.Reason, 0, 200).OnError(
+					validator.SetField("revocations_reason", nil),
+				),
+			)
+		}),
+	)
+
+	if len(errs) > 0 {
+		return false, errs[0]
+	}
+
+	return true, nil
+}
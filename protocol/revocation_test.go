@@ -0,0 +1,193 @@
+package protocol
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Should reject tokens signed after the key revocation time
+func TestReadWithRevocationChecker(t *testing.T) {
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	var options = certificateOptions("merchant.com", "merchant-key", keys["publicKey"], "")
+
+	qrToken, err := builder.CreatePaymentInstruction(refundOriginal, keys["secretKey"], options)
+
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name    string
+		checker RevocationChecker
+		err     error
+	}{
+		{name: "not revoked", checker: NewMemoryRevocationChecker(RevokedKey{KeyIssuer: "merchant.com", KeyId: "other-key", RevokedAt: 1})},
+		{name: "revoked before issue", checker: NewMemoryRevocationChecker(RevokedKey{KeyIssuer: "merchant.com", KeyId: "merchant-key", RevokedAt: time.Now().Add(-time.Hour).UnixMilli()}), err: ErrKeyRevoked},
+		{name: "revoked after issue", checker: NewMemoryRevocationChecker(RevokedKey{KeyIssuer: "merchant.com", KeyId: "merchant-key", RevokedAt: time.Now().Add(time.Hour).UnixMilli()})},
+		{name: "compromised after issue", checker: NewMemoryRevocationChecker(RevokedKey{KeyIssuer: "merchant.com", KeyId: "merchant-key", RevokedAt: time.Now().Add(time.Hour).UnixMilli(), Reason: RevocationReasonCompromised}), err: ErrKeyRevoked},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := builder.Read(qrToken, keys["publicKey"], QrCriptoReadOptions{RevocationChecker: test.checker})
+
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+// Should keep the earliest revocation time of a key
+func TestMemoryRevocationChecker(t *testing.T) {
+	assert := assert.New(t)
+
+	checker := NewMemoryRevocationChecker(
+		RevokedKey{KeyIssuer: "merchant.com", KeyId: "merchant-key", RevokedAt: 200},
+		RevokedKey{KeyIssuer: "merchant.com", KeyId: "merchant-key", RevokedAt: 100, Reason: "key compromise"},
+		RevokedKey{KeyIssuer: "merchant.com", KeyId: "merchant-key", RevokedAt: 300},
+	)
+
+	revocation, err := checker.Revocation("merchant.com", "merchant-key")
+
+	assert.Nil(err)
+	assert.Equal(int64(100), revocation.RevokedAt)
+	assert.Equal("key compromise", revocation.Reason)
+
+	revocation, err = checker.Revocation("other.com", "merchant-key")
+
+	assert.Nil(err)
+	assert.Nil(revocation)
+
+	// A later compromise keeps the earliest time but marks the key compromised
+	checker.Add(RevokedKey{KeyIssuer: "merchant.com", KeyId: "merchant-key", RevokedAt: 400, Reason: RevocationReasonCompromised})
+
+	revocation, _ = checker.Revocation("merchant.com", "merchant-key")
+
+	assert.Equal(int64(100), revocation.RevokedAt)
+	assert.Equal(RevocationReasonCompromised, revocation.Reason)
+}
+
+// Should reject tokens whose certificate chain contains a revoked key
+func TestReadWithRevokedCertificateChain(t *testing.T) {
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	rootKeys, _ := paseto.GenerateKey("public", "paserk")
+	intermediateKeys, _ := paseto.GenerateKey("public", "paserk")
+
+	var anchors = StaticKeyResolver{"psp.com": {"root-1": rootKeys["publicKey"]}}
+	var now = time.Now()
+
+	intermediate, _ := builder.CreateKeyCertificate(KeyCertificate{
+		PublicKey: intermediateKeys["publicKey"],
+		KeyId:     "region-1",
+		KeyIssuer: "merchant.com",
+		NotBefore: now.Add(-time.Hour).UnixMilli(),
+		NotAfter:  now.Add(time.Hour).UnixMilli(),
+		IsCA:      true,
+	}, rootKeys["secretKey"], certificateOptions("psp.com", "root-1", rootKeys["publicKey"], ""))
+
+	certificate, _ := builder.CreateKeyCertificate(KeyCertificate{
+		PublicKey: keys["publicKey"],
+		KeyId:     "merchant-key",
+		KeyIssuer: "merchant.com",
+		NotBefore: now.Add(-time.Hour).UnixMilli(),
+		NotAfter:  now.Add(time.Hour).UnixMilli(),
+	}, intermediateKeys["secretKey"], certificateOptions("merchant.com", "region-1", intermediateKeys["publicKey"], intermediate))
+
+	qrToken, err := builder.CreatePaymentInstruction(refundOriginal, keys["secretKey"], certificateOptions("merchant.com", "merchant-key", keys["publicKey"], certificate))
+
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name    string
+		revoked RevokedKey
+		err     error
+	}{
+		{name: "other key", revoked: RevokedKey{KeyIssuer: "merchant.com", KeyId: "other-key", RevokedAt: 1, Reason: RevocationReasonCompromised}},
+		{name: "compromised root", revoked: RevokedKey{KeyIssuer: "psp.com", KeyId: "root-1", RevokedAt: now.Add(time.Hour).UnixMilli(), Reason: RevocationReasonCompromised}, err: ErrKeyRevoked},
+		{name: "compromised intermediate", revoked: RevokedKey{KeyIssuer: "merchant.com", KeyId: "region-1", RevokedAt: now.Add(time.Hour).UnixMilli(), Reason: RevocationReasonCompromised}, err: ErrKeyRevoked},
+		{name: "superseded intermediate", revoked: RevokedKey{KeyIssuer: "merchant.com", KeyId: "region-1", RevokedAt: now.Add(-time.Hour).UnixMilli(), Reason: "superseded"}, err: ErrKeyRevoked},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := builder.Read(qrToken, "", QrCriptoReadOptions{TrustAnchors: anchors, RevocationChecker: NewMemoryRevocationChecker(test.revoked)})
+
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+// Should load a signed revocation list from a file
+func TestFileRevocationChecker(t *testing.T) {
+	assert := assert.New(t)
+
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	issuerKeys, _ := paseto.GenerateKey("public", "paserk")
+
+	var options = QrCriptoCreateOptions{
+		SignOptions:   paseto.PasetoSignOptions{KeyId: "revocations", ExpiresIn: "1d", Assertion: []byte(issuerKeys["publicKey"])},
+		KeyIssuer:     "merchant.com",
+		KeyExpiration: time.Now().Add(1e9).Format(utils.RFC3339Mili),
+	}
+
+	list, err := builder.CreateRevocationList(RevocationList{Revocations: []RevokedKey{
+		{KeyIssuer: "merchant.com", KeyId: "merchant-key", RevokedAt: time.Now().Add(-time.Hour).UnixMilli(), Reason: RevocationReasonCompromised},
+	}}, issuerKeys["secretKey"], options)
+
+	assert.Nil(err)
+
+	path := filepath.Join(t.TempDir(), "revocations.txt")
+
+	assert.Nil(os.WriteFile(path, []byte(list+"\n"), 0o600))
+
+	checker, err := NewFileRevocationChecker(path, builder, issuerKeys["publicKey"], QrCriptoReadOptions{KeyIssuer: "merchant.com"})
+
+	if err != nil {
+		t.Fatalf("TestFileRevocationChecker FAIL --> %v", err)
+	}
+
+	qrToken, _ := builder.CreatePaymentInstruction(refundOriginal, keys["secretKey"], certificateOptions("merchant.com", "merchant-key", keys["publicKey"], ""))
+
+	_, err = builder.Read(qrToken, keys["publicKey"], QrCriptoReadOptions{RevocationChecker: checker})
+
+	assert.ErrorIs(err, ErrKeyRevoked)
+
+	// A list signed by another key is rejected and the loaded revocations are kept
+	forged, _ := builder.CreateRevocationList(RevocationList{Revocations: []RevokedKey{
+		{KeyIssuer: "other.com", KeyId: "other-key", RevokedAt: 1},
+	}}, keys["secretKey"], certificateOptions("merchant.com", "revocations", keys["publicKey"], ""))
+
+	assert.Nil(os.WriteFile(path, []byte(forged), 0o600))
+	assert.NotNil(checker.Reload())
+
+	// A list revoking keys of another issuer is rejected
+	foreign, _ := builder.CreateRevocationList(RevocationList{Revocations: []RevokedKey{
+		{KeyIssuer: "other.com", KeyId: "other-key", RevokedAt: 1},
+	}}, issuerKeys["secretKey"], options)
+
+	assert.Nil(os.WriteFile(path, []byte(foreign), 0o600))
+	assert.EqualError(checker.Reload(), "revocation list key issuer mismatch")
+
+	revocation, _ := checker.Revocation("other.com", "other-key")
+
+	assert.Nil(revocation)
+
+	revocation, _ = checker.Revocation("merchant.com", "merchant-key")
+
+	assert.NotNil(revocation)
+
+	_, err = NewFileRevocationChecker(filepath.Join(t.TempDir(), "missing.txt"), builder, issuerKeys["publicKey"], QrCriptoReadOptions{})
+
+	assert.EqualError(err, "unable to read revocation list file")
+
+	_, err = builder.CreateRevocationList(RevocationList{}, issuerKeys["secretKey"], options)
+
+	assert.Contains(err.Error(), "revocations")
+}
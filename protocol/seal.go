@@ -0,0 +1,143 @@
+package protocol
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/fluxisus/naspip-go/v3/encoding/protobuf"
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/utils"
+)
+
+// SealedPayload carries the signed NASPIP token of a sealed instruction inside its
+// encrypted token.
+type SealedPayload struct {
+	SignedToken string `json:"signed_token"` // Issuer-signed NASPIP token
+}
+
+// CreateSealedPaymentInstruction creates a signed NASPIP token that only one recipient can read.
+// The instruction is first signed with the issuer secret key, as CreatePaymentInstruction does.
+// The signed token is then encrypted as a PASETO v4.local token with a random key, and the key
+// is wrapped to the recipient public key (PASERK k4.seal) and carried in the footer, so the
+// token can be shared over insecure channels without losing the issuer signature.
+//
+// Parameters:
+//   - data: The instruction payload to encode in the token
+//   - secretKey: The issuer private key (in raw or PASERK format) to sign the instruction
+//   - recipientPublicKey: The recipient wallet public key (in raw or PASERK format)
+//   - options: Options for the signed token creation; the key ID and key issuer identify the issuer
+//
+// Returns:
+//   - A NASPIP token string if creation succeeds
+//   - An error if validation, signing, encryption or key wrapping fails
+func (p PaymentInstructionsBuilder) CreateSealedPaymentInstruction(data InstructionPayload, secretKey string, recipientPublicKey string, options QrCriptoCreateOptions) (string, error) {
+	signedToken, err := p.CreatePaymentInstruction(data, secretKey, options)
+
+	if err != nil {
+		return "", err
+	}
+
+	localKeys, err := paseto.GenerateKey("local", "paserk")
+
+	if err != nil {
+		return "", err
+	}
+
+	localKey, err := paseto.GetLocalKey(localKeys["localKey"])
+
+	if err != nil {
+		return "", err
+	}
+
+	sealedKey, err := paseto.SealLocalKey(localKey, recipientPublicKey)
+
+	if err != nil {
+		return "", err
+	}
+
+	footer, err := json.Marshal(TokenFooter{
+		KeyId:     options.SignOptions.KeyId,
+		KeyIssuer: options.KeyIssuer,
+		SealedKey: sealedKey,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	options.SignOptions.Footer = footer
+	options.SignOptions.Assertion = []byte(localKeys["localKey"])
+	options.KeyCertificate = ""
+	options.KeyFooter = false
+
+	var payload = &protobuf.PasetoTokenData{
+		Data: &protobuf.PasetoTokenData_SealedPayload{
+			SealedPayload: &protobuf.SealedPayload{SignedToken: signedToken},
+		},
+	}
+
+	return p.local().create(payload, localKeys["localKey"], options)
+}
+
+// ReadSealed reads a NASPIP token created with CreateSealedPaymentInstruction.
+// The token key is unwrapped with the recipient secret key and the token is decrypted. The
+// signed token it carries is then verified with the issuer public key like Read does, and
+// must have the key ID and key issuer of the sealed token.
+//
+// Parameters:
+//   - qrPayment: A sealed NASPIP token string
+//   - signerPublicKey: The issuer public key (in raw or PASERK format) to verify the signed token
+//   - recipientSecretKey: The recipient wallet private key (in raw or PASERK format)
+//   - options: Options controlling verification behavior
+//
+// Returns:
+//   - The content of the signed token if decryption and verification succeed
+//   - An error if the token is not sealed to the recipient or verification fails
+func (p PaymentInstructionsBuilder) ReadSealed(qrPayment string, signerPublicKey string, recipientSecretKey string, options QrCriptoReadOptions) (*paseto.PasetoCompleteResult, error) {
+	footer, err := p.ReadFooter(qrPayment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if footer.SealedKey == "" {
+		return nil, errors.New("token is not sealed")
+	}
+
+	localKey, err := paseto.UnsealLocalKey(footer.SealedKey, recipientSecretKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := p.local().Read(qrPayment, "k4.local."+utils.EncodeRawURLBase64(localKey[:]), options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	signedToken, ok := sealed.Payload.Data["signed_token"].(string)
+
+	if !ok {
+		return nil, errors.New("token is not sealed")
+	}
+
+	data, err := p.Read(signedToken, signerPublicKey, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if data.Payload.Kis != sealed.Payload.Kis || data.Payload.Kid != sealed.Payload.Kid {
+		return nil, errors.New("sealed token signer mismatch")
+	}
+
+	return data, nil
+}
+
+// local returns a copy of the builder that creates and reads PASETO v4.local tokens.
+func (p PaymentInstructionsBuilder) local() PaymentInstructionsBuilder {
+	p.PasetoHandler = paseto.PasetoV4LocalHandler{}
+
+	return p
+}
@@ -0,0 +1,82 @@
+package protocol
+
+import (
+	"testing"
+
+	"github.com/fluxisus/naspip-go/v3/encoding/protobuf"
+	"github.com/fluxisus/naspip-go/v3/paseto"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Should create signed instructions that only the recipient wallet can read
+func TestSealedPaymentInstruction(t *testing.T) {
+	assert := assert.New(t)
+
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	recipientKeys, _ := paseto.GenerateKey("public", "paserk")
+	otherKeys, _ := paseto.GenerateKey("public", "paserk")
+
+	var options = certificateOptions("psp.com", "payouts", keys["publicKey"], "")
+
+	qrToken, err := builder.CreateSealedPaymentInstruction(refundOriginal, keys["secretKey"], recipientKeys["publicKey"], options)
+
+	assert.Nil(err)
+
+	decoded, _ := builder.Decode(qrToken)
+	pasetoToken, _ := paseto.DecodeV4(decoded.Token)
+
+	assert.Equal("local", pasetoToken.Purpose)
+	assert.Equal("psp.com", decoded.KeyIssuer)
+
+	data, err := builder.ReadSealed(qrToken, keys["publicKey"], recipientKeys["secretKey"], QrCriptoReadOptions{KeyIssuer: "psp.com"})
+
+	assert.Nil(err)
+	assert.Equal("payouts", data.Payload.Kid)
+	assert.Equal(refundOriginal.Payment.Id, data.Payload.Data["payment"].(map[string]interface{})["id"])
+
+	_, err = builder.ReadSealed(qrToken, keys["publicKey"], otherKeys["secretKey"], QrCriptoReadOptions{})
+
+	assert.EqualError(err, "invalid sealed key")
+
+	// The instruction must be signed by the expected issuer
+	_, err = builder.ReadSealed(qrToken, otherKeys["publicKey"], recipientKeys["secretKey"], QrCriptoReadOptions{})
+
+	assert.NotNil(err)
+
+	// Anyone holding the recipient public key can seal a token, but not sign it as the issuer
+	forged, err := builder.CreateSealedPaymentInstruction(refundOriginal, otherKeys["secretKey"], recipientKeys["publicKey"], certificateOptions("psp.com", "payouts", otherKeys["publicKey"], ""))
+
+	assert.Nil(err)
+
+	_, err = builder.ReadSealed(forged, keys["publicKey"], recipientKeys["secretKey"], QrCriptoReadOptions{})
+
+	assert.EqualError(err, "paseto: invalid token signature")
+
+	// The sealed token must carry the key of the signed token
+	localKeys, _ := paseto.GenerateKey("local", "paserk")
+	localKey, _ := paseto.GetLocalKey(localKeys["localKey"])
+	sealedKey, _ := paseto.SealLocalKey(localKey, recipientKeys["publicKey"])
+
+	var outerOptions = certificateOptions("other.com", "payouts", localKeys["localKey"], "")
+	outerOptions.SignOptions.Footer = []byte(`{"kid":"payouts","kis":"other.com","seal":"` + sealedKey + `"}`)
+
+	signedToken, _ := builder.CreatePaymentInstruction(refundOriginal, keys["secretKey"], options)
+	mismatched, err := builder.local().create(&protobuf.PasetoTokenData{
+		Data: &protobuf.PasetoTokenData_SealedPayload{SealedPayload: &protobuf.SealedPayload{SignedToken: signedToken}},
+	}, localKeys["localKey"], outerOptions)
+
+	assert.Nil(err)
+
+	_, err = builder.ReadSealed(mismatched, keys["publicKey"], recipientKeys["secretKey"], QrCriptoReadOptions{})
+
+	assert.EqualError(err, "sealed token signer mismatch")
+
+	// Plain signed tokens are not sealed
+	signed, _ := builder.CreatePaymentInstruction(refundOriginal, keys["secretKey"], certificateOptions("psp.com", "payouts", keys["publicKey"], ""))
+
+	_, err = builder.ReadSealed(signed, keys["publicKey"], recipientKeys["secretKey"], QrCriptoReadOptions{})
+
+	assert.EqualError(err, "token is not sealed")
+}
@@ -0,0 +1,116 @@
+package protocol
+
+import (
+	"errors"
+
+	"github.com/fluxisus/naspip-go/v3/encoding/protobuf"
+	validator "github.com/tiendc/go-validator"
+)
+
+// WebhookEvent represents a signed notification of a payment event, sent by a PSP to a
+// merchant. It is signed with the same keys as the payment instructions, so merchants verify
+// it with the key resolution they already use for NASPIP tokens.
+type WebhookEvent struct {
+	EventType     string `json:"event_type"`     // Type of the event (e.g., instruction.paid)
+	InstructionId string `json:"instruction_id"` // Payment identifier of the instruction
+	Status        string `json:"status"`         // Status of the instruction after the event
+	OccurredAt    int64  `json:"occurred_at"`    // Unix timestamp (milliseconds) when the event occurred
+}
+
+// CreateWebhookEvent creates a NASPIP token containing a webhook event.
+//
+// Parameters:
+//   - data: The webhook event to encode in the token
+//   - secretKey: The private key (in raw or PASERK format) to sign the token
+//   - options: Options for token creation
+//
+// Returns:
+//   - A NASPIP token string if creation succeeds
+//   - An error if validation or creation fails
+func (p PaymentInstructionsBuilder) CreateWebhookEvent(data WebhookEvent, secretKey string, options QrCriptoCreateOptions) (string, error) {
+
+	isValid, err := validateWebhookEvent(data)
+
+	if !isValid {
+		return "", err
+	}
+
+	protoPayload := &protobuf.WebhookEvent{}
+	if err := protobuf.ConvertGoToProto(data, protoPayload); err != nil {
+		return "", err
+	}
+
+	var payload = &protobuf.PasetoTokenData{
+		Data: &protobuf.PasetoTokenData_WebhookEvent{
+			WebhookEvent: protoPayload,
+		},
+	}
+
+	return p.create(payload, secretKey, options)
+}
+
+// ReadWebhookEvent reads and verifies a NASPIP token containing a webhook event.
+//
+// Parameters:
+//   - qrPayment: A NASPIP webhook event token to verify
+//   - publicKey: The public key (in raw or PASERK format) of the sender
+//   - options: Options controlling verification behavior
+//
+// Returns:
+//   - The webhook event if verification succeeds
+//   - An error if verification fails or the token does not contain a webhook event
+func (p PaymentInstructionsBuilder) ReadWebhookEvent(qrPayment string, publicKey string, options QrCriptoReadOptions) (*WebhookEvent, error) {
+	data, err := p.Read(qrPayment, publicKey, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return WebhookEventOf(data.Payload.Data)
+}
+
+// WebhookEventOf converts verified token data into a webhook event.
+//
+// Parameters:
+//   - data: The data of a verified token, as returned by Read
+//
+// Returns:
+//   - The webhook event
+//   - An error if the data does not contain a webhook event
+func WebhookEventOf(data map[string]interface{}) (*WebhookEvent, error) {
+	if _, ok := data["event_type"]; !ok {
+		return nil, errors.New("token does not contain a webhook event")
+	}
+
+	var event WebhookEvent
+
+	if err := PayloadOf(data, &event); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// validateWebhookEvent validates the fields of a webhook event.
+func validateWebhookEvent(payload WebhookEvent) (bool, error) {
+	errs := validator.Validate(
+		validator.StrLen(&payload.EventType, 1, 100).OnError(
+			validator.SetField("event_type", nil),
+		),
+		validator.StrLen(&payload.InstructionId, 1, 100).OnError(
+			validator.SetField("instruction_id", nil),
+		),
+		validator.StrLen(&payload.Status, 1, 50).OnError(
+			validator.SetField("status", nil),
+		),
+		validator.NumGT(&payload.OccurredAt, 0).OnError(
+			validator.SetField("occurred_at", nil),
+		),
+	)
+
+	if len(errs) > 0 {
+		return false, errs[0]
+	}
+
+	return true, nil
+}
@@ -0,0 +1,72 @@
+package protocol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/paseto"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var webhookEvent = WebhookEvent{
+	EventType:     "instruction.paid",
+	InstructionId: "payment-id",
+	Status:        "paid",
+	OccurredAt:    time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC).UnixMilli(),
+}
+
+// Should create and read a webhook event token
+func TestCreateWebhookEvent(t *testing.T) {
+	assert := assert.New(t)
+
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	token, err := builder.CreateWebhookEvent(webhookEvent, keys["secretKey"], certificateOptions("psp.com", "webhook-key", keys["publicKey"], ""))
+
+	assert.Nil(err)
+
+	event, err := builder.ReadWebhookEvent(token, keys["publicKey"], QrCriptoReadOptions{})
+
+	assert.Nil(err)
+	assert.Equal(webhookEvent, *event)
+}
+
+// Should reject invalid webhook events
+func TestCreateWebhookEventValidation(t *testing.T) {
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	tests := []struct {
+		name   string
+		modify func(event *WebhookEvent)
+	}{
+		{name: "missing event type", modify: func(event *WebhookEvent) { event.EventType = "" }},
+		{name: "missing instruction id", modify: func(event *WebhookEvent) { event.InstructionId = "" }},
+		{name: "missing status", modify: func(event *WebhookEvent) { event.Status = "" }},
+		{name: "missing occurred at", modify: func(event *WebhookEvent) { event.OccurredAt = 0 }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			event := webhookEvent
+			test.modify(&event)
+
+			_, err := builder.CreateWebhookEvent(event, keys["secretKey"], certificateOptions("psp.com", "webhook-key", keys["publicKey"], ""))
+
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+// Should reject tokens that do not contain a webhook event
+func TestReadWebhookEventWrongPayload(t *testing.T) {
+	var builder = PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+	token, err := builder.CreatePaymentInstruction(refundOriginal, keys["secretKey"], certificateOptions("psp.com", "webhook-key", keys["publicKey"], ""))
+
+	assert.Nil(t, err)
+
+	_, err = builder.ReadWebhookEvent(token, keys["publicKey"], QrCriptoReadOptions{})
+
+	assert.EqualError(t, err, "token does not contain a webhook event")
+}
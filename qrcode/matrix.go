@@ -0,0 +1,355 @@
+package qrcode
+
+// symbol is a QR code being built, tracking which modules belong to function patterns.
+type symbol struct {
+	QRCode
+	isFunction [][]bool
+}
+
+// newQRCode creates a symbol with its function patterns drawn.
+func newQRCode(version int, level Level) *symbol {
+	size := version*4 + 17
+
+	qr := &symbol{QRCode: QRCode{Version: version, Level: level, Size: size}}
+	qr.Modules = make([][]bool, size)
+	qr.isFunction = make([][]bool, size)
+
+	for i := range qr.Modules {
+		qr.Modules[i] = make([]bool, size)
+		qr.isFunction[i] = make([]bool, size)
+	}
+
+	qr.drawFunctionPatterns()
+
+	return qr
+}
+
+// setFunction sets the color of a function module.
+func (qr *symbol) setFunction(x int, y int, dark bool) {
+	qr.Modules[y][x] = dark
+	qr.isFunction[y][x] = true
+}
+
+// drawFunctionPatterns draws the timing, finder and alignment patterns and reserves the
+// format and version information areas.
+func (qr *symbol) drawFunctionPatterns() {
+	for i := 0; i < qr.Size; i++ {
+		qr.setFunction(6, i, i%2 == 0)
+		qr.setFunction(i, 6, i%2 == 0)
+	}
+
+	qr.drawFinderPattern(3, 3)
+	qr.drawFinderPattern(qr.Size-4, 3)
+	qr.drawFinderPattern(3, qr.Size-4)
+
+	positions := alignmentPatternPositions(qr.Version)
+	last := len(positions) - 1
+
+	for i, x := range positions {
+		for j, y := range positions {
+			// Alignment patterns overlapping the finder patterns are skipped
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+
+			qr.drawAlignmentPattern(x, y)
+		}
+	}
+
+	qr.drawFormatBits(0)
+	qr.drawVersion()
+}
+
+// drawFinderPattern draws a finder pattern and its separator centered on the module.
+func (qr *symbol) drawFinderPattern(x int, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+
+			if xx < 0 || xx >= qr.Size || yy < 0 || yy >= qr.Size {
+				continue
+			}
+
+			distance := max(abs(dx), abs(dy))
+			qr.setFunction(xx, yy, distance != 2 && distance != 4)
+		}
+	}
+}
+
+// drawAlignmentPattern draws an alignment pattern centered on the module.
+func (qr *symbol) drawAlignmentPattern(x int, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			qr.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// drawFormatBits draws both copies of the format information for the level and mask.
+func (qr *symbol) drawFormatBits(mask int) {
+	bits := formatInformation(qr.Level, mask)
+
+	for i := 0; i <= 5; i++ {
+		qr.setFunction(8, i, bit(bits, i))
+	}
+
+	qr.setFunction(8, 7, bit(bits, 6))
+	qr.setFunction(8, 8, bit(bits, 7))
+	qr.setFunction(7, 8, bit(bits, 8))
+
+	for i := 9; i < 15; i++ {
+		qr.setFunction(14-i, 8, bit(bits, i))
+	}
+
+	for i := 0; i < 8; i++ {
+		qr.setFunction(qr.Size-1-i, 8, bit(bits, i))
+	}
+
+	for i := 8; i < 15; i++ {
+		qr.setFunction(8, qr.Size-15+i, bit(bits, i))
+	}
+
+	// Dark module
+	qr.setFunction(8, qr.Size-8, true)
+}
+
+// drawVersion draws both copies of the version information, present from version 7.
+func (qr *symbol) drawVersion() {
+	if qr.Version < 7 {
+		return
+	}
+
+	bits := versionInformation(qr.Version)
+
+	for i := 0; i < 18; i++ {
+		a, b := qr.Size-11+i%3, i/3
+
+		qr.setFunction(a, b, bit(bits, i))
+		qr.setFunction(b, a, bit(bits, i))
+	}
+}
+
+// drawCodewords places the codewords in the data area, in two-module wide columns
+// zigzagging from the bottom right corner.
+func (qr *symbol) drawCodewords(codewords []byte) {
+	i := 0
+
+	for right := qr.Size - 1; right >= 1; right -= 2 {
+		// The vertical timing pattern is skipped
+		if right == 6 {
+			right = 5
+		}
+
+		for vertical := 0; vertical < qr.Size; vertical++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vertical
+
+				if (right+1)&2 == 0 {
+					y = qr.Size - 1 - vertical
+				}
+
+				if !qr.isFunction[y][x] && i < len(codewords)*8 {
+					qr.Modules[y][x] = bit(int(codewords[i>>3]), 7-(i&7))
+					i++
+				}
+			}
+		}
+	}
+}
+
+// applyMask inverts the data modules selected by the mask pattern.
+func (qr *symbol) applyMask(mask int) {
+	for y := 0; y < qr.Size; y++ {
+		for x := 0; x < qr.Size; x++ {
+			var invert bool
+
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+
+			if invert && !qr.isFunction[y][x] {
+				qr.Modules[y][x] = !qr.Modules[y][x]
+			}
+		}
+	}
+}
+
+// penaltyScore rates the symbol appearance; the mask with the lowest score is selected.
+// It penalizes long runs, 2x2 blocks, finder-like patterns and unbalanced dark modules.
+func (qr *symbol) penaltyScore() int {
+	const (
+		penaltyRun     = 3
+		penaltyBlock   = 3
+		penaltyFinder  = 40
+		penaltyBalance = 10
+	)
+
+	result := 0
+
+	for _, vertical := range []bool{false, true} {
+		for a := 0; a < qr.Size; a++ {
+			runColor := false
+			runLength := 0
+			history := make([]int, 7)
+
+			for b := 0; b < qr.Size; b++ {
+				color := qr.Modules[a][b]
+
+				if vertical {
+					color = qr.Modules[b][a]
+				}
+
+				if color == runColor {
+					runLength++
+
+					if runLength == 5 {
+						result += penaltyRun
+					} else if runLength > 5 {
+						result++
+					}
+
+					continue
+				}
+
+				qr.addRunHistory(runLength, history)
+
+				if !runColor {
+					result += finderPatterns(history) * penaltyFinder
+				}
+
+				runColor = color
+				runLength = 1
+			}
+
+			if runColor {
+				qr.addRunHistory(runLength, history)
+				runLength = 0
+			}
+
+			qr.addRunHistory(runLength+qr.Size, history)
+			result += finderPatterns(history) * penaltyFinder
+		}
+	}
+
+	dark := 0
+
+	for y := 0; y < qr.Size; y++ {
+		for x := 0; x < qr.Size; x++ {
+			color := qr.Modules[y][x]
+
+			if color {
+				dark++
+			}
+
+			if x < qr.Size-1 && y < qr.Size-1 && color == qr.Modules[y][x+1] && color == qr.Modules[y+1][x] && color == qr.Modules[y+1][x+1] {
+				result += penaltyBlock
+			}
+		}
+	}
+
+	total := qr.Size * qr.Size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+
+	return result + k*penaltyBalance
+}
+
+// addRunHistory records the length of a run; the first run includes the light border.
+func (qr *symbol) addRunHistory(length int, history []int) {
+	if history[0] == 0 {
+		length += qr.Size
+	}
+
+	copy(history[1:], history[:len(history)-1])
+	history[0] = length
+}
+
+// finderPatterns counts the 1:1:3:1:1 patterns with a light border at the end of the run history.
+func finderPatterns(history []int) int {
+	n := history[1]
+	core := n > 0 && history[2] == n && history[3] == n*3 && history[4] == n && history[5] == n
+
+	count := 0
+
+	if core && history[0] >= n*4 && history[6] >= n {
+		count++
+	}
+
+	if core && history[6] >= n*4 && history[0] >= n {
+		count++
+	}
+
+	return count
+}
+
+// formatInformation returns the 15-bit format information of a level and mask:
+// a BCH(15,5) code word masked with 0x5412.
+func formatInformation(level Level, mask int) int {
+	data := level.formatBits()<<3 | mask
+	remainder := data
+
+	for i := 0; i < 10; i++ {
+		remainder = (remainder << 1) ^ ((remainder >> 9) * 0x537)
+	}
+
+	return (data<<10 | remainder) ^ 0x5412
+}
+
+// versionInformation returns the 18-bit version information: a BCH(18,6) code word.
+func versionInformation(version int) int {
+	remainder := version
+
+	for i := 0; i < 12; i++ {
+		remainder = (remainder << 1) ^ ((remainder >> 11) * 0x1F25)
+	}
+
+	return version<<12 | remainder
+}
+
+// alignmentPatternPositions returns the center coordinates of the alignment patterns of a version.
+func alignmentPatternPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+
+	numAlign := version/7 + 2
+	step := (version*8 + numAlign*3 + 5) / (numAlign*4 - 4) * 2
+
+	result := make([]int, numAlign)
+	result[0] = 6
+
+	for i, position := numAlign-1, version*4+10; i >= 1; i, position = i-1, position-step {
+		result[i] = position
+	}
+
+	return result
+}
+
+// bit reports whether the bit at index i of value is set.
+func bit(value int, i int) bool {
+	return (value>>i)&1 != 0
+}
+
+// abs returns the absolute value of an integer.
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+
+	return value
+}
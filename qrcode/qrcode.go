@@ -0,0 +1,278 @@
+// Package qrcode encodes NASPIP tokens as QR Code symbols (ISO/IEC 18004) and renders them
+// as PNG or SVG images. Tokens are encoded in byte mode, using the smallest version (1 to 40)
+// that holds the content at the requested error correction level.
+package qrcode
+
+import (
+	"errors"
+)
+
+// Level is the error correction level of a QR code.
+type Level int
+
+const (
+	Low      Level = iota // Recovers about 7% of the symbol
+	Medium                // Recovers about 15% of the symbol
+	Quartile              // Recovers about 25% of the symbol
+	High                  // Recovers about 30% of the symbol
+)
+
+// formatBits returns the two bits identifying the level in the format information.
+func (l Level) formatBits() int {
+	return [...]int{1, 0, 3, 2}[l]
+}
+
+// QRCode is an encoded QR code symbol.
+type QRCode struct {
+	Version int      // Symbol version, from 1 to 40
+	Level   Level    // Error correction level
+	Mask    int      // Data mask pattern, from 0 to 7
+	Size    int      // Number of modules per side
+	Modules [][]bool // Module colors indexed by row and column, true for dark modules
+}
+
+// eccCodewordsPerBlock is the number of error correction codewords per block, by level and version.
+var eccCodewordsPerBlock = [4][41]int{
+	{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+// numErrorCorrectionBlocks is the number of error correction blocks, by level and version.
+var numErrorCorrectionBlocks = [4][41]int{
+	{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+// Encode encodes the content in a QR code symbol.
+//
+// Parameters:
+//   - content: The text to encode, typically a NASPIP token
+//   - level: The error correction level
+//
+// Returns:
+//   - The QR code symbol
+//   - An error if the content does not fit in a version 40 symbol
+func Encode(content string, level Level) (*QRCode, error) {
+	if level < Low || level > High {
+		return nil, errors.New("invalid error correction level")
+	}
+
+	data := []byte(content)
+
+	version := 0
+
+	for v := 1; v <= 40; v++ {
+		if dataBitsLength(v, len(data)) <= numDataCodewords(v, level)*8 {
+			version = v
+			break
+		}
+	}
+
+	if version == 0 {
+		return nil, errors.New("content too long for a QR code")
+	}
+
+	codewords := addEccAndInterleave(encodeData(data, version, level), version, level)
+
+	qr := newQRCode(version, level)
+	qr.drawCodewords(codewords)
+
+	bestPenalty := -1
+
+	for mask := 0; mask < 8; mask++ {
+		qr.applyMask(mask)
+		qr.drawFormatBits(mask)
+
+		if penalty := qr.penaltyScore(); bestPenalty < 0 || penalty < bestPenalty {
+			bestPenalty = penalty
+			qr.Mask = mask
+		}
+
+		// Masks are XOR operations, applying a mask again removes it
+		qr.applyMask(mask)
+	}
+
+	qr.applyMask(qr.Mask)
+	qr.drawFormatBits(qr.Mask)
+
+	return &qr.QRCode, nil
+}
+
+// dataBitsLength returns the number of bits used by byte mode content in a symbol version.
+func dataBitsLength(version int, length int) int {
+	return 4 + charCountBits(version) + length*8
+}
+
+// charCountBits returns the size of the byte mode character count indicator.
+func charCountBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+
+	return 16
+}
+
+// encodeData builds the data codewords: mode indicator, character count, content, terminator and padding.
+func encodeData(data []byte, version int, level Level) []byte {
+	capacity := numDataCodewords(version, level) * 8
+
+	bits := &bitBuffer{}
+	bits.append(0x4, 4)
+	bits.append(len(data), charCountBits(version))
+
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+
+	bits.append(0, min(4, capacity-bits.length))
+	bits.append(0, (8-bits.length%8)%8)
+
+	for pad := 0xEC; bits.length < capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	return bits.bytes
+}
+
+// numRawDataModules returns the number of modules available for data and error correction in a version.
+func numRawDataModules(version int) int {
+	result := (16*version+128)*version + 64
+
+	if version >= 2 {
+		numAlign := version/7 + 2
+		result -= (25*numAlign-10)*numAlign - 55
+
+		if version >= 7 {
+			result -= 36
+		}
+	}
+
+	return result
+}
+
+// numDataCodewords returns the number of data codewords of a version and level.
+func numDataCodewords(version int, level Level) int {
+	return numRawDataModules(version)/8 - eccCodewordsPerBlock[level][version]*numErrorCorrectionBlocks[level][version]
+}
+
+// addEccAndInterleave splits the data in blocks, appends the error correction codewords of
+// each block and interleaves the blocks.
+func addEccAndInterleave(data []byte, version int, level Level) []byte {
+	numBlocks := numErrorCorrectionBlocks[level][version]
+	blockEccLength := eccCodewordsPerBlock[level][version]
+	rawCodewords := numRawDataModules(version) / 8
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLength := rawCodewords / numBlocks
+
+	divisor := reedSolomonDivisor(blockEccLength)
+
+	blocks := make([][]byte, numBlocks)
+
+	for i, k := 0, 0; i < numBlocks; i++ {
+		length := shortBlockLength - blockEccLength
+
+		if i >= numShortBlocks {
+			length++
+		}
+
+		block := append([]byte{}, data[k:k+length]...)
+		k += length
+
+		ecc := reedSolomonRemainder(block, divisor)
+
+		// Short blocks are padded so all blocks have the same length; the padding is skipped below
+		if i < numShortBlocks {
+			block = append(block, 0)
+		}
+
+		blocks[i] = append(block, ecc...)
+	}
+
+	result := make([]byte, 0, rawCodewords)
+
+	for i := range blocks[0] {
+		for j, block := range blocks {
+			if i != shortBlockLength-blockEccLength || j >= numShortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+
+	return result
+}
+
+// reedSolomonDivisor returns the generator polynomial of the given degree, without its leading term.
+func reedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+
+	root := byte(1)
+
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+
+		root = gfMultiply(root, 0x02)
+	}
+
+	return result
+}
+
+// reedSolomonRemainder returns the error correction codewords of a block.
+func reedSolomonRemainder(data []byte, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+
+		for i := range result {
+			result[i] ^= gfMultiply(divisor[i], factor)
+		}
+	}
+
+	return result
+}
+
+// gfMultiply multiplies two elements of GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1.
+func gfMultiply(x byte, y byte) byte {
+	z := 0
+
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>i)&1) * int(x)
+	}
+
+	return byte(z)
+}
+
+// bitBuffer is an append-only sequence of bits.
+type bitBuffer struct {
+	bytes  []byte
+	length int
+}
+
+// append adds the lowest count bits of value, most significant bit first.
+func (b *bitBuffer) append(value int, count int) {
+	for i := count - 1; i >= 0; i-- {
+		if b.length%8 == 0 {
+			b.bytes = append(b.bytes, 0)
+		}
+
+		if (value>>i)&1 != 0 {
+			b.bytes[b.length/8] |= 0x80 >> (b.length % 8)
+		}
+
+		b.length++
+	}
+}
@@ -0,0 +1,177 @@
+package qrcode
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const token = "naspip;merchant.com;merchant-key;v4.public.CiQKBnBheS0xMhIJMHhBZGRyZXNzGg1ucG9seWdvbl90MHgxIgMxMC41KIDi4uHqMhoGbWVyY2hhbnQtY2hhbm5lbDIjMjAyNS0wMS0wMVQwMDowMDowMC4wMDBaOgZrZXktaWQ"
+
+// Should compute the error correction codewords of the ISO/IEC 18004 example
+func TestReedSolomon(t *testing.T) {
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+
+	ecc := reedSolomonRemainder(data, reedSolomonDivisor(10))
+
+	assert.Equal(t, []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}, ecc)
+}
+
+// Should compute the format and version information code words
+func TestFormatAndVersionInformation(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0b111011111000100, formatInformation(Low, 0))
+	assert.Equal(0b101010000010010, formatInformation(Medium, 0))
+	assert.Equal(0b011010101011111, formatInformation(Quartile, 0))
+	assert.Equal(0b001011010001001, formatInformation(High, 0))
+	assert.Equal(0b000111110010010100, versionInformation(7))
+	assert.Equal(0b101000110001101001, versionInformation(40))
+}
+
+// Should compute the symbol capacities
+func TestCapacity(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(19, numDataCodewords(1, Low))
+	assert.Equal(16, numDataCodewords(1, Medium))
+	assert.Equal(669, numDataCodewords(20, Medium))
+	assert.Equal(2956, numDataCodewords(40, Low))
+	assert.Equal(1276, numDataCodewords(40, High))
+	assert.Equal([]int{6, 34, 60, 86, 112, 138}, alignmentPatternPositions(32))
+}
+
+// Should select the smallest version holding the content
+func TestEncodeVersion(t *testing.T) {
+	assert := assert.New(t)
+
+	qr, err := Encode(strings.Repeat("a", 17), Low)
+
+	assert.Nil(err)
+	assert.Equal(1, qr.Version)
+	assert.Equal(21, qr.Size)
+
+	qr, _ = Encode(strings.Repeat("a", 18), Low)
+
+	assert.Equal(2, qr.Version)
+
+	qr, _ = Encode(strings.Repeat("a", 2953), Low)
+
+	assert.Equal(40, qr.Version)
+
+	_, err = Encode(strings.Repeat("a", 2954), Low)
+
+	assert.EqualError(err, "content too long for a QR code")
+}
+
+// Should place the codewords and format information so they can be read back
+func TestEncodeReadBack(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, level := range []Level{Low, Medium, Quartile, High} {
+		qr, err := Encode(token, level)
+
+		assert.Nil(err)
+
+		reference := newQRCode(qr.Version, level)
+
+		// Format information next to the top left finder pattern
+		format := 0
+
+		for i := 0; i <= 5; i++ {
+			format |= boolBit(qr.Modules[i][8]) << i
+		}
+
+		format |= boolBit(qr.Modules[7][8])<<6 | boolBit(qr.Modules[8][8])<<7 | boolBit(qr.Modules[8][7])<<8
+
+		for i := 9; i < 15; i++ {
+			format |= boolBit(qr.Modules[8][14-i]) << i
+		}
+
+		assert.Equal(formatInformation(level, qr.Mask), format)
+
+		// Unmask and read the data area
+		reference.Modules = qr.Modules
+		reference.applyMask(qr.Mask)
+
+		expected := addEccAndInterleave(encodeData([]byte(token), qr.Version, level), qr.Version, level)
+		read := make([]byte, len(expected))
+		i := 0
+
+		for right := qr.Size - 1; right >= 1; right -= 2 {
+			if right == 6 {
+				right = 5
+			}
+
+			for vertical := 0; vertical < qr.Size; vertical++ {
+				for j := 0; j < 2; j++ {
+					x, y := right-j, vertical
+
+					if (right+1)&2 == 0 {
+						y = qr.Size - 1 - vertical
+					}
+
+					if !reference.isFunction[y][x] && i < len(read)*8 {
+						read[i>>3] |= byte(boolBit(reference.Modules[y][x]) << (7 - i&7))
+						i++
+					}
+				}
+			}
+		}
+
+		reference.applyMask(qr.Mask)
+
+		assert.Equal(expected, read)
+	}
+}
+
+// Should render PNG and SVG images
+func TestRender(t *testing.T) {
+	assert := assert.New(t)
+
+	qr, _ := Encode(token, Medium)
+
+	image, err := qr.PNG(4, QuietZone)
+
+	assert.Nil(err)
+
+	decoded, err := png.Decode(bytes.NewReader(image))
+
+	assert.Nil(err)
+	assert.Equal((qr.Size+2*QuietZone)*4, decoded.Bounds().Dx())
+
+	svg, err := qr.SVG(QuietZone)
+
+	assert.Nil(err)
+	assert.Contains(svg, "<svg")
+	assert.Equal(countDark(qr), strings.Count(svg, "h1v1h-1z"))
+
+	_, err = qr.PNG(0, QuietZone)
+
+	assert.EqualError(err, "invalid QR code image size")
+}
+
+// boolBit converts a module color to a bit.
+func boolBit(dark bool) int {
+	if dark {
+		return 1
+	}
+
+	return 0
+}
+
+// countDark counts the dark modules of a symbol.
+func countDark(qr *QRCode) int {
+	count := 0
+
+	for _, row := range qr.Modules {
+		for _, dark := range row {
+			count += boolBit(dark)
+		}
+	}
+
+	return count
+}
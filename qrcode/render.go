@@ -0,0 +1,91 @@
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+)
+
+// QuietZone is the recommended number of light modules around the symbol.
+const QuietZone = 4
+
+// PNG renders the QR code as a grayscale PNG image.
+//
+// Parameters:
+//   - scale: Size in pixels of each module
+//   - border: Number of light modules around the symbol (see QuietZone)
+//
+// Returns:
+//   - The PNG image bytes
+//   - An error if the parameters are invalid or encoding fails
+func (qr *QRCode) PNG(scale int, border int) ([]byte, error) {
+	if scale < 1 || border < 0 {
+		return nil, errors.New("invalid QR code image size")
+	}
+
+	size := (qr.Size + border*2) * scale
+
+	img := image.NewGray(image.Rect(0, 0, size, size))
+
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			pixel := color.Gray{Y: 0xFF}
+
+			if qr.dark(x/scale-border, y/scale-border) {
+				pixel = color.Gray{Y: 0x00}
+			}
+
+			img.SetGray(x, y, pixel)
+		}
+	}
+
+	var buffer bytes.Buffer
+
+	if err := png.Encode(&buffer, img); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// SVG renders the QR code as an SVG document, using one module per user unit.
+//
+// Parameters:
+//   - border: Number of light modules around the symbol (see QuietZone)
+//
+// Returns:
+//   - The SVG document
+//   - An error if the border is invalid
+func (qr *QRCode) SVG(border int) (string, error) {
+	if border < 0 {
+		return "", errors.New("invalid QR code image size")
+	}
+
+	var path strings.Builder
+
+	for y := 0; y < qr.Size; y++ {
+		for x := 0; x < qr.Size; x++ {
+			if qr.Modules[y][x] {
+				fmt.Fprintf(&path, "M%d,%dh1v1h-1z", x+border, y+border)
+			}
+		}
+	}
+
+	size := qr.Size + border*2
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" version="1.1" viewBox="0 0 %d %d" stroke="none">
+<rect width="100%%" height="100%%" fill="#FFFFFF"/>
+<path d="%s" fill="#000000"/>
+</svg>
+`, size, size, path.String()), nil
+}
+
+// dark reports whether the module is dark; modules outside the symbol are light.
+func (qr *QRCode) dark(x int, y int) bool {
+	return x >= 0 && x < qr.Size && y >= 0 && y < qr.Size && qr.Modules[y][x]
+}
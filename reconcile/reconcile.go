@@ -0,0 +1,414 @@
+// Package reconcile matches observed on-chain transfers with issued payment instructions.
+//
+// The caller supplies the instructions it issued and the transfers it observed (e.g., from a
+// block explorer or node). Reconcile assigns each transfer to at most one instruction, using
+// the recipient address, the asset, the address tag (memo) and the amount, and reports the
+// payment status of every instruction: paid, partially paid (underpaid) or overpaid, whether a
+// payment arrived after the instruction expired, and duplicated transfers.
+package reconcile
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/fluxisus/naspip-go/v3/protocol"
+	"github.com/shopspring/decimal"
+)
+
+// MatchStatus is the outcome of a transfer matched with an instruction.
+type MatchStatus string
+
+const (
+	MatchPaid      MatchStatus = "paid"      // The instruction is paid with this transfer
+	MatchUnderpaid MatchStatus = "underpaid" // The amount received so far is below the requested amount
+	MatchOverpaid  MatchStatus = "overpaid"  // The amount received so far is above the requested amount
+	MatchDuplicate MatchStatus = "duplicate" // The instruction was already paid before this transfer
+)
+
+// UnmatchedReason explains why a transfer was not matched.
+type UnmatchedReason string
+
+const (
+	ReasonNoInstruction     UnmatchedReason = "no_instruction"     // No instruction is payable to the address, asset and tag
+	ReasonAmbiguous         UnmatchedReason = "ambiguous"          // Several instructions could be paid by the transfer
+	ReasonInvalidAmount     UnmatchedReason = "invalid_amount"     // The transfer amount is not a positive decimal
+	ReasonDuplicateTransfer UnmatchedReason = "duplicate_transfer" // The transfer was already reported
+	ReasonInvalidRequest    UnmatchedReason = "invalid_request"    // The only candidate instructions have an invalid requested amount
+)
+
+// InstructionStatus is the payment status of an instruction after reconciliation.
+type InstructionStatus string
+
+const (
+	StatusUnpaid        InstructionStatus = "unpaid"         // No transfer was matched
+	StatusPartiallyPaid InstructionStatus = "partially_paid" // The amount received is below the requested amount
+	StatusPaid          InstructionStatus = "paid"           // The amount received is the requested amount, or within the open range
+	StatusOverpaid      InstructionStatus = "overpaid"       // The amount received is above the requested amount
+	StatusInvalid       InstructionStatus = "invalid"        // The fixed requested amount is not a decimal, so no transfer is matched
+)
+
+// evmAddress matches EVM addresses, whose hex digits may be in checksum (mixed) case.
+var evmAddress = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// Options contains the options of ReconcileWith.
+type Options struct {
+	// AddressEqual reports whether a transfer address is the instruction address for an asset,
+	// for networks whose addresses have several spellings. SameAddress when nil.
+	AddressEqual func(uniqueAssetId string, instructionAddress string, transferAddress string) bool
+}
+
+// SameAddress reports whether two addresses designate the same account. EVM addresses (0x
+// followed by 40 hex digits) are compared ignoring case, so checksum and lowercase spellings
+// match; other addresses must be equal.
+//
+// Parameters:
+//   - uniqueAssetId: Asset of the transfer, in the format of PaymentInstruction.UniqueAssetId
+//   - instructionAddress: Address of the instruction
+//   - transferAddress: Recipient address of the transfer
+//
+// Returns:
+//   - Whether the addresses are the same
+func SameAddress(uniqueAssetId string, instructionAddress string, transferAddress string) bool {
+	if evmAddress.MatchString(instructionAddress) && evmAddress.MatchString(transferAddress) {
+		return strings.EqualFold(instructionAddress, transferAddress)
+	}
+
+	return instructionAddress == transferAddress
+}
+
+// Transfer is an observed on-chain transfer.
+type Transfer struct {
+	Id            string // Unique identifier of the transfer (e.g., transaction hash and log index)
+	Address       string // Recipient address
+	AddressTag    string // Tag or memo of the transfer, if any
+	UniqueAssetId string // Asset identifier, in the format of PaymentInstruction.UniqueAssetId
+	Amount        string // Transferred amount, as a decimal string
+	Timestamp     int64  // Unix timestamp (milliseconds) of the transfer
+}
+
+// Match is a transfer assigned to an instruction.
+type Match struct {
+	Transfer      Transfer    // The matched transfer
+	InstructionId string      // Payment identifier of the instruction
+	Status        MatchStatus // Outcome of the transfer
+	Late          bool        // Whether the transfer happened after the instruction expired, never when ExpiresAt is 0
+	Received      string      // Total amount received for the instruction, including this transfer
+	Difference    string      // Received minus the requested amount (the nearest range bound for open amounts), "0" when paid
+}
+
+// Unmatched is a transfer that was not assigned to an instruction.
+type Unmatched struct {
+	Transfer   Transfer        // The transfer
+	Reason     UnmatchedReason // Why it was not matched
+	Candidates []string        // Payment identifiers of the candidate instructions, for ambiguous transfers
+}
+
+// Summary is the payment status of an instruction.
+type Summary struct {
+	InstructionId string            // Payment identifier of the instruction
+	Status        InstructionStatus // Payment status
+	Received      string            // Total amount received
+	Late          bool              // Whether any matched transfer happened after the instruction expired
+	TransferIds   []string          // Identifiers of the matched transfers, in matching order
+}
+
+// Report is the result of a reconciliation.
+type Report struct {
+	Matches      []Match     // Matched transfers, in timestamp order
+	Unmatched    []Unmatched // Transfers that were not matched, in timestamp order
+	Instructions []Summary   // Status of every instruction, in the order they were given
+}
+
+// Summary returns the status of the instruction with the given payment identifier.
+func (r Report) Summary(instructionId string) (Summary, bool) {
+	for _, summary := range r.Instructions {
+		if summary.InstructionId == instructionId {
+			return summary, true
+		}
+	}
+
+	return Summary{}, false
+}
+
+// ledger tracks the amount received by an instruction.
+type ledger struct {
+	instruction protocol.PaymentInstruction
+	received    decimal.Decimal
+	late        bool
+	invalid     bool // Whether the fixed requested amount is not a decimal
+	transferIds []string
+}
+
+// Reconcile matches transfers with payment instructions. Transfers are processed in timestamp
+// order, so that the amounts of partial payments accumulate.
+//
+// A transfer is a candidate for an instruction when the address (compared with SameAddress) and
+// asset are equal and, if the instruction has an AddressTag, the transfer carries the same tag.
+// Instructions whose fixed amount is not a decimal are never paid: they have StatusInvalid and
+// transfers only they could match are reported with ReasonInvalidRequest. When the transfer tag
+// designates a tagged instruction, untagged instructions are not considered. Among several
+// unpaid candidates, the only one whose amount the transfer settles is chosen; otherwise the
+// transfer is ambiguous. A transfer whose only candidate is already paid is a duplicate payment.
+//
+// Parameters:
+//   - instructions: The issued payment instructions
+//   - transfers: The observed transfers
+//
+// Returns:
+//   - The match report
+func Reconcile(instructions []protocol.PaymentInstruction, transfers []Transfer) Report {
+	return ReconcileWith(instructions, transfers, Options{})
+}
+
+// ReconcileWith matches transfers with payment instructions like Reconcile, with options.
+//
+// Parameters:
+//   - instructions: The issued payment instructions
+//   - transfers: The observed transfers
+//   - options: Matching options
+//
+// Returns:
+//   - The match report
+func ReconcileWith(instructions []protocol.PaymentInstruction, transfers []Transfer, options Options) Report {
+	if options.AddressEqual == nil {
+		options.AddressEqual = SameAddress
+	}
+
+	ledgers := make([]*ledger, len(instructions))
+
+	for index, instruction := range instructions {
+		_, err := decimal.NewFromString(instruction.Amount)
+
+		ledgers[index] = &ledger{instruction: instruction, received: decimal.Zero, invalid: !instruction.IsOpen && err != nil}
+	}
+
+	sorted := slices.Clone(transfers)
+	slices.SortStableFunc(sorted, func(a Transfer, b Transfer) int {
+		switch {
+		case a.Timestamp < b.Timestamp:
+			return -1
+		case a.Timestamp > b.Timestamp:
+			return 1
+		}
+
+		return 0
+	})
+
+	report := Report{Matches: []Match{}, Unmatched: []Unmatched{}}
+	seen := map[string]bool{}
+
+	for _, transfer := range sorted {
+		if transfer.Id != "" && seen[transfer.Id] {
+			report.Unmatched = append(report.Unmatched, Unmatched{Transfer: transfer, Reason: ReasonDuplicateTransfer})
+			continue
+		}
+
+		seen[transfer.Id] = true
+
+		amount, err := decimal.NewFromString(transfer.Amount)
+
+		if err != nil || !amount.IsPositive() {
+			report.Unmatched = append(report.Unmatched, Unmatched{Transfer: transfer, Reason: ReasonInvalidAmount})
+			continue
+		}
+
+		target, unmatched := selectLedger(ledgers, transfer, amount, options)
+
+		if unmatched != nil {
+			report.Unmatched = append(report.Unmatched, *unmatched)
+			continue
+		}
+
+		report.Matches = append(report.Matches, target.apply(transfer, amount))
+	}
+
+	for _, entry := range ledgers {
+		status, _ := entry.status()
+
+		report.Instructions = append(report.Instructions, Summary{
+			InstructionId: entry.instruction.Id,
+			Status:        status,
+			Received:      entry.received.String(),
+			Late:          entry.late,
+			TransferIds:   entry.transferIds,
+		})
+	}
+
+	return report
+}
+
+// selectLedger returns the instruction paid by a transfer, or the reason why none is.
+func selectLedger(ledgers []*ledger, transfer Transfer, amount decimal.Decimal, options Options) (*ledger, *Unmatched) {
+	candidates := []*ledger{}
+	tagged := false
+
+	for _, entry := range ledgers {
+		instruction := entry.instruction
+
+		if instruction.UniqueAssetId != transfer.UniqueAssetId || !options.AddressEqual(instruction.UniqueAssetId, instruction.Address, transfer.Address) {
+			continue
+		}
+
+		if instruction.AddressTag != "" && instruction.AddressTag != transfer.AddressTag {
+			continue
+		}
+
+		candidates = append(candidates, entry)
+		tagged = tagged || instruction.AddressTag != ""
+	}
+
+	if tagged {
+		candidates = slices.DeleteFunc(candidates, func(entry *ledger) bool { return entry.instruction.AddressTag == "" })
+	}
+
+	if len(candidates) == 0 {
+		return nil, &Unmatched{Transfer: transfer, Reason: ReasonNoInstruction}
+	}
+
+	invalid := candidates
+	candidates = slices.DeleteFunc(slices.Clone(candidates), func(entry *ledger) bool { return entry.invalid })
+
+	if len(candidates) == 0 {
+		return nil, &Unmatched{Transfer: transfer, Reason: ReasonInvalidRequest, Candidates: ledgerIds(invalid)}
+	}
+
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+
+	unpaid := slices.DeleteFunc(slices.Clone(candidates), (*ledger).settled)
+
+	if len(unpaid) == 1 {
+		return unpaid[0], nil
+	}
+
+	settling := slices.DeleteFunc(slices.Clone(unpaid), func(entry *ledger) bool {
+		status, _ := entry.statusWith(amount)
+		return status != StatusPaid
+	})
+
+	if len(settling) == 1 {
+		return settling[0], nil
+	}
+
+	ambiguous := unpaid
+
+	if len(ambiguous) == 0 {
+		ambiguous = candidates
+	}
+
+	return nil, &Unmatched{Transfer: transfer, Reason: ReasonAmbiguous, Candidates: ledgerIds(ambiguous)}
+}
+
+// ledgerIds returns the payment identifiers of the instructions.
+func ledgerIds(ledgers []*ledger) []string {
+	ids := []string{}
+
+	for _, entry := range ledgers {
+		ids = append(ids, entry.instruction.Id)
+	}
+
+	return ids
+}
+
+// apply records a transfer on the instruction.
+func (l *ledger) apply(transfer Transfer, amount decimal.Decimal) Match {
+	settled := l.settled()
+
+	l.received = l.received.Add(amount)
+	l.transferIds = append(l.transferIds, transfer.Id)
+
+	late := l.instruction.ExpiresAt > 0 && transfer.Timestamp > l.instruction.ExpiresAt
+	l.late = l.late || late
+
+	status, difference := l.status()
+
+	match := Match{
+		Transfer:      transfer,
+		InstructionId: l.instruction.Id,
+		Late:          late,
+		Received:      l.received.String(),
+		Difference:    difference.String(),
+	}
+
+	switch {
+	case settled:
+		match.Status = MatchDuplicate
+	case status == StatusPartiallyPaid:
+		match.Status = MatchUnderpaid
+	case status == StatusOverpaid:
+		match.Status = MatchOverpaid
+	default:
+		match.Status = MatchPaid
+	}
+
+	return match
+}
+
+// settled reports whether the instruction already received at least the requested amount.
+func (l *ledger) settled() bool {
+	status, _ := l.status()
+	return status == StatusPaid || status == StatusOverpaid
+}
+
+// status returns the payment status of the instruction and the difference between the amount
+// received and the requested amount.
+func (l *ledger) status() (InstructionStatus, decimal.Decimal) {
+	if l.invalid {
+		return StatusInvalid, decimal.Zero
+	}
+
+	if l.received.IsZero() {
+		return StatusUnpaid, decimal.Zero
+	}
+
+	return l.evaluate(l.received)
+}
+
+// statusWith returns the payment status the instruction would have after receiving amount.
+func (l *ledger) statusWith(amount decimal.Decimal) (InstructionStatus, decimal.Decimal) {
+	return l.evaluate(l.received.Add(amount))
+}
+
+// evaluate compares a received amount with the requested amount. Fixed amounts must be equal;
+// invalid fixed amounts are never paid. Open amounts must be within MinAmount and MaxAmount,
+// each bound being optional; invalid bounds are treated as absent.
+func (l *ledger) evaluate(received decimal.Decimal) (InstructionStatus, decimal.Decimal) {
+	instruction := l.instruction
+
+	if !instruction.IsOpen {
+		requested, err := decimal.NewFromString(instruction.Amount)
+
+		if err != nil {
+			return StatusInvalid, decimal.Zero
+		}
+
+		return compare(received, requested, requested)
+	}
+
+	minimum, errMin := decimal.NewFromString(instruction.MinAmount)
+	maximum, errMax := decimal.NewFromString(instruction.MaxAmount)
+
+	if errMin != nil {
+		minimum = decimal.Zero
+	}
+
+	if errMax != nil {
+		maximum = received
+	}
+
+	return compare(received, minimum, decimal.Max(minimum, maximum))
+}
+
+// compare returns the status of a received amount against an accepted range.
+func compare(received decimal.Decimal, minimum decimal.Decimal, maximum decimal.Decimal) (InstructionStatus, decimal.Decimal) {
+	switch {
+	case received.LessThan(minimum):
+		return StatusPartiallyPaid, received.Sub(minimum)
+	case received.GreaterThan(maximum):
+		return StatusOverpaid, received.Sub(maximum)
+	}
+
+	return StatusPaid, decimal.Zero
+}
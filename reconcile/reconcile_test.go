@@ -0,0 +1,347 @@
+package reconcile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fluxisus/naspip-go/v3/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	usdt    = "ntrc20_tTR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	address = "TQmTzBAXYGhP9FdKWZZHLxG9MQK8kKYvN2"
+	expiry  = int64(1_700_000_000_000)
+)
+
+// fixed returns a fixed amount instruction.
+func fixed(id string, amount string) protocol.PaymentInstruction {
+	return protocol.PaymentInstruction{Id: id, Address: address, UniqueAssetId: usdt, Amount: amount, ExpiresAt: expiry}
+}
+
+// open returns an open amount instruction.
+func open(id string, minAmount string, maxAmount string) protocol.PaymentInstruction {
+	return protocol.PaymentInstruction{
+		Id: id, Address: address, UniqueAssetId: usdt, IsOpen: true, MinAmount: minAmount, MaxAmount: maxAmount, ExpiresAt: expiry,
+	}
+}
+
+// tagged returns a fixed amount instruction with an address tag.
+func tagged(id string, tag string, amount string) protocol.PaymentInstruction {
+	instruction := fixed(id, amount)
+	instruction.AddressTag = tag
+	return instruction
+}
+
+// transfer returns a transfer to the test address.
+func transfer(id string, amount string, timestamp int64) Transfer {
+	return Transfer{Id: id, Address: address, UniqueAssetId: usdt, Amount: amount, Timestamp: timestamp}
+}
+
+// withTag returns the transfer with an address tag.
+func withTag(t Transfer, tag string) Transfer {
+	t.AddressTag = tag
+	return t
+}
+
+type expectedMatch struct {
+	transferId    string
+	instructionId string
+	status        MatchStatus
+	late          bool
+	difference    string
+}
+
+type expectedUnmatched struct {
+	transferId string
+	reason     UnmatchedReason
+}
+
+func TestReconcile(t *testing.T) {
+	before := expiry - 1000
+	after := expiry + 1000
+
+	tests := []struct {
+		name         string
+		instructions []protocol.PaymentInstruction
+		transfers    []Transfer
+		matches      []expectedMatch
+		unmatched    []expectedUnmatched
+		statuses     map[string]InstructionStatus
+	}{
+		{
+			name:         "fixed amount paid",
+			instructions: []protocol.PaymentInstruction{fixed("p1", "10.5")},
+			transfers:    []Transfer{transfer("t1", "10.50", before)},
+			matches:      []expectedMatch{{"t1", "p1", MatchPaid, false, "0"}},
+			statuses:     map[string]InstructionStatus{"p1": StatusPaid},
+		},
+		{
+			name:         "fixed amount underpaid",
+			instructions: []protocol.PaymentInstruction{fixed("p1", "10")},
+			transfers:    []Transfer{transfer("t1", "7", before)},
+			matches:      []expectedMatch{{"t1", "p1", MatchUnderpaid, false, "-3"}},
+			statuses:     map[string]InstructionStatus{"p1": StatusPartiallyPaid},
+		},
+		{
+			name:         "fixed amount overpaid",
+			instructions: []protocol.PaymentInstruction{fixed("p1", "10")},
+			transfers:    []Transfer{transfer("t1", "12.25", before)},
+			matches:      []expectedMatch{{"t1", "p1", MatchOverpaid, false, "2.25"}},
+			statuses:     map[string]InstructionStatus{"p1": StatusOverpaid},
+		},
+		{
+			name:         "partial payments accumulate in timestamp order",
+			instructions: []protocol.PaymentInstruction{fixed("p1", "10")},
+			transfers:    []Transfer{transfer("t2", "6", before), transfer("t1", "4", before-500)},
+			matches: []expectedMatch{
+				{"t1", "p1", MatchUnderpaid, false, "-6"},
+				{"t2", "p1", MatchPaid, false, "0"},
+			},
+			statuses: map[string]InstructionStatus{"p1": StatusPaid},
+		},
+		{
+			name:         "payment after expiry is late",
+			instructions: []protocol.PaymentInstruction{fixed("p1", "10")},
+			transfers:    []Transfer{transfer("t1", "10", after)},
+			matches:      []expectedMatch{{"t1", "p1", MatchPaid, true, "0"}},
+			statuses:     map[string]InstructionStatus{"p1": StatusPaid},
+		},
+		{
+			name:         "open amount within range",
+			instructions: []protocol.PaymentInstruction{open("p1", "5", "20")},
+			transfers:    []Transfer{transfer("t1", "12", before)},
+			matches:      []expectedMatch{{"t1", "p1", MatchPaid, false, "0"}},
+			statuses:     map[string]InstructionStatus{"p1": StatusPaid},
+		},
+		{
+			name:         "open amount below minimum",
+			instructions: []protocol.PaymentInstruction{open("p1", "5", "20")},
+			transfers:    []Transfer{transfer("t1", "2", before)},
+			matches:      []expectedMatch{{"t1", "p1", MatchUnderpaid, false, "-3"}},
+			statuses:     map[string]InstructionStatus{"p1": StatusPartiallyPaid},
+		},
+		{
+			name:         "open amount above maximum",
+			instructions: []protocol.PaymentInstruction{open("p1", "5", "20")},
+			transfers:    []Transfer{transfer("t1", "25", before)},
+			matches:      []expectedMatch{{"t1", "p1", MatchOverpaid, false, "5"}},
+			statuses:     map[string]InstructionStatus{"p1": StatusOverpaid},
+		},
+		{
+			name:         "open amount without bounds",
+			instructions: []protocol.PaymentInstruction{open("p1", "", "")},
+			transfers:    []Transfer{transfer("t1", "0.0001", before)},
+			matches:      []expectedMatch{{"t1", "p1", MatchPaid, false, "0"}},
+			statuses:     map[string]InstructionStatus{"p1": StatusPaid},
+		},
+		{
+			name:         "address tag selects the instruction",
+			instructions: []protocol.PaymentInstruction{tagged("p1", "1001", "10"), tagged("p2", "1002", "10")},
+			transfers:    []Transfer{withTag(transfer("t1", "10", before), "1002")},
+			matches:      []expectedMatch{{"t1", "p2", MatchPaid, false, "0"}},
+			statuses:     map[string]InstructionStatus{"p1": StatusUnpaid, "p2": StatusPaid},
+		},
+		{
+			name:         "tagged instruction is preferred over untagged",
+			instructions: []protocol.PaymentInstruction{fixed("p1", "10"), tagged("p2", "1002", "10")},
+			transfers:    []Transfer{withTag(transfer("t1", "10", before), "1002")},
+			matches:      []expectedMatch{{"t1", "p2", MatchPaid, false, "0"}},
+			statuses:     map[string]InstructionStatus{"p1": StatusUnpaid, "p2": StatusPaid},
+		},
+		{
+			name:         "missing or wrong tag does not match",
+			instructions: []protocol.PaymentInstruction{tagged("p1", "1001", "10")},
+			transfers:    []Transfer{transfer("t1", "10", before), withTag(transfer("t2", "10", before), "9999")},
+			unmatched:    []expectedUnmatched{{"t1", ReasonNoInstruction}, {"t2", ReasonNoInstruction}},
+			statuses:     map[string]InstructionStatus{"p1": StatusUnpaid},
+		},
+		{
+			name:         "amount disambiguates instructions on the same address",
+			instructions: []protocol.PaymentInstruction{fixed("p1", "10"), fixed("p2", "15")},
+			transfers:    []Transfer{transfer("t1", "15", before)},
+			matches:      []expectedMatch{{"t1", "p2", MatchPaid, false, "0"}},
+			statuses:     map[string]InstructionStatus{"p1": StatusUnpaid, "p2": StatusPaid},
+		},
+		{
+			name:         "paid instruction is skipped for the next transfer",
+			instructions: []protocol.PaymentInstruction{fixed("p1", "10"), fixed("p2", "20")},
+			transfers:    []Transfer{transfer("t1", "10", before), transfer("t2", "10", before+1)},
+			matches: []expectedMatch{
+				{"t1", "p1", MatchPaid, false, "0"},
+				{"t2", "p2", MatchUnderpaid, false, "-10"},
+			},
+			statuses: map[string]InstructionStatus{"p1": StatusPaid, "p2": StatusPartiallyPaid},
+		},
+		{
+			name:         "identical instructions are ambiguous",
+			instructions: []protocol.PaymentInstruction{fixed("p1", "10"), fixed("p2", "10")},
+			transfers:    []Transfer{transfer("t1", "10", before), transfer("t2", "10", before+1)},
+			unmatched:    []expectedUnmatched{{"t1", ReasonAmbiguous}, {"t2", ReasonAmbiguous}},
+			statuses:     map[string]InstructionStatus{"p1": StatusUnpaid, "p2": StatusUnpaid},
+		},
+		{
+			name:         "remaining unpaid instruction receives a partial payment",
+			instructions: []protocol.PaymentInstruction{fixed("p1", "10"), fixed("p2", "20")},
+			transfers:    []Transfer{transfer("t1", "20", before), transfer("t2", "3", before+1)},
+			matches: []expectedMatch{
+				{"t1", "p2", MatchPaid, false, "0"},
+				{"t2", "p1", MatchUnderpaid, false, "-7"},
+			},
+			statuses: map[string]InstructionStatus{"p1": StatusPartiallyPaid, "p2": StatusPaid},
+		},
+		{
+			name:         "payment to a paid instruction is a duplicate",
+			instructions: []protocol.PaymentInstruction{fixed("p1", "10")},
+			transfers:    []Transfer{transfer("t1", "10", before), transfer("t2", "10", after)},
+			matches: []expectedMatch{
+				{"t1", "p1", MatchPaid, false, "0"},
+				{"t2", "p1", MatchDuplicate, true, "10"},
+			},
+			statuses: map[string]InstructionStatus{"p1": StatusOverpaid},
+		},
+		{
+			name:         "repeated transfer is reported once",
+			instructions: []protocol.PaymentInstruction{fixed("p1", "10")},
+			transfers:    []Transfer{transfer("t1", "10", before), transfer("t1", "10", before)},
+			matches:      []expectedMatch{{"t1", "p1", MatchPaid, false, "0"}},
+			unmatched:    []expectedUnmatched{{"t1", ReasonDuplicateTransfer}},
+			statuses:     map[string]InstructionStatus{"p1": StatusPaid},
+		},
+		{
+			name:         "other asset or address does not match",
+			instructions: []protocol.PaymentInstruction{fixed("p1", "10")},
+			transfers: []Transfer{
+				{Id: "t1", Address: address, UniqueAssetId: "ntrc20_tOTHER", Amount: "10", Timestamp: before},
+				{Id: "t2", Address: "TOther", UniqueAssetId: usdt, Amount: "10", Timestamp: before},
+			},
+			unmatched: []expectedUnmatched{{"t1", ReasonNoInstruction}, {"t2", ReasonNoInstruction}},
+			statuses:  map[string]InstructionStatus{"p1": StatusUnpaid},
+		},
+		{
+			name:         "checksum and lowercase EVM addresses match",
+			instructions: []protocol.PaymentInstruction{{Id: "p1", Address: "0x52908400098527886E0F7030069857D2E4169EE7", UniqueAssetId: "npolygon_t0xc2132D05D31c914a87C6611C10748AEb04B58e8F", Amount: "10", ExpiresAt: expiry}},
+			transfers: []Transfer{
+				{Id: "t1", Address: "0x52908400098527886e0f7030069857d2e4169ee7", UniqueAssetId: "npolygon_t0xc2132D05D31c914a87C6611C10748AEb04B58e8F", Amount: "10", Timestamp: before},
+			},
+			matches:  []expectedMatch{{"t1", "p1", MatchPaid, false, "0"}},
+			statuses: map[string]InstructionStatus{"p1": StatusPaid},
+		},
+		{
+			name:         "other addresses are case sensitive",
+			instructions: []protocol.PaymentInstruction{fixed("p1", "10")},
+			transfers:    []Transfer{{Id: "t1", Address: "tqmtzbaxyghp9fdkwzzhlxg9mqk8kkyvn2", UniqueAssetId: usdt, Amount: "10", Timestamp: before}},
+			unmatched:    []expectedUnmatched{{"t1", ReasonNoInstruction}},
+			statuses:     map[string]InstructionStatus{"p1": StatusUnpaid},
+		},
+		{
+			name:         "invalid requested amount is never paid",
+			instructions: []protocol.PaymentInstruction{fixed("p1", "ten")},
+			transfers:    []Transfer{transfer("t1", "10", before)},
+			unmatched:    []expectedUnmatched{{"t1", ReasonInvalidRequest}},
+			statuses:     map[string]InstructionStatus{"p1": StatusInvalid},
+		},
+		{
+			name:         "valid instruction is preferred to an invalid one",
+			instructions: []protocol.PaymentInstruction{fixed("p1", "ten"), fixed("p2", "10")},
+			transfers:    []Transfer{transfer("t1", "10", before)},
+			matches:      []expectedMatch{{"t1", "p2", MatchPaid, false, "0"}},
+			statuses:     map[string]InstructionStatus{"p1": StatusInvalid, "p2": StatusPaid},
+		},
+		{
+			name: "instruction without expiry is never late",
+			instructions: []protocol.PaymentInstruction{
+				{Id: "p1", Address: address, UniqueAssetId: usdt, Amount: "10"},
+			},
+			transfers: []Transfer{transfer("t1", "10", after)},
+			matches:   []expectedMatch{{"t1", "p1", MatchPaid, false, "0"}},
+			statuses:  map[string]InstructionStatus{"p1": StatusPaid},
+		},
+		{
+			name:         "invalid amounts are rejected",
+			instructions: []protocol.PaymentInstruction{fixed("p1", "10")},
+			transfers:    []Transfer{transfer("t1", "ten", before), transfer("t2", "0", before), transfer("t3", "-10", before)},
+			unmatched: []expectedUnmatched{
+				{"t1", ReasonInvalidAmount}, {"t2", ReasonInvalidAmount}, {"t3", ReasonInvalidAmount},
+			},
+			statuses: map[string]InstructionStatus{"p1": StatusUnpaid},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			report := Reconcile(test.instructions, test.transfers)
+
+			require.Len(t, report.Matches, len(test.matches))
+
+			for index, expected := range test.matches {
+				match := report.Matches[index]
+
+				assert.Equal(t, expected.transferId, match.Transfer.Id)
+				assert.Equal(t, expected.instructionId, match.InstructionId)
+				assert.Equal(t, expected.status, match.Status)
+				assert.Equal(t, expected.late, match.Late)
+				assert.Equal(t, expected.difference, match.Difference)
+			}
+
+			require.Len(t, report.Unmatched, len(test.unmatched))
+
+			for index, expected := range test.unmatched {
+				assert.Equal(t, expected.transferId, report.Unmatched[index].Transfer.Id)
+				assert.Equal(t, expected.reason, report.Unmatched[index].Reason)
+			}
+
+			require.Len(t, report.Instructions, len(test.instructions))
+
+			for id, status := range test.statuses {
+				summary, ok := report.Summary(id)
+
+				require.True(t, ok)
+				assert.Equal(t, status, summary.Status, id)
+			}
+		})
+	}
+}
+
+func TestReconcileAmbiguousCandidates(t *testing.T) {
+	instructions := []protocol.PaymentInstruction{open("p1", "1", "100"), open("p2", "1", "100")}
+
+	report := Reconcile(instructions, []Transfer{transfer("t1", "50", expiry)})
+
+	require.Len(t, report.Unmatched, 1)
+	assert.Equal(t, ReasonAmbiguous, report.Unmatched[0].Reason)
+	assert.Equal(t, []string{"p1", "p2"}, report.Unmatched[0].Candidates)
+}
+
+func TestReconcileSummary(t *testing.T) {
+	instructions := []protocol.PaymentInstruction{fixed("p1", "10")}
+	transfers := []Transfer{transfer("t1", "4", expiry-10), transfer("t2", "6", expiry+10)}
+
+	report := Reconcile(instructions, transfers)
+
+	summary, ok := report.Summary("p1")
+
+	require.True(t, ok)
+	assert.Equal(t, StatusPaid, summary.Status)
+	assert.Equal(t, "10", summary.Received)
+	assert.True(t, summary.Late)
+	assert.Equal(t, []string{"t1", "t2"}, summary.TransferIds)
+
+	_, ok = report.Summary("unknown")
+	assert.False(t, ok)
+}
+
+func TestReconcileWithAddressComparer(t *testing.T) {
+	instructions := []protocol.PaymentInstruction{fixed("p1", "10")}
+	transfers := []Transfer{{Id: "t1", Address: "tqmtzbaxyghp9fdkwzzhlxg9mqk8kkyvn2", UniqueAssetId: usdt, Amount: "10", Timestamp: expiry}}
+
+	report := ReconcileWith(instructions, transfers, Options{
+		AddressEqual: func(uniqueAssetId string, instructionAddress string, transferAddress string) bool {
+			return uniqueAssetId == usdt && strings.EqualFold(instructionAddress, transferAddress)
+		},
+	})
+
+	require.Len(t, report.Matches, 1)
+	assert.Equal(t, "p1", report.Matches[0].InstructionId)
+}
@@ -0,0 +1,233 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/fluxisus/naspip-go/v3/encoding/protobuf"
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/protocol"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// apiKeyMetadata is the gRPC metadata entry carrying the API key.
+const apiKeyMetadata = "x-api-key"
+
+// errorDomain is the domain of the errdetails.ErrorInfo attached to gRPC errors.
+const errorDomain = "naspip"
+
+// grpcCodes maps the HTTP status of the service errors to gRPC status codes.
+var grpcCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusUnprocessableEntity: codes.FailedPrecondition,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+}
+
+// GRPCConfig configures the gRPC service.
+type GRPCConfig struct {
+	Issuing      IssuingConfig      // Configuration of CreateInstruction and CreateUrlPayload, QRLevel is not used
+	Verification VerificationConfig // Configuration of Read and ResolveKey, RateLimiter is not used
+}
+
+// grpcService is the reference implementation of protobuf.NaspipServiceServer.
+type grpcService struct {
+	protobuf.UnimplementedNaspipServiceServer
+	config GRPCConfig
+}
+
+// NewGRPCService creates the reference implementation of the NaspipService gRPC service,
+// backed by the same issuing and verification logic as the HTTP handlers. Register it with
+// protobuf.RegisterNaspipServiceServer.
+//
+// Creation requests are authenticated with the x-api-key metadata entry. Errors carry the gRPC
+// status code matching the failure and an errdetails.ErrorInfo whose reason is the error code
+// of the HTTP API (see the Error constants). Rate limiting is left to server interceptors.
+//
+// Parameters:
+//   - config: The service configuration
+//
+// Returns:
+//   - The gRPC service implementation
+func NewGRPCService(config GRPCConfig) protobuf.NaspipServiceServer {
+	return &grpcService{config: config}
+}
+
+// CreateInstruction creates a payment instruction token.
+func (s *grpcService) CreateInstruction(ctx context.Context, request *protobuf.CreateInstructionRequest) (*protobuf.CreateTokenResponse, error) {
+	var payload protocol.InstructionPayload
+
+	if err := protoToGo(request.GetPayload(), &payload); err != nil {
+		return nil, grpcError(&serviceError{http.StatusBadRequest, ErrorInvalidRequest, "invalid payload"})
+	}
+
+	return issueToken(ctx, s.config.Issuing, request.GetOptions(), payload, protocol.PaymentInstructionsBuilder.CreatePaymentInstruction)
+}
+
+// CreateUrlPayload creates a payment URL token.
+func (s *grpcService) CreateUrlPayload(ctx context.Context, request *protobuf.CreateUrlPayloadRequest) (*protobuf.CreateTokenResponse, error) {
+	var payload protocol.UrlPayload
+
+	if err := protoToGo(request.GetPayload(), &payload); err != nil {
+		return nil, grpcError(&serviceError{http.StatusBadRequest, ErrorInvalidRequest, "invalid payload"})
+	}
+
+	return issueToken(ctx, s.config.Issuing, request.GetOptions(), payload, protocol.PaymentInstructionsBuilder.CreateUrlPayload)
+}
+
+// Read verifies a token and returns its claims and typed payload.
+func (s *grpcService) Read(ctx context.Context, request *protobuf.ReadRequest) (*protobuf.ReadResponse, error) {
+	data, failure := readToken(s.config.Verification, request.GetToken())
+
+	if failure != nil {
+		return nil, grpcError(failure)
+	}
+
+	tokenData, err := tokenDataToProto(data.Payload)
+
+	if err != nil {
+		return nil, grpcError(&serviceError{http.StatusUnprocessableEntity, ErrorUnsupportedPayload, err.Error()})
+	}
+
+	return &protobuf.ReadResponse{Data: tokenData}, nil
+}
+
+// ResolveKey returns the public key of a key issuer and key ID.
+func (s *grpcService) ResolveKey(ctx context.Context, request *protobuf.ResolveKeyRequest) (*protobuf.ResolveKeyResponse, error) {
+	publicKey, failure := resolveKey(s.config.Verification, request.GetKeyIssuer(), request.GetKeyId())
+
+	if failure != nil {
+		return nil, grpcError(failure)
+	}
+
+	return &protobuf.ResolveKeyResponse{PublicKey: publicKey}, nil
+}
+
+// issueToken authenticates a creation request and signs its payload.
+func issueToken[T any](ctx context.Context, config IssuingConfig, options *protobuf.TokenOptions, payload T, create func(protocol.PaymentInstructionsBuilder, T, string, protocol.QrCriptoCreateOptions) (string, error)) (*protobuf.CreateTokenResponse, error) {
+	var apiKey string
+
+	if values := metadata.ValueFromIncomingContext(ctx, apiKeyMetadata); len(values) > 0 {
+		apiKey = values[0]
+	}
+
+	policy, ok := config.APIKeys[apiKey]
+
+	if !ok {
+		return nil, grpcError(&serviceError{http.StatusUnauthorized, ErrorUnauthorized, "missing or unknown API key"})
+	}
+
+	token, failure := sign(config, policy, options.GetKeyIssuer(), options.GetKeyId(), options.GetExpiresIn(), payload, create)
+
+	if failure != nil {
+		return nil, grpcError(failure)
+	}
+
+	return &protobuf.CreateTokenResponse{Token: token}, nil
+}
+
+// grpcError converts a service error into a gRPC status error.
+func grpcError(failure *serviceError) error {
+	code, ok := grpcCodes[failure.status]
+
+	if !ok {
+		code = codes.Internal
+	}
+
+	result := status.New(code, failure.message)
+
+	if detailed, err := result.WithDetails(&errdetails.ErrorInfo{Reason: failure.code, Domain: errorDomain}); err == nil {
+		result = detailed
+	}
+
+	return result.Err()
+}
+
+// protoToGo converts a protobuf message into the equivalent protocol struct. The protobuf JSON
+// encoding writes int64 fields as strings, so expires_at timestamps are converted back to numbers.
+func protoToGo(message proto.Message, target any) error {
+	data := map[string]interface{}{}
+
+	if err := protobuf.ConvertProtoToGo(message, &data); err != nil {
+		return err
+	}
+
+	numericTimestamps(data)
+
+	return protocol.PayloadOf(data, target)
+}
+
+// numericTimestamps replaces the expires_at strings of decoded JSON values with numbers.
+func numericTimestamps(value interface{}) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if timestamp, ok := field.(string); ok && key == "expires_at" {
+				value[key] = json.Number(timestamp)
+				continue
+			}
+
+			numericTimestamps(field)
+		}
+	case []interface{}:
+		for _, item := range value {
+			numericTimestamps(item)
+		}
+	}
+}
+
+// tokenDataToProto converts verified token data into its protobuf message.
+//
+// Parameters:
+//   - data: The verified token data
+//
+// Returns:
+//   - The protobuf token data, with the payload in the data oneof
+//   - An error if the data is not a payment token payload
+func tokenDataToProto(data paseto.PasetoTokenData) (*protobuf.PasetoTokenData, error) {
+	result := &protobuf.PasetoTokenData{
+		Iss: data.Iss,
+		Sub: data.Sub,
+		Aud: data.Aud,
+		Exp: data.Exp,
+		Nbf: data.Nbf,
+		Iat: data.Iat,
+		Jti: data.Jti,
+		Kid: data.Kid,
+		Kep: data.Kep,
+		Kis: data.Kis,
+	}
+
+	_, payload, err := typedPayload(data.Data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch payload := payload.(type) {
+	case *protocol.InstructionPayload:
+		message := &protobuf.InstructionPayload{}
+		err = protobuf.ConvertGoToProto(payload, message)
+		result.Data = &protobuf.PasetoTokenData_InstructionPayload{InstructionPayload: message}
+	case *protocol.MultiAssetPayload:
+		message := &protobuf.MultiAssetPayload{}
+		err = protobuf.ConvertGoToProto(payload, message)
+		result.Data = &protobuf.PasetoTokenData_MultiAssetPayload{MultiAssetPayload: message}
+	case *protocol.UrlPayload:
+		message := &protobuf.UrlPayload{}
+		err = protobuf.ConvertGoToProto(payload, message)
+		result.Data = &protobuf.PasetoTokenData_UrlPayload{UrlPayload: message}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/fluxisus/naspip-go/v3/encoding/protobuf"
+	"github.com/fluxisus/naspip-go/v3/protocol"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// grpcClient starts the gRPC service on an in-process listener and returns a client
+func grpcClient(t *testing.T, config GRPCConfig) protobuf.NaspipServiceClient {
+	listener := bufconn.Listen(1 << 20)
+
+	server := grpc.NewServer()
+	protobuf.RegisterNaspipServiceServer(server, NewGRPCService(config))
+
+	go func() { _ = server.Serve(listener) }()
+
+	connection, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+
+	if err != nil {
+		t.Fatalf("grpcClient FAIL --> %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = connection.Close()
+		server.Stop()
+	})
+
+	return protobuf.NewNaspipServiceClient(connection)
+}
+
+// grpcConfig returns a gRPC configuration issuing and verifying with the test key
+func grpcConfig() GRPCConfig {
+	return GRPCConfig{
+		Issuing: issuingConfig(),
+		Verification: VerificationConfig{
+			Builder:  builder,
+			Resolver: protocol.StaticKeyResolver{"merchant.com": {"key-1": keys["publicKey"]}},
+		},
+	}
+}
+
+// errorReason returns the gRPC status code and error code of an error
+func errorReason(err error) (codes.Code, string) {
+	result := status.Convert(err)
+
+	for _, detail := range result.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return result.Code(), info.Reason
+		}
+	}
+
+	return result.Code(), ""
+}
+
+// Should create an instruction token and read it back over gRPC
+func TestGRPCCreateAndRead(t *testing.T) {
+	assert := assert.New(t)
+
+	client := grpcClient(t, grpcConfig())
+
+	payload := &protobuf.InstructionPayload{}
+	assert.Nil(protobuf.ConvertGoToProto(instruction, payload))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "merchant-api-key")
+
+	created, err := client.CreateInstruction(ctx, &protobuf.CreateInstructionRequest{
+		Options: &protobuf.TokenOptions{KeyId: "key-1"},
+		Payload: payload,
+	})
+
+	assert.Nil(err)
+
+	read, err := client.Read(context.Background(), &protobuf.ReadRequest{Token: created.Token})
+
+	assert.Nil(err)
+	assert.Equal("merchant.com", read.Data.Kis)
+	assert.Equal("key-1", read.Data.Kid)
+	assert.Equal(instruction.Payment.Id, read.Data.GetInstructionPayload().Payment.Id)
+	assert.Equal(instruction.Payment.ExpiresAt, read.Data.GetInstructionPayload().Payment.ExpiresAt)
+
+	urlPayload := &protobuf.UrlPayload{Url: "https://mystore.com/payments/123", PaymentOptions: []string{"ntrc20_tTR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"}}
+
+	created, err = client.CreateUrlPayload(ctx, &protobuf.CreateUrlPayloadRequest{
+		Options: &protobuf.TokenOptions{KeyId: "key-1"},
+		Payload: urlPayload,
+	})
+
+	assert.Nil(err)
+
+	read, err = client.Read(context.Background(), &protobuf.ReadRequest{Token: created.Token})
+
+	assert.Nil(err)
+	assert.Equal(urlPayload.Url, read.Data.GetUrlPayload().Url)
+}
+
+// Should resolve keys of allowed issuers
+func TestGRPCResolveKey(t *testing.T) {
+	assert := assert.New(t)
+
+	client := grpcClient(t, grpcConfig())
+
+	resolved, err := client.ResolveKey(context.Background(), &protobuf.ResolveKeyRequest{KeyIssuer: "merchant.com", KeyId: "key-1"})
+
+	assert.Nil(err)
+	assert.Equal(keys["publicKey"], resolved.PublicKey)
+
+	_, err = client.ResolveKey(context.Background(), &protobuf.ResolveKeyRequest{KeyIssuer: "merchant.com", KeyId: "key-2"})
+
+	code, reason := errorReason(err)
+	assert.Equal(codes.FailedPrecondition, code)
+	assert.Equal(ErrorUnknownKey, reason)
+}
+
+// Should report failures with gRPC status codes and error codes
+func TestGRPCErrors(t *testing.T) {
+	client := grpcClient(t, grpcConfig())
+
+	payload := &protobuf.InstructionPayload{}
+	_ = protobuf.ConvertGoToProto(instruction, payload)
+
+	authenticated := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "limited-api-key")
+
+	tests := []struct {
+		name   string
+		call   func() error
+		code   codes.Code
+		reason string
+	}{
+		{
+			name: "missing API key",
+			call: func() error {
+				_, err := client.CreateInstruction(context.Background(), &protobuf.CreateInstructionRequest{Options: &protobuf.TokenOptions{KeyId: "key-1"}, Payload: payload})
+				return err
+			},
+			code:   codes.Unauthenticated,
+			reason: ErrorUnauthorized,
+		},
+		{
+			name: "key not allowed",
+			call: func() error {
+				_, err := client.CreateInstruction(authenticated, &protobuf.CreateInstructionRequest{Options: &protobuf.TokenOptions{KeyId: "key-1"}, Payload: payload})
+				return err
+			},
+			code:   codes.PermissionDenied,
+			reason: ErrorForbidden,
+		},
+		{
+			name: "invalid token",
+			call: func() error {
+				_, err := client.Read(context.Background(), &protobuf.ReadRequest{Token: "qr-content"})
+				return err
+			},
+			code:   codes.InvalidArgument,
+			reason: ErrorInvalidToken,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			code, reason := errorReason(test.call())
+
+			assert.Equal(t, test.code, code)
+			assert.Equal(t, test.reason, reason)
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/fluxisus/naspip-go/v3/internal/httpapi"
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/protocol"
+	"github.com/fluxisus/naspip-go/v3/qrcode"
+)
+
+// pngScale is the size in pixels of each QR code module in PNG images.
+const pngScale = 8
+
+// defaultExpiresIn is the token lifetime used when the request does not set one.
+const defaultExpiresIn = "10m"
+
+// SigningKey is a key used by the issuing service to sign tokens.
+type SigningKey struct {
+	SecretKey     string               // Private key in raw or PASERK format, or a key reference understood by Handler
+	PublicKey     string               // Public key, bound to the tokens as implicit assertion
+	KeyExpiration string               // Key expiration date (RFC3339Mili format)
+	Handler       paseto.PasetoHandler // Optional handler signing with the key (e.g., backed by an HSM), the builder handler is used when nil
+}
+
+// Signer provides the keys used to sign tokens. Implementations can load keys from a
+// secret store, or return key references for a PasetoHandler backed by an HSM.
+type Signer interface {
+	// SigningKey returns the key of the given key issuer and key ID.
+	SigningKey(keyIssuer string, keyId string) (*SigningKey, error)
+}
+
+// StaticSigner is a Signer backed by a fixed set of keys, indexed by key issuer and key ID.
+type StaticSigner map[string]map[string]SigningKey
+
+// SigningKey returns the key of the given key issuer and key ID.
+func (s StaticSigner) SigningKey(keyIssuer string, keyId string) (*SigningKey, error) {
+	key, ok := s[keyIssuer][keyId]
+
+	if !ok {
+		return nil, errors.New("signing key not found")
+	}
+
+	return &key, nil
+}
+
+// APIKeyPolicy restricts the keys a client may sign with.
+type APIKeyPolicy struct {
+	KeyIssuer string   // Key issuer the client signs for
+	KeyIds    []string // Key IDs the client may use, empty for any key of the issuer
+}
+
+// allows reports whether the policy allows signing with the key.
+func (p APIKeyPolicy) allows(keyIssuer string, keyId string) bool {
+	return keyIssuer == p.KeyIssuer && (len(p.KeyIds) == 0 || slices.Contains(p.KeyIds, keyId))
+}
+
+// IssuingConfig configures the issuing service.
+type IssuingConfig struct {
+	Builder protocol.PaymentInstructionsBuilder // Builder used to validate and create tokens
+	Signer  Signer                              // Provider of the signing keys
+	APIKeys map[string]APIKeyPolicy             // Policies indexed by API key
+	QRLevel qrcode.Level                        // Error correction level of the QR code images
+}
+
+// IssueRequest is the body of the token creation endpoints.
+type IssueRequest[T any] struct {
+	KeyIssuer string   `json:"key_issuer,omitempty"` // Key issuer, defaults to the API key issuer
+	KeyId     string   `json:"key_id"`               // Key ID used to sign the token
+	ExpiresIn string   `json:"expires_in,omitempty"` // Token lifetime (e.g., "1h"), 10 minutes by default
+	Images    []string `json:"images,omitempty"`     // QR code images to return: "png" and/or "svg"
+	Payload   T        `json:"payload"`              // Token payload
+}
+
+// IssueResponse is the body of successful token creation responses.
+type IssueResponse struct {
+	Token string `json:"token"`         // NASPIP token
+	PNG   []byte `json:"png,omitempty"` // QR code PNG image (base64 encoded in JSON)
+	SVG   string `json:"svg,omitempty"` // QR code SVG document
+}
+
+// NewIssuingHandler creates the HTTP handler of the issuing service. It serves:
+//   - POST /v1/instructions: creates a payment instruction token from a protocol.InstructionPayload
+//   - POST /v1/url-payloads: creates a payment URL token from a protocol.UrlPayload
+//   - GET /openapi.yaml: the OpenAPI description of the API
+//
+// Requests are authenticated with the X-Api-Key header, and each API key may only sign with
+// the keys allowed by its APIKeyPolicy.
+//
+// Parameters:
+//   - config: The service configuration
+//
+// Returns:
+//   - The HTTP handler
+func NewIssuingHandler(config IssuingConfig) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /v1/instructions", func(w http.ResponseWriter, r *http.Request) {
+		issue(config, w, r, protocol.PaymentInstructionsBuilder.CreatePaymentInstruction)
+	})
+
+	mux.HandleFunc("POST /v1/url-payloads", func(w http.ResponseWriter, r *http.Request) {
+		issue(config, w, r, protocol.PaymentInstructionsBuilder.CreateUrlPayload)
+	})
+
+	mux.HandleFunc("GET /openapi.yaml", serveOpenAPI)
+
+	return mux
+}
+
+// issue handles a token creation request.
+//
+// Parameters:
+//   - config: The service configuration
+//   - w: The response writer
+//   - r: The request
+//   - create: The builder method creating the token from the payload
+func issue[T any](config IssuingConfig, w http.ResponseWriter, r *http.Request, create func(protocol.PaymentInstructionsBuilder, T, string, protocol.QrCriptoCreateOptions) (string, error)) {
+	policy, ok := config.APIKeys[r.Header.Get(apiKeyHeader)]
+
+	if !ok {
+		httpapi.WriteError(w, http.StatusUnauthorized, ErrorUnauthorized, "missing or unknown API key")
+		return
+	}
+
+	var request IssueRequest[T]
+
+	if err := decodeJSON(w, r, &request); err != nil {
+		httpapi.WriteError(w, http.StatusBadRequest, ErrorInvalidRequest, "invalid request body")
+		return
+	}
+
+	for _, image := range request.Images {
+		if image != "png" && image != "svg" {
+			httpapi.WriteError(w, http.StatusBadRequest, ErrorInvalidRequest, "unsupported image format")
+			return
+		}
+	}
+
+	token, failure := sign(config, policy, request.KeyIssuer, request.KeyId, request.ExpiresIn, request.Payload, create)
+
+	if failure != nil {
+		writeFailure(w, failure)
+		return
+	}
+
+	response := IssueResponse{Token: token}
+
+	if len(request.Images) > 0 {
+		if err := renderImages(&response, request.Images, config.QRLevel); err != nil {
+			httpapi.WriteError(w, http.StatusInternalServerError, ErrorImageGeneration, err.Error())
+			return
+		}
+	}
+
+	httpapi.WriteJSON(w, http.StatusCreated, response)
+}
+
+// sign creates a token with the key selected by the request, after checking the API key policy.
+//
+// Parameters:
+//   - config: The service configuration
+//   - policy: The policy of the request API key
+//   - keyIssuer: The requested key issuer, the policy issuer when empty
+//   - keyId: The requested key ID
+//   - expiresIn: The requested token lifetime, defaultExpiresIn when empty
+//   - payload: The token payload
+//   - create: The builder method creating the token from the payload
+//
+// Returns:
+//   - The NASPIP token
+//   - The failure if the token cannot be created
+func sign[T any](config IssuingConfig, policy APIKeyPolicy, keyIssuer string, keyId string, expiresIn string, payload T, create func(protocol.PaymentInstructionsBuilder, T, string, protocol.QrCriptoCreateOptions) (string, error)) (string, *serviceError) {
+	if keyIssuer == "" {
+		keyIssuer = policy.KeyIssuer
+	}
+
+	if expiresIn == "" {
+		expiresIn = defaultExpiresIn
+	}
+
+	if keyId == "" {
+		return "", &serviceError{http.StatusBadRequest, ErrorInvalidRequest, "key_id is required"}
+	}
+
+	if !policy.allows(keyIssuer, keyId) {
+		return "", &serviceError{http.StatusForbidden, ErrorForbidden, "API key may not sign with this key"}
+	}
+
+	key, err := config.Signer.SigningKey(keyIssuer, keyId)
+
+	if err != nil {
+		return "", &serviceError{http.StatusBadRequest, ErrorUnknownSigner, err.Error()}
+	}
+
+	builder := config.Builder
+
+	if key.Handler != nil {
+		builder.PasetoHandler = key.Handler
+	}
+
+	options := protocol.QrCriptoCreateOptions{
+		SignOptions: paseto.PasetoSignOptions{
+			KeyId:     keyId,
+			ExpiresIn: expiresIn,
+			Assertion: []byte(key.PublicKey),
+		},
+		KeyIssuer:     keyIssuer,
+		KeyExpiration: key.KeyExpiration,
+	}
+
+	token, err := create(builder, payload, key.SecretKey, options)
+
+	if err != nil {
+		return "", &serviceError{http.StatusUnprocessableEntity, ErrorInvalidPayload, err.Error()}
+	}
+
+	return token, nil
+}
+
+// renderImages adds the requested QR code images of the token to the response.
+func renderImages(response *IssueResponse, images []string, level qrcode.Level) error {
+	qr, err := qrcode.Encode(response.Token, level)
+
+	if err != nil {
+		return err
+	}
+
+	for _, image := range images {
+		switch image {
+		case "png":
+			response.PNG, err = qr.PNG(pngScale, qrcode.QuietZone)
+		case "svg":
+			response.SVG, err = qr.SVG(qrcode.QuietZone)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/protocol"
+	"github.com/fluxisus/naspip-go/v3/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var keys = map[string]string{
+	"publicKey": "k4.public.sGVse4eAyt6ycfmkKl3Az7RxB34nklDPgKbNLvxVwlk",
+	"secretKey": "k4.secret.y4-gze54dwfLR0eyxiJL2mRicZr6SX2-xIn6kgo999iwZWx7h4DK3rJx-aQqXcDPtHEHfieSUM-Aps0u_FXCWQ",
+}
+
+var builder = protocol.PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+// issuingHandler returns an issuing handler with one merchant key and two API keys
+func issuingHandler() http.Handler {
+	return NewIssuingHandler(issuingConfig())
+}
+
+// issuingConfig returns an issuing configuration with one merchant key and two API keys
+func issuingConfig() IssuingConfig {
+	return IssuingConfig{
+		Builder: builder,
+		Signer: StaticSigner{"merchant.com": {"key-1": {
+			SecretKey:     keys["secretKey"],
+			PublicKey:     keys["publicKey"],
+			KeyExpiration: time.Now().Add(1e9).Format(utils.RFC3339Mili),
+		}}},
+		APIKeys: map[string]APIKeyPolicy{
+			"merchant-api-key": {KeyIssuer: "merchant.com"},
+			"limited-api-key":  {KeyIssuer: "merchant.com", KeyIds: []string{"key-2"}},
+		},
+	}
+}
+
+// post sends a JSON request to the handler
+func post(handler http.Handler, path string, apiKey string, body any) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(body)
+
+	request := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(payload))
+	request.Header.Set("X-Api-Key", apiKey)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+var instruction = protocol.InstructionPayload{
+	Payment: protocol.PaymentInstruction{
+		Id:            "payment-id",
+		Address:       "crypto-address",
+		UniqueAssetId: "ntrc20_tTR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+		Amount:        "10",
+		ExpiresAt:     time.Now().Add(time.Hour).UnixMilli(),
+	},
+}
+
+// Should create instruction tokens with QR code images
+func TestIssueInstruction(t *testing.T) {
+	assert := assert.New(t)
+
+	recorder := post(issuingHandler(), "/v1/instructions", "merchant-api-key", IssueRequest[protocol.InstructionPayload]{
+		KeyId:   "key-1",
+		Images:  []string{"png", "svg"},
+		Payload: instruction,
+	})
+
+	assert.Equal(http.StatusCreated, recorder.Code)
+
+	var response IssueResponse
+
+	assert.Nil(json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.True(bytes.HasPrefix(response.PNG, []byte("\x89PNG")))
+	assert.Contains(response.SVG, "<svg")
+
+	data, err := builder.Read(response.Token, keys["publicKey"], protocol.QrCriptoReadOptions{KeyIssuer: "merchant.com"})
+
+	assert.Nil(err)
+	assert.Equal("payment-id", data.Payload.Data["payment"].(map[string]interface{})["id"])
+}
+
+// Should create URL payload tokens
+func TestIssueUrlPayload(t *testing.T) {
+	assert := assert.New(t)
+
+	recorder := post(issuingHandler(), "/v1/url-payloads", "merchant-api-key", IssueRequest[protocol.UrlPayload]{
+		KeyId:   "key-1",
+		Payload: protocol.UrlPayload{Url: "https://merchant.com/pay/123", PaymentOptions: []string{instruction.Payment.UniqueAssetId}},
+	})
+
+	assert.Equal(http.StatusCreated, recorder.Code)
+
+	var response IssueResponse
+
+	assert.Nil(json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Empty(response.PNG)
+
+	data, err := builder.Read(response.Token, keys["publicKey"], protocol.QrCriptoReadOptions{})
+
+	assert.Nil(err)
+	assert.Equal("https://merchant.com/pay/123", data.Payload.Data["url"])
+}
+
+// Should reject requests with structured error codes
+func TestIssueErrors(t *testing.T) {
+	var invalid = instruction
+	invalid.Payment.Amount = "-1"
+
+	tests := []struct {
+		name   string
+		apiKey string
+		body   any
+		status int
+		code   string
+	}{
+		{name: "missing api key", body: IssueRequest[protocol.InstructionPayload]{KeyId: "key-1", Payload: instruction}, status: http.StatusUnauthorized, code: ErrorUnauthorized},
+		{name: "other issuer", apiKey: "merchant-api-key", body: IssueRequest[protocol.InstructionPayload]{KeyIssuer: "other.com", KeyId: "key-1", Payload: instruction}, status: http.StatusForbidden, code: ErrorForbidden},
+		{name: "key not allowed", apiKey: "limited-api-key", body: IssueRequest[protocol.InstructionPayload]{KeyId: "key-1", Payload: instruction}, status: http.StatusForbidden, code: ErrorForbidden},
+		{name: "unknown key", apiKey: "merchant-api-key", body: IssueRequest[protocol.InstructionPayload]{KeyId: "key-3", Payload: instruction}, status: http.StatusBadRequest, code: ErrorUnknownSigner},
+		{name: "missing key id", apiKey: "merchant-api-key", body: IssueRequest[protocol.InstructionPayload]{Payload: instruction}, status: http.StatusBadRequest, code: ErrorInvalidRequest},
+		{name: "unknown field", apiKey: "merchant-api-key", body: map[string]any{"key_id": "key-1", "signature": "x"}, status: http.StatusBadRequest, code: ErrorInvalidRequest},
+		{name: "unsupported image", apiKey: "merchant-api-key", body: IssueRequest[protocol.InstructionPayload]{KeyId: "key-1", Images: []string{"gif"}, Payload: instruction}, status: http.StatusBadRequest, code: ErrorInvalidRequest},
+		{name: "invalid payload", apiKey: "merchant-api-key", body: IssueRequest[protocol.InstructionPayload]{KeyId: "key-1", Payload: invalid}, status: http.StatusUnprocessableEntity, code: ErrorInvalidPayload},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := post(issuingHandler(), "/v1/instructions", test.apiKey, test.body)
+
+			var response ErrorResponse
+
+			assert.Equal(t, test.status, recorder.Code)
+			assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.Equal(t, test.code, response.Error.Code)
+		})
+	}
+}
+
+// Should serve the OpenAPI description
+func TestOpenAPI(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	issuingHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/openapi.yaml", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.True(t, strings.HasPrefix(recorder.Body.String(), "openapi: 3"))
+}
@@ -0,0 +1,79 @@
+// Package server exposes the NASPIP protocol over HTTP, so services that do not link this
+// library can create NASPIP tokens, and thin clients that cannot verify signatures can have
+// scanned tokens verified by a backend. Handlers are plain http.Handler values that can be mounted
+// on any router; the API is described by the OpenAPI document served at /openapi.yaml.
+package server
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+
+	"github.com/fluxisus/naspip-go/v3/internal/httpapi"
+)
+
+// maxRequestSize is the maximum size in bytes of a request body.
+const maxRequestSize = 64 << 10
+
+// apiKeyHeader is the request header carrying the API key.
+const apiKeyHeader = "X-Api-Key"
+
+//go:embed openapi.yaml
+var openAPI []byte
+
+// Error codes returned in ErrorResponse.
+const (
+	ErrorUnauthorized    = "unauthorized"        // Missing or unknown API key
+	ErrorForbidden       = "forbidden"           // The API key may not use the requested key
+	ErrorInvalidRequest  = "invalid_request"     // The request body is not valid JSON or misses fields
+	ErrorInvalidPayload  = "invalid_payload"     // The payload was rejected by the protocol validation
+	ErrorUnknownSigner   = "unknown_signing_key" // The signer has no key for the key issuer and key ID
+	ErrorImageGeneration = "image_generation"    // The QR code image could not be generated
+
+	ErrorRateLimited        = "rate_limited"        // The rate limiter rejected the request
+	ErrorInvalidToken       = "invalid_token"       // The token is not a NASPIP token
+	ErrorIssuerNotAllowed   = "issuer_not_allowed"  // The key issuer is not in the allowlist
+	ErrorUnknownKey         = "unknown_key"         // The resolver has no public key for the token key
+	ErrorInvalidSignature   = "invalid_signature"   // The token signature does not match the key
+	ErrorTokenExpired       = "token_expired"       // The token is expired
+	ErrorTokenNotActive     = "token_not_active"    // The token is not valid yet
+	ErrorMaxAgeExceeded     = "max_age_exceeded"    // The token is older than the allowed maximum age
+	ErrorAudienceMismatch   = "audience_mismatch"   // The token is not intended for the configured audience
+	ErrorKeyExpired         = "key_expired"         // The signing key is expired
+	ErrorKeyRevoked         = "key_revoked"         // The signing key was revoked
+	ErrorUnsupportedPayload = "unsupported_payload" // The token payload is not a payment token
+	ErrorVerificationFailed = "verification_failed" // The token failed another verification check
+)
+
+// ErrorResponse is the body of error responses.
+type ErrorResponse = httpapi.ErrorResponse
+
+// ErrorDetail describes an error with a stable code and a human readable message.
+type ErrorDetail = httpapi.ErrorDetail
+
+// serviceError is a failure of the issuing or verification logic, shared by the HTTP and gRPC
+// transports.
+type serviceError struct {
+	status  int    // HTTP status code
+	code    string // Error code, see the Error constants
+	message string // Human readable description
+}
+
+// writeFailure writes the ErrorResponse of a service error.
+func writeFailure(w http.ResponseWriter, failure *serviceError) {
+	httpapi.WriteError(w, failure.status, failure.code, failure.message)
+}
+
+// decodeJSON decodes a JSON request body, rejecting unknown fields and oversized bodies.
+func decodeJSON(w http.ResponseWriter, r *http.Request, target any) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	decoder.DisallowUnknownFields()
+
+	return decoder.Decode(target)
+}
+
+// serveOpenAPI serves the OpenAPI description of the API.
+func serveOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = w.Write(openAPI)
+}
@@ -0,0 +1,222 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/fluxisus/naspip-go/v3/internal/httpapi"
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/protocol"
+)
+
+// Payload types returned in VerifyResponse.
+const (
+	PayloadInstruction = "instruction" // protocol.InstructionPayload
+	PayloadUrl         = "url"         // protocol.UrlPayload
+	PayloadMultiAsset  = "multi_asset" // protocol.MultiAssetPayload
+)
+
+// RateLimiter decides whether a request may be served, for example by counting requests
+// per client address. Rejected requests receive a 429 response.
+type RateLimiter interface {
+	// Allow reports whether the request may be served.
+	Allow(r *http.Request) bool
+}
+
+// RateLimiterFunc adapts a function to the RateLimiter interface.
+type RateLimiterFunc func(r *http.Request) bool
+
+// Allow calls f(r).
+func (f RateLimiterFunc) Allow(r *http.Request) bool {
+	return f(r)
+}
+
+// VerificationConfig configures the verification service.
+type VerificationConfig struct {
+	Builder           protocol.PaymentInstructionsBuilder // Builder used to read tokens
+	Resolver          protocol.KeyResolver                // Resolver of the public keys of the token key issuers
+	KeyIssuers        []string                            // Allowed key issuers, empty to allow any issuer known by Resolver
+	Audience          string                              // Required token audience, empty to accept any
+	MaxTokenAge       string                              // Maximum token age (e.g., "1h"), empty for no limit
+	RevocationChecker protocol.RevocationChecker          // Optional lookup of revoked keys
+	RateLimiter       RateLimiter                         // Optional rate limiter
+}
+
+// VerifyRequest is the body of the verification endpoint.
+type VerifyRequest struct {
+	Token string `json:"token"` // NASPIP token, as scanned from the QR code
+}
+
+// VerifyResponse is the body of successful verification responses.
+type VerifyResponse struct {
+	KeyIssuer string `json:"key_issuer"` // Issuer of the key that signed the token
+	KeyId     string `json:"key_id"`     // ID of the key that signed the token
+	IssuedAt  string `json:"iat"`        // Time when the token was issued (RFC3339Mili format)
+	ExpiresAt string `json:"exp"`        // Token expiration time (RFC3339Mili format)
+	Type      string `json:"type"`       // Payload type, see the Payload constants
+	Payload   any    `json:"payload"`    // Typed payload
+}
+
+// verificationErrors maps the verification errors to their status and error code.
+var verificationErrors = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{paseto.ErrInvalidSignature, http.StatusUnauthorized, ErrorInvalidSignature},
+	{paseto.ErrTokenExpired, http.StatusUnprocessableEntity, ErrorTokenExpired},
+	{paseto.ErrTokenNotActive, http.StatusUnprocessableEntity, ErrorTokenNotActive},
+	{paseto.ErrMaxTokenAge, http.StatusUnprocessableEntity, ErrorMaxAgeExceeded},
+	{paseto.ErrAudienceMismatch, http.StatusUnprocessableEntity, ErrorAudienceMismatch},
+	{protocol.ErrKeyExpired, http.StatusUnprocessableEntity, ErrorKeyExpired},
+	{protocol.ErrKeyRevoked, http.StatusUnprocessableEntity, ErrorKeyRevoked},
+}
+
+// NewVerificationHandler creates the HTTP handler of the verification service. It serves:
+//   - POST /v1/read: verifies a NASPIP token and returns its typed payload
+//   - GET /openapi.yaml: the OpenAPI description of the API
+//
+// The public key is resolved from the key issuer and key ID of the token prefix, and the token
+// is read with the configured policies. Failures are reported with a structured error code.
+//
+// Parameters:
+//   - config: The service configuration
+//
+// Returns:
+//   - The HTTP handler
+func NewVerificationHandler(config VerificationConfig) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /v1/read", func(w http.ResponseWriter, r *http.Request) {
+		verify(config, w, r)
+	})
+
+	mux.HandleFunc("GET /openapi.yaml", serveOpenAPI)
+
+	return mux
+}
+
+// verify handles a verification request.
+func verify(config VerificationConfig, w http.ResponseWriter, r *http.Request) {
+	if config.RateLimiter != nil && !config.RateLimiter.Allow(r) {
+		httpapi.WriteError(w, http.StatusTooManyRequests, ErrorRateLimited, "too many requests")
+		return
+	}
+
+	var request VerifyRequest
+
+	if err := decodeJSON(w, r, &request); err != nil || request.Token == "" {
+		httpapi.WriteError(w, http.StatusBadRequest, ErrorInvalidRequest, "invalid request body")
+		return
+	}
+
+	data, failure := readToken(config, request.Token)
+
+	if failure != nil {
+		writeFailure(w, failure)
+		return
+	}
+
+	payloadType, payload, err := typedPayload(data.Payload.Data)
+
+	if err != nil {
+		httpapi.WriteError(w, http.StatusUnprocessableEntity, ErrorUnsupportedPayload, err.Error())
+		return
+	}
+
+	httpapi.WriteJSON(w, http.StatusOK, VerifyResponse{
+		KeyIssuer: data.Payload.Kis,
+		KeyId:     data.Payload.Kid,
+		IssuedAt:  data.Payload.Iat,
+		ExpiresAt: data.Payload.Exp,
+		Type:      payloadType,
+		Payload:   payload,
+	})
+}
+
+// readToken verifies a token with the configured policies.
+//
+// Parameters:
+//   - config: The service configuration
+//   - token: The NASPIP token
+//
+// Returns:
+//   - The verified token content
+//   - The failure if the token is rejected
+func readToken(config VerificationConfig, token string) (*paseto.PasetoCompleteResult, *serviceError) {
+	decoded, err := config.Builder.Decode(token)
+
+	if err != nil {
+		return nil, &serviceError{http.StatusBadRequest, ErrorInvalidToken, err.Error()}
+	}
+
+	publicKey, failure := resolveKey(config, decoded.KeyIssuer, decoded.KeyId)
+
+	if failure != nil {
+		return nil, failure
+	}
+
+	data, err := config.Builder.Read(token, publicKey, protocol.QrCriptoReadOptions{
+		VerifyOptions:     paseto.PasetoVerifyOptions{Audience: config.Audience, MaxTokenAge: config.MaxTokenAge},
+		RevocationChecker: config.RevocationChecker,
+	})
+
+	if err != nil {
+		for _, mapped := range verificationErrors {
+			if errors.Is(err, mapped.err) {
+				return nil, &serviceError{mapped.status, mapped.code, err.Error()}
+			}
+		}
+
+		return nil, &serviceError{http.StatusUnprocessableEntity, ErrorVerificationFailed, err.Error()}
+	}
+
+	return data, nil
+}
+
+// resolveKey returns the public key of an allowed key issuer.
+func resolveKey(config VerificationConfig, keyIssuer string, keyId string) (string, *serviceError) {
+	if len(config.KeyIssuers) > 0 && !slices.Contains(config.KeyIssuers, keyIssuer) {
+		return "", &serviceError{http.StatusForbidden, ErrorIssuerNotAllowed, "key issuer not allowed"}
+	}
+
+	publicKey, err := config.Resolver.ResolveKey(keyIssuer, keyId)
+
+	if err != nil {
+		return "", &serviceError{http.StatusUnprocessableEntity, ErrorUnknownKey, err.Error()}
+	}
+
+	return publicKey, nil
+}
+
+// typedPayload converts the token data into the payload struct of its type.
+//
+// Parameters:
+//   - data: The verified token data
+//
+// Returns:
+//   - The payload type
+//   - The typed payload
+//   - An error if the data is not a payment token payload
+func typedPayload(data map[string]interface{}) (string, any, error) {
+	var payloadType string
+	var payload any
+
+	switch {
+	case data["payment"] != nil:
+		payloadType, payload = PayloadInstruction, &protocol.InstructionPayload{}
+	case data["payments"] != nil:
+		payloadType, payload = PayloadMultiAsset, &protocol.MultiAssetPayload{}
+	case data["url"] != nil:
+		payloadType, payload = PayloadUrl, &protocol.UrlPayload{}
+	default:
+		return "", nil, errors.New("token does not contain a payment payload")
+	}
+
+	if err := protocol.PayloadOf(data, payload); err != nil {
+		return "", nil, err
+	}
+
+	return payloadType, payload, nil
+}
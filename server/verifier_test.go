@@ -0,0 +1,136 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/protocol"
+	"github.com/fluxisus/naspip-go/v3/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// createToken creates an instruction token signed with the test key
+func createToken(t *testing.T, kis string, signOptions paseto.PasetoSignOptions) string {
+	signOptions.KeyId = "key-1"
+	signOptions.Assertion = []byte(keys["publicKey"])
+
+	if signOptions.ExpiresIn == "" {
+		signOptions.ExpiresIn = "5m"
+	}
+
+	token, err := builder.CreatePaymentInstruction(instruction, keys["secretKey"], protocol.QrCriptoCreateOptions{
+		SignOptions:   signOptions,
+		KeyIssuer:     kis,
+		KeyExpiration: time.Now().Add(1e9).Format(utils.RFC3339Mili),
+	})
+
+	if err != nil {
+		t.Fatalf("createToken FAIL --> %v", err)
+	}
+
+	return token
+}
+
+// verificationHandler returns a verification handler trusting the test key
+func verificationHandler(config VerificationConfig) http.Handler {
+	config.Builder = builder
+	config.Resolver = protocol.StaticKeyResolver{
+		"merchant.com": {"key-1": keys["publicKey"]},
+		"other.com":    {"key-1": keys["publicKey"]},
+	}
+
+	return NewVerificationHandler(config)
+}
+
+// Should verify a token and return its typed payload
+func TestVerify(t *testing.T) {
+	assert := assert.New(t)
+
+	token := createToken(t, "merchant.com", paseto.PasetoSignOptions{Audience: "checkout"})
+
+	recorder := post(verificationHandler(VerificationConfig{KeyIssuers: []string{"merchant.com"}, Audience: "checkout", MaxTokenAge: "1h"}), "/v1/read", "", VerifyRequest{Token: token})
+
+	assert.Equal(http.StatusOK, recorder.Code)
+
+	var response struct {
+		VerifyResponse
+		Payload protocol.InstructionPayload `json:"payload"`
+	}
+
+	assert.Nil(json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal("merchant.com", response.KeyIssuer)
+	assert.Equal("key-1", response.KeyId)
+	assert.Equal(PayloadInstruction, response.Type)
+	assert.Equal(instruction.Payment, response.Payload.Payment)
+}
+
+// Should reject tokens with structured error codes
+func TestVerifyErrors(t *testing.T) {
+	token := createToken(t, "merchant.com", paseto.PasetoSignOptions{})
+
+	otherKeys, _ := paseto.GenerateKey("public", "paserk")
+
+	tests := []struct {
+		name   string
+		config VerificationConfig
+		token  string
+		status int
+		code   string
+	}{
+		{name: "not a token", token: "qr-content", status: http.StatusBadRequest, code: ErrorInvalidToken},
+		{name: "issuer not allowed", config: VerificationConfig{KeyIssuers: []string{"other.com"}}, token: token, status: http.StatusForbidden, code: ErrorIssuerNotAllowed},
+		{name: "unknown key", token: "naspip;unknown.com;key-1;v4.public.payload", status: http.StatusUnprocessableEntity, code: ErrorUnknownKey},
+		{name: "audience mismatch", config: VerificationConfig{Audience: "checkout"}, token: token, status: http.StatusUnprocessableEntity, code: ErrorAudienceMismatch},
+		{name: "token expired", token: createToken(t, "merchant.com", paseto.PasetoSignOptions{ExpiresIn: "0s"}), status: http.StatusUnprocessableEntity, code: ErrorTokenExpired},
+		{name: "token not active", token: createToken(t, "merchant.com", paseto.PasetoSignOptions{NotBefore: "1h"}), status: http.StatusUnprocessableEntity, code: ErrorTokenNotActive},
+		{name: "max age exceeded", config: VerificationConfig{MaxTokenAge: "0s"}, token: token, status: http.StatusUnprocessableEntity, code: ErrorMaxAgeExceeded},
+		{name: "key revoked", config: VerificationConfig{RevocationChecker: protocol.NewMemoryRevocationChecker(protocol.RevokedKey{KeyIssuer: "merchant.com", KeyId: "key-1", RevokedAt: 1})}, token: token, status: http.StatusUnprocessableEntity, code: ErrorKeyRevoked},
+		{name: "rate limited", config: VerificationConfig{RateLimiter: RateLimiterFunc(func(r *http.Request) bool { return false })}, token: token, status: http.StatusTooManyRequests, code: ErrorRateLimited},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := post(verificationHandler(test.config), "/v1/read", "", VerifyRequest{Token: test.token})
+
+			var response ErrorResponse
+
+			assert.Equal(t, test.status, recorder.Code)
+			assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.Equal(t, test.code, response.Error.Code)
+		})
+	}
+
+	// A token signed by another key for a known issuer
+	forged, _ := builder.CreatePaymentInstruction(instruction, otherKeys["secretKey"], protocol.QrCriptoCreateOptions{
+		SignOptions:   paseto.PasetoSignOptions{KeyId: "key-1", ExpiresIn: "5m", Assertion: []byte(keys["publicKey"])},
+		KeyIssuer:     "merchant.com",
+		KeyExpiration: time.Now().Add(1e9).Format(utils.RFC3339Mili),
+	})
+
+	recorder := post(verificationHandler(VerificationConfig{}), "/v1/read", "", VerifyRequest{Token: forged})
+
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), ErrorInvalidSignature)
+}
+
+// Should reject local tokens encrypted with the bytes of a trusted public key
+func TestVerifyLocalTokenKeyedWithPublicKey(t *testing.T) {
+	forgedKey := "k4.local." + utils.EncodeRawURLBase64(paseto.GetPublicKey(keys["publicKey"]))
+
+	forged, err := protocol.PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4LocalHandler{}}.CreatePaymentInstruction(instruction, forgedKey, protocol.QrCriptoCreateOptions{
+		SignOptions:   paseto.PasetoSignOptions{KeyId: "key-1", ExpiresIn: "5m", Assertion: []byte(forgedKey)},
+		KeyIssuer:     "merchant.com",
+		KeyExpiration: time.Now().Add(1e9).Format(utils.RFC3339Mili),
+	})
+
+	assert.Nil(t, err)
+
+	recorder := post(verificationHandler(VerificationConfig{}), "/v1/read", "", VerifyRequest{Token: forged})
+
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "unexpected PASETO header")
+}
@@ -0,0 +1,169 @@
+package urlpayload
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/protocol"
+)
+
+// Client resolves payment URL tokens on the wallet side.
+type Client struct {
+	HTTPClient  *http.Client                        // Client used to call the payment URL, http.DefaultClient when nil
+	Builder     protocol.PaymentInstructionsBuilder // Builder used to read tokens
+	Resolver    protocol.KeyResolver                // Resolver of the public keys of the token key issuers
+	ReadOptions protocol.QrCriptoReadOptions        // Options used to read both tokens
+}
+
+// Result is a resolved payment URL.
+type Result struct {
+	UrlPayload  protocol.UrlPayload          // Verified payment URL payload
+	Token       string                       // NASPIP token of the returned payment instruction
+	Instruction protocol.InstructionPayload  // Verified payment instruction
+	Data        *paseto.PasetoCompleteResult // Verified content of the instruction token
+}
+
+// Resolve verifies a payment URL token, requests the payment instruction for the chosen asset
+// and verifies the returned instruction. The instruction must be signed by the key issuer of
+// the payment URL token, and its asset must be the chosen one and among the PaymentOptions.
+//
+// Parameters:
+//   - ctx: Context of the HTTP request
+//   - qrPayment: A NASPIP token containing a payment URL
+//   - request: The request sent to the payment URL
+//
+// Returns:
+//   - The resolved payment instruction
+//   - An error if a token is invalid, the payment URL fails (*Error) or the instruction does not
+//     match the request
+func (c Client) Resolve(ctx context.Context, qrPayment string, request Request) (*Result, error) {
+	urlData, err := c.read(qrPayment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := urlPayload(urlData)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if request.UniqueAssetId == "" || !offers(payload.PaymentOptions, request.UniqueAssetId) {
+		return nil, errors.New("asset not offered by the payment url")
+	}
+
+	token, err := c.post(ctx, payload.Url, request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	decodedQr, err := c.Builder.Decode(token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if decodedQr.KeyIssuer != urlData.Payload.Kis {
+		return nil, errors.New("instruction key issuer mismatch")
+	}
+
+	data, err := c.read(token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if data.Payload.Kis != urlData.Payload.Kis {
+		return nil, errors.New("instruction key issuer mismatch")
+	}
+
+	instruction, err := instructionPayload(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if instruction.Payment.UniqueAssetId != request.UniqueAssetId || !offers(payload.PaymentOptions, instruction.Payment.UniqueAssetId) {
+		return nil, errors.New("instruction asset does not match the request")
+	}
+
+	return &Result{UrlPayload: *payload, Token: token, Instruction: *instruction, Data: data}, nil
+}
+
+// read verifies a token with the key resolved from its prefix.
+func (c Client) read(qrPayment string) (*paseto.PasetoCompleteResult, error) {
+	decodedQr, err := c.Builder.Decode(qrPayment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := c.Resolver.ResolveKey(decodedQr.KeyIssuer, decodedQr.KeyId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Builder.Read(qrPayment, publicKey, c.ReadOptions)
+}
+
+// post sends the request to the payment URL and returns the instruction token.
+func (c Client) post(ctx context.Context, url string, request Request) (string, error) {
+	body, err := json.Marshal(request)
+
+	if err != nil {
+		return "", err
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+
+	if err != nil {
+		return "", err
+	}
+
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Accept", "application/json")
+
+	client := c.HTTPClient
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	httpResponse, err := client.Do(httpRequest)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer httpResponse.Body.Close()
+
+	responseBody, err := io.ReadAll(io.LimitReader(httpResponse.Body, maxBodySize))
+
+	if err != nil {
+		return "", err
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		var errorResponse ErrorResponse
+
+		_ = json.Unmarshal(responseBody, &errorResponse)
+
+		return "", &Error{Status: httpResponse.StatusCode, Code: errorResponse.Error.Code, Message: errorResponse.Error.Message}
+	}
+
+	var response Response
+
+	if err := json.Unmarshal(responseBody, &response); err != nil || response.Token == "" {
+		return "", errors.New("invalid payment url response")
+	}
+
+	return response.Token, nil
+}
@@ -0,0 +1,102 @@
+package urlpayload
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fluxisus/naspip-go/v3/internal/httpapi"
+	"github.com/fluxisus/naspip-go/v3/protocol"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// resolveWith serves the handler, creates a payment URL token pointing to it and resolves it
+func resolveWith(t *testing.T, handler http.Handler, request Request) (*Result, error) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	qrPayment, err := builder.CreateUrlPayload(protocol.UrlPayload{Url: server.URL, PaymentOptions: paymentOptions}, keys["secretKey"], createOptions("merchant.com"))
+
+	if err != nil {
+		t.Fatalf("resolveWith FAIL --> %v", err)
+	}
+
+	client := Client{
+		HTTPClient: server.Client(),
+		Builder:    builder,
+		Resolver: protocol.StaticKeyResolver{
+			"merchant.com": {"key-1": keys["publicKey"]},
+			"other.com":    {"key-1": keys["publicKey"]},
+		},
+	}
+
+	return client.Resolve(context.Background(), qrPayment, request)
+}
+
+// Should resolve a payment URL into a verified instruction
+func TestResolve(t *testing.T) {
+	assert := assert.New(t)
+
+	result, err := resolveWith(t, NewHandler(handlerConfig()), Request{UniqueAssetId: paymentOptions[0]})
+
+	assert.Nil(err)
+	assert.Equal(paymentOptions, result.UrlPayload.PaymentOptions)
+	assert.Equal(paymentOptions[0], result.Instruction.Payment.UniqueAssetId)
+	assert.Equal("deposit-address", result.Instruction.Payment.Address)
+	assert.Equal("merchant.com", result.Data.Payload.Kis)
+}
+
+// Should reject instructions that do not match the payment URL
+func TestResolveErrors(t *testing.T) {
+	otherIssuer := handlerConfig()
+	otherIssuer.Options = createOptions("other.com")
+
+	otherAsset := handlerConfig()
+	otherAsset.PaymentOptions = nil
+	otherAsset.Instruction = func(r *http.Request, request Request) (*protocol.InstructionPayload, error) {
+		return depositInstruction(r, Request{UniqueAssetId: paymentOptions[1]})
+	}
+
+	// A merchant answering with an instruction for another asset than the requested one
+	misbehaving := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		instruction, _ := otherAsset.Instruction(r, Request{})
+		token, _ := builder.CreatePaymentInstruction(*instruction, keys["secretKey"], createOptions("merchant.com"))
+		httpapi.WriteJSON(w, http.StatusOK, Response{Token: token})
+	})
+
+	tests := []struct {
+		name    string
+		handler http.Handler
+		request Request
+		err     string
+	}{
+		{name: "asset not offered", handler: NewHandler(handlerConfig()), request: Request{UniqueAssetId: "nbep20_token"}, err: "asset not offered by the payment url"},
+		{name: "other key issuer", handler: NewHandler(otherIssuer), request: Request{UniqueAssetId: paymentOptions[0]}, err: "instruction key issuer mismatch"},
+		{name: "other asset", handler: misbehaving, request: Request{UniqueAssetId: paymentOptions[0]}, err: "instruction asset does not match the request"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := resolveWith(t, test.handler, test.request)
+
+			assert.EqualError(t, err, test.err)
+		})
+	}
+
+	failing := handlerConfig()
+	failing.Instruction = func(r *http.Request, request Request) (*protocol.InstructionPayload, error) {
+		return nil, errors.New("no deposit address available")
+	}
+
+	_, err := resolveWith(t, NewHandler(failing), Request{UniqueAssetId: paymentOptions[0]})
+
+	var urlError *Error
+
+	assert.True(t, errors.As(err, &urlError))
+	assert.Equal(t, http.StatusUnprocessableEntity, urlError.Status)
+	assert.Equal(t, ErrorInstructionUnavailable, urlError.Code)
+	assert.Equal(t, "no deposit address available", urlError.Message)
+}
@@ -0,0 +1,77 @@
+package urlpayload
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/fluxisus/naspip-go/v3/internal/httpapi"
+	"github.com/fluxisus/naspip-go/v3/protocol"
+)
+
+// InstructionFunc builds the payment instruction for a request, for example by assigning a
+// deposit address for the chosen asset. The payment asset must be the requested one.
+type InstructionFunc func(r *http.Request, request Request) (*protocol.InstructionPayload, error)
+
+// HandlerConfig configures the merchant side of a payment URL.
+type HandlerConfig struct {
+	Builder        protocol.PaymentInstructionsBuilder // Builder used to create the instruction token
+	PaymentOptions []string                            // Assets offered by the payment URL token, empty for any
+	SecretKey      string                              // Private key signing the instructions, of the same key issuer as the payment URL token
+	Options        protocol.QrCriptoCreateOptions      // Options used to create the instruction token
+	Instruction    InstructionFunc                     // Builder of the payment instructions
+}
+
+// NewHandler creates the HTTP handler of a payment URL. It accepts POST requests with a
+// Request body, and answers with a Response carrying the signed payment instruction, or an
+// ErrorResponse with a stable error code.
+//
+// Parameters:
+//   - config: The handler configuration
+//
+// Returns:
+//   - The HTTP handler
+func NewHandler(config HandlerConfig) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			httpapi.WriteError(w, http.StatusMethodNotAllowed, ErrorInvalidRequest, "method not allowed")
+			return
+		}
+
+		var request Request
+
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(&request); err != nil || request.UniqueAssetId == "" {
+			httpapi.WriteError(w, http.StatusBadRequest, ErrorInvalidRequest, "invalid request body")
+			return
+		}
+
+		if !offers(config.PaymentOptions, request.UniqueAssetId) {
+			httpapi.WriteError(w, http.StatusUnprocessableEntity, ErrorAssetNotOffered, "asset not offered by the payment url")
+			return
+		}
+
+		instruction, err := config.Instruction(r, request)
+
+		if err != nil {
+			httpapi.WriteError(w, http.StatusUnprocessableEntity, ErrorInstructionUnavailable, err.Error())
+			return
+		}
+
+		if instruction.Payment.UniqueAssetId != request.UniqueAssetId {
+			httpapi.WriteError(w, http.StatusInternalServerError, ErrorInstructionUnavailable, "instruction asset does not match the request")
+			return
+		}
+
+		token, err := config.Builder.CreatePaymentInstruction(*instruction, config.SecretKey, config.Options)
+
+		if err != nil {
+			httpapi.WriteError(w, http.StatusInternalServerError, ErrorSigningFailed, err.Error())
+			return
+		}
+
+		httpapi.WriteJSON(w, http.StatusOK, Response{Token: token})
+	})
+}
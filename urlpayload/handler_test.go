@@ -0,0 +1,129 @@
+package urlpayload
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/protocol"
+	"github.com/fluxisus/naspip-go/v3/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var keys = map[string]string{
+	"publicKey": "k4.public.sGVse4eAyt6ycfmkKl3Az7RxB34nklDPgKbNLvxVwlk",
+	"secretKey": "k4.secret.y4-gze54dwfLR0eyxiJL2mRicZr6SX2-xIn6kgo999iwZWx7h4DK3rJx-aQqXcDPtHEHfieSUM-Aps0u_FXCWQ",
+}
+
+var builder = protocol.PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+var paymentOptions = []string{"ntrc20_tTR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", "nerc20_0xdAC17F958D2ee523a2206206994597C13D831ec7"}
+
+// createOptions returns the options to sign tokens for the key issuer with the test key
+func createOptions(keyIssuer string) protocol.QrCriptoCreateOptions {
+	return protocol.QrCriptoCreateOptions{
+		SignOptions: paseto.PasetoSignOptions{
+			KeyId:     "key-1",
+			ExpiresIn: "5m",
+			Assertion: []byte(keys["publicKey"]),
+		},
+		KeyIssuer:     keyIssuer,
+		KeyExpiration: time.Now().Add(1e9).Format(utils.RFC3339Mili),
+	}
+}
+
+// depositInstruction returns an instruction paying the requested asset
+func depositInstruction(r *http.Request, request Request) (*protocol.InstructionPayload, error) {
+	return &protocol.InstructionPayload{
+		Payment: protocol.PaymentInstruction{
+			Id:            "payment-id",
+			Address:       "deposit-address",
+			UniqueAssetId: request.UniqueAssetId,
+			Amount:        "10",
+			ExpiresAt:     time.Now().Add(time.Hour).UnixMilli(),
+		},
+	}, nil
+}
+
+// handlerConfig returns a merchant handler configuration for merchant.com
+func handlerConfig() HandlerConfig {
+	return HandlerConfig{
+		Builder:        builder,
+		PaymentOptions: paymentOptions,
+		SecretKey:      keys["secretKey"],
+		Options:        createOptions("merchant.com"),
+		Instruction:    depositInstruction,
+	}
+}
+
+// post sends a request body to the handler
+func post(handler http.Handler, body any) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(body)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/pay", bytes.NewReader(payload)))
+
+	return recorder
+}
+
+// Should return a signed instruction for an offered asset
+func TestHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	recorder := post(NewHandler(handlerConfig()), Request{UniqueAssetId: paymentOptions[1], Payer: &Payer{Wallet: "test-wallet"}})
+
+	assert.Equal(http.StatusOK, recorder.Code)
+
+	var response Response
+
+	assert.Nil(json.Unmarshal(recorder.Body.Bytes(), &response))
+
+	data, err := builder.Read(response.Token, keys["publicKey"], protocol.QrCriptoReadOptions{})
+
+	assert.Nil(err)
+	assert.Equal(paymentOptions[1], data.Payload.Data["payment"].(map[string]interface{})["unique_asset_id"])
+}
+
+// Should reject invalid requests with stable error codes
+func TestHandlerErrors(t *testing.T) {
+	failing := handlerConfig()
+	failing.Instruction = func(r *http.Request, request Request) (*protocol.InstructionPayload, error) {
+		return nil, errors.New("no deposit address available")
+	}
+
+	tests := []struct {
+		name   string
+		config HandlerConfig
+		body   any
+		status int
+		code   string
+	}{
+		{name: "missing asset", config: handlerConfig(), body: Request{}, status: http.StatusBadRequest, code: ErrorInvalidRequest},
+		{name: "unknown field", config: handlerConfig(), body: map[string]string{"unique_asset_id": paymentOptions[0], "amount": "1"}, status: http.StatusBadRequest, code: ErrorInvalidRequest},
+		{name: "asset not offered", config: handlerConfig(), body: Request{UniqueAssetId: "nbep20_token"}, status: http.StatusUnprocessableEntity, code: ErrorAssetNotOffered},
+		{name: "instruction unavailable", config: failing, body: Request{UniqueAssetId: paymentOptions[0]}, status: http.StatusUnprocessableEntity, code: ErrorInstructionUnavailable},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := post(NewHandler(test.config), test.body)
+
+			var response ErrorResponse
+
+			assert.Equal(t, test.status, recorder.Code)
+			assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.Equal(t, test.code, response.Error.Code)
+		})
+	}
+
+	recorder := httptest.NewRecorder()
+	NewHandler(handlerConfig()).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/pay", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
@@ -0,0 +1,102 @@
+// Package urlpayload implements the resolution of NASPIP payment URLs. A protocol.UrlPayload
+// token sends the wallet to a merchant URL to obtain a concrete payment instruction for one of
+// its PaymentOptions. The wallet POSTs a Request with the chosen asset to the URL, and the
+// merchant answers with a Response carrying a signed payment instruction token.
+//
+// Client implements the wallet side and checks that the returned instruction is for the chosen
+// asset and is signed by the key issuer of the payment URL. NewHandler implements the merchant side.
+package urlpayload
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/fluxisus/naspip-go/v3/internal/httpapi"
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/protocol"
+)
+
+// maxBodySize is the maximum size in bytes of a request or response body.
+const maxBodySize = 64 << 10
+
+// Error codes returned in ErrorResponse.
+const (
+	ErrorInvalidRequest         = "invalid_request"         // The request body is not valid JSON or misses fields
+	ErrorAssetNotOffered        = "asset_not_offered"       // The chosen asset is not one of the payment options
+	ErrorInstructionUnavailable = "instruction_unavailable" // The merchant could not provide an instruction
+	ErrorSigningFailed          = "signing_failed"          // The instruction could not be signed
+)
+
+// Request is sent by the wallet to the payment URL.
+type Request struct {
+	UniqueAssetId string `json:"unique_asset_id"` // Asset chosen by the payer, one of the PaymentOptions
+	Payer         *Payer `json:"payer,omitempty"` // Optional payer information
+}
+
+// Payer describes the payer, for merchants that need it to build the instruction
+// (e.g., to assign a deposit address or to send a refund).
+type Payer struct {
+	Name    string `json:"name,omitempty"`    // Payer name
+	Email   string `json:"email,omitempty"`   // Payer email address
+	Address string `json:"address,omitempty"` // Address the payment is sent from
+	Wallet  string `json:"wallet,omitempty"`  // Wallet application name
+}
+
+// Response is returned by the payment URL.
+type Response struct {
+	Token string `json:"token"` // NASPIP token of the payment instruction
+}
+
+// ErrorResponse is the body of error responses.
+type ErrorResponse = httpapi.ErrorResponse
+
+// ErrorDetail describes an error with a stable code and a human readable message.
+type ErrorDetail = httpapi.ErrorDetail
+
+// Error is returned by Client.Resolve when the payment URL rejects the request.
+type Error struct {
+	Status  int    // HTTP status code
+	Code    string // Error code, see the Error constants
+	Message string // Human readable description
+}
+
+// Error returns the error description.
+func (e *Error) Error() string {
+	return fmt.Sprintf("payment url error %d: %s: %s", e.Status, e.Code, e.Message)
+}
+
+// offers reports whether the payment options include the asset. Empty options allow any asset.
+func offers(paymentOptions []string, uniqueAssetId string) bool {
+	return len(paymentOptions) == 0 || slices.Contains(paymentOptions, uniqueAssetId)
+}
+
+// urlPayload returns the payment URL payload of verified token data.
+func urlPayload(data *paseto.PasetoCompleteResult) (*protocol.UrlPayload, error) {
+	if _, ok := data.Payload.Data["url"]; !ok {
+		return nil, errors.New("token does not contain a payment url")
+	}
+
+	var payload protocol.UrlPayload
+
+	if err := protocol.PayloadOf(data.Payload.Data, &payload); err != nil {
+		return nil, err
+	}
+
+	return &payload, nil
+}
+
+// instructionPayload returns the payment instruction payload of verified token data.
+func instructionPayload(data *paseto.PasetoCompleteResult) (*protocol.InstructionPayload, error) {
+	if _, ok := data.Payload.Data["payment"]; !ok {
+		return nil, errors.New("token does not contain a payment instruction")
+	}
+
+	var payload protocol.InstructionPayload
+
+	if err := protocol.PayloadOf(data.Payload.Data, &payload); err != nil {
+		return nil, err
+	}
+
+	return &payload, nil
+}
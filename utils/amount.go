@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/shopspring/decimal"
+)
+
+// FitsDecimals checks if a string representation of a decimal number can be expressed
+// with at most the given number of decimal places. Trailing zeros are not significant,
+// so "1.50" fits in one decimal place. It returns false for values that cannot be parsed.
+func FitsDecimals(value string, decimals int32) bool {
+	val, err := decimal.NewFromString(value)
+
+	if err != nil {
+		return false
+	}
+
+	return val.Equal(val.Truncate(decimals))
+}
+
+// ToBaseUnits converts a decimal amount string into its integer representation in the
+// smallest unit of an asset with the given number of decimals (e.g. "1.5" with 6 decimals
+// becomes "1500000"). It returns an error if the value is not a valid decimal number or
+// has more precision than the asset supports.
+func ToBaseUnits(value string, decimals int32) (string, error) {
+	val, err := decimal.NewFromString(value)
+
+	if err != nil {
+		return "", errors.New("invalid amount")
+	}
+
+	if !val.Equal(val.Truncate(decimals)) {
+		return "", errors.New("amount exceeds asset precision")
+	}
+
+	return val.Shift(decimals).BigInt().String(), nil
+}
+
+// FromBaseUnits converts an integer amount string expressed in the smallest unit of an
+// asset with the given number of decimals into a decimal amount string
+// (e.g. "1500000" with 6 decimals becomes "1.5").
+// It returns an error if the value is not a valid base-10 integer.
+func FromBaseUnits(value string, decimals int32) (string, error) {
+	val, ok := new(big.Int).SetString(value, 10)
+
+	if !ok {
+		return "", errors.New("invalid base units amount")
+	}
+
+	return decimal.NewFromBigInt(val, -decimals).String(), nil
+}
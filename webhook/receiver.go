@@ -0,0 +1,205 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/internal/httpapi"
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/protocol"
+	"github.com/fluxisus/naspip-go/v3/utils"
+)
+
+// defaultMaxAge is the default maximum age of a received event token.
+const defaultMaxAge = 5 * time.Minute
+
+// maxClockSkew is how far ahead of the receiver clock the issue time of an event token may be.
+const maxClockSkew = 30 * time.Second
+
+// ReceiverConfig configures the webhook receiver middleware.
+type ReceiverConfig struct {
+	Builder     protocol.PaymentInstructionsBuilder // Builder used to read the event tokens
+	Resolver    protocol.KeyResolver                // Resolver of the public keys of the senders
+	KeyIssuers  []string                            // Accepted key issuers, empty for any issuer known to the Resolver
+	Audience    string                              // Required audience (aud claim) of the event tokens, identifying this receiver
+	ReadOptions protocol.QrCriptoReadOptions        // Options used to read the event tokens
+	MaxAge      time.Duration                       // Maximum age of an event token, from its iat claim, 5m when zero
+	Replay      ReplayCache                         // Cache of the received token identifiers, a MemoryReplayCache when nil
+	Clock       protocol.Clock                      // Clock used to check the freshness, protocol.SystemClock when nil
+}
+
+// NewReceiver creates a middleware receiving webhook deliveries. It accepts POST requests with
+// a Delivery body, verifies the event token signature with the key of its issuer and its
+// audience, rejects tokens older than MaxAge or issued in the future, and tokens whose jti was
+// already received from the same key issuer, and calls next with the verified event in the
+// request context (see FromContext). Rejected deliveries are answered with an ErrorResponse
+// carrying a stable error code.
+//
+// When next answers with a 5xx status, the jti is forgotten so that the sender can retry the
+// delivery.
+//
+// Parameters:
+//   - config: The receiver configuration
+//   - next: The handler of the verified events
+//
+// Returns:
+//   - The HTTP handler
+//   - ErrMissingAudience if config.Audience is empty, since tokens signed for another receiver
+//     would otherwise be accepted
+func NewReceiver(config ReceiverConfig, next http.Handler) (http.Handler, error) {
+	if config.Audience == "" {
+		return nil, ErrMissingAudience
+	}
+
+	config.ReadOptions.VerifyOptions.Audience = config.Audience
+
+	if config.Clock == nil {
+		config.Clock = protocol.SystemClock{}
+	}
+
+	if config.Replay == nil {
+		config.Replay = NewMemoryReplayCache(config.Clock)
+	}
+
+	if config.MaxAge <= 0 {
+		config.MaxAge = defaultMaxAge
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			httpapi.WriteError(w, http.StatusMethodNotAllowed, ErrorInvalidRequest, "method not allowed")
+			return
+		}
+
+		var delivery Delivery
+
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(&delivery); err != nil || delivery.Token == "" {
+			httpapi.WriteError(w, http.StatusBadRequest, ErrorInvalidRequest, "invalid request body")
+			return
+		}
+
+		received, issuedAt, failure := config.verify(delivery.Token)
+
+		if failure != nil {
+			httpapi.WriteError(w, failure.status, failure.code, failure.message)
+			return
+		}
+
+		replayKey := received.replayKey()
+
+		if !config.Replay.Remember(replayKey, issuedAt.Add(config.MaxAge)) {
+			httpapi.WriteError(w, http.StatusConflict, ErrorReplayedEvent, "event already received")
+			return
+		}
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(recorder, r.WithContext(context.WithValue(r.Context(), eventKey, received)))
+
+		if recorder.status >= 500 {
+			config.Replay.Forget(replayKey)
+		}
+	})
+
+	return handler, nil
+}
+
+// receiverFailure describes a rejected delivery.
+type receiverFailure struct {
+	status  int    // HTTP status code
+	code    string // Error code
+	message string // Human readable description
+}
+
+// verify checks the signature, the key issuer, the audience and the freshness of an event
+// token, and returns the verified event with the issue time of the token.
+func (c ReceiverConfig) verify(token string) (*Received, time.Time, *receiverFailure) {
+	decodedQr, err := c.Builder.Decode(token)
+
+	if err != nil {
+		return nil, time.Time{}, &receiverFailure{http.StatusBadRequest, ErrorInvalidRequest, err.Error()}
+	}
+
+	if len(c.KeyIssuers) > 0 && !slices.Contains(c.KeyIssuers, decodedQr.KeyIssuer) {
+		return nil, time.Time{}, &receiverFailure{http.StatusForbidden, ErrorIssuerNotAllowed, "key issuer not allowed"}
+	}
+
+	publicKey, err := c.Resolver.ResolveKey(decodedQr.KeyIssuer, decodedQr.KeyId)
+
+	if err != nil {
+		return nil, time.Time{}, &receiverFailure{http.StatusUnauthorized, ErrorUnknownKey, err.Error()}
+	}
+
+	data, err := c.Builder.Read(token, publicKey, c.ReadOptions)
+
+	if errors.Is(err, paseto.ErrAudienceMismatch) {
+		return nil, time.Time{}, &receiverFailure{http.StatusUnauthorized, ErrorAudienceMismatch, err.Error()}
+	}
+
+	if err != nil {
+		return nil, time.Time{}, &receiverFailure{http.StatusUnauthorized, ErrorInvalidSignature, err.Error()}
+	}
+
+	event, err := protocol.WebhookEventOf(data.Payload.Data)
+
+	if err != nil {
+		return nil, time.Time{}, &receiverFailure{http.StatusBadRequest, ErrorInvalidRequest, err.Error()}
+	}
+
+	iat, err := time.Parse(utils.RFC3339Mili, data.Payload.Iat)
+	now := c.Clock.Now()
+
+	if err != nil || now.Sub(iat) > c.MaxAge {
+		return nil, time.Time{}, &receiverFailure{http.StatusUnauthorized, ErrorStaleEvent, "event token is too old"}
+	}
+
+	if iat.After(now.Add(maxClockSkew)) {
+		return nil, time.Time{}, &receiverFailure{http.StatusUnauthorized, ErrorFutureEvent, "event token is issued in the future"}
+	}
+
+	if data.Payload.Jti == "" {
+		return nil, time.Time{}, &receiverFailure{http.StatusBadRequest, ErrorMissingIdentifier, "event token has no jti"}
+	}
+
+	received := &Received{
+		Event:     *event,
+		Jti:       data.Payload.Jti,
+		KeyIssuer: data.Payload.Kis,
+		KeyId:     data.Payload.Kid,
+		Token:     token,
+	}
+
+	return received, iat, nil
+}
+
+// statusRecorder records the status code written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status  int
+	written bool
+}
+
+// WriteHeader records the first status code and writes it.
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.written {
+		r.status = status
+		r.written = true
+	}
+
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write writes the body, with a 200 status code if none was written.
+func (r *statusRecorder) Write(body []byte) (int, error) {
+	r.written = true
+
+	return r.ResponseWriter.Write(body)
+}
@@ -0,0 +1,280 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/protocol"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// receiverConfig returns a receiver accepting events of psp.com signed with the test key
+func receiverConfig() ReceiverConfig {
+	return ReceiverConfig{
+		Builder:    builder,
+		Resolver:   protocol.StaticKeyResolver{"psp.com": {"key-1": keys["publicKey"]}, "other.com": {"key-1": keys["publicKey"]}},
+		KeyIssuers: []string{"psp.com"},
+		Audience:   "merchant.com/webhook",
+	}
+}
+
+// newReceiver creates a receiver, failing the test on error
+func newReceiver(t *testing.T, config ReceiverConfig, next http.Handler) http.Handler {
+	handler, err := NewReceiver(config, next)
+
+	if err != nil {
+		t.Fatalf("newReceiver FAIL --> %v", err)
+	}
+
+	return handler
+}
+
+// deliver POSTs a delivery body to the handler
+func deliver(handler http.Handler, token string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(Delivery{Token: token})
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body)))
+
+	return recorder
+}
+
+// errorCode returns the error code of a response
+func errorCode(recorder *httptest.ResponseRecorder) string {
+	var response ErrorResponse
+
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+	return response.Error.Code
+}
+
+// Should pass verified events to the next handler and reject replays
+func TestReceiver(t *testing.T) {
+	assert := assert.New(t)
+
+	var received []*Received
+
+	handler := newReceiver(t, receiverConfig(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event, ok := FromContext(r.Context())
+
+		assert.True(ok)
+		received = append(received, event)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	token, err := sender("psp.com").Sign(event)
+	assert.Nil(err)
+
+	recorder := deliver(handler, token)
+
+	assert.Equal(http.StatusNoContent, recorder.Code)
+	assert.Len(received, 1)
+	assert.Equal(event, received[0].Event)
+	assert.Equal("psp.com", received[0].KeyIssuer)
+	assert.Equal("key-1", received[0].KeyId)
+	assert.NotEmpty(received[0].Jti)
+	assert.Equal(token, received[0].Token)
+
+	recorder = deliver(handler, token)
+
+	assert.Equal(http.StatusConflict, recorder.Code)
+	assert.Equal(ErrorReplayedEvent, errorCode(recorder))
+	assert.Len(received, 1)
+}
+
+// Should reject invalid deliveries with a stable error code
+func TestReceiverErrors(t *testing.T) {
+	valid, _ := sender("psp.com").Sign(event)
+	otherIssuer, _ := sender("other.com").Sign(event)
+	unknownKey, _ := sender("unknown.com").Sign(event)
+
+	forgedKeys, _ := paseto.GenerateKey("public", "paserk")
+	forgedSender := sender("psp.com")
+	forgedSender.SecretKey = forgedKeys["secretKey"]
+	forgedSender.Options.SignOptions.Assertion = []byte(keys["publicKey"])
+	forged, _ := forgedSender.Sign(event)
+
+	otherAudienceSender := sender("psp.com")
+	otherAudienceSender.Audience = "other.com/webhook"
+	otherAudience, _ := otherAudienceSender.Sign(event)
+
+	options := createOptions("psp.com")
+	options.SignOptions.ExpiresIn = "5m"
+	options.SignOptions.Audience = "merchant.com/webhook"
+
+	withoutJti, _ := builder.CreateWebhookEvent(event, keys["secretKey"], options)
+
+	options.SignOptions.Jti = "instruction-jti"
+
+	instruction, _ := builder.CreatePaymentInstruction(protocol.InstructionPayload{
+		Payment: protocol.PaymentInstruction{
+			Id:            "payment-id",
+			Address:       "deposit-address",
+			UniqueAssetId: "ntrc20_tTR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+			Amount:        "10",
+			ExpiresAt:     time.Now().Add(time.Hour).UnixMilli(),
+		},
+	}, keys["secretKey"], options)
+
+	tests := []struct {
+		name   string
+		token  string
+		clock  protocol.Clock
+		status int
+		code   string
+	}{
+		{name: "malformed token", token: "not-a-token", status: http.StatusBadRequest, code: ErrorInvalidRequest},
+		{name: "issuer not allowed", token: otherIssuer, status: http.StatusForbidden, code: ErrorIssuerNotAllowed},
+		{name: "unknown issuer", token: unknownKey, status: http.StatusForbidden, code: ErrorIssuerNotAllowed},
+		{name: "forged signature", token: forged, status: http.StatusUnauthorized, code: ErrorInvalidSignature},
+		{name: "audience mismatch", token: otherAudience, status: http.StatusUnauthorized, code: ErrorAudienceMismatch},
+		{name: "stale event", token: valid, clock: &fixedClock{now: time.Now().Add(6 * time.Minute)}, status: http.StatusUnauthorized, code: ErrorStaleEvent},
+		{name: "future event", token: valid, clock: &fixedClock{now: time.Now().Add(-time.Minute)}, status: http.StatusUnauthorized, code: ErrorFutureEvent},
+		{name: "missing jti", token: withoutJti, status: http.StatusBadRequest, code: ErrorMissingIdentifier},
+		{name: "not a webhook event", token: instruction, status: http.StatusBadRequest, code: ErrorInvalidRequest},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := receiverConfig()
+			config.Clock = test.clock
+
+			handler := newReceiver(t, config, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Fatal("next handler called")
+			}))
+
+			recorder := deliver(handler, test.token)
+
+			assert.Equal(t, test.status, recorder.Code)
+			assert.Equal(t, test.code, errorCode(recorder))
+		})
+	}
+
+	t.Run("unresolved key", func(t *testing.T) {
+		config := receiverConfig()
+		config.KeyIssuers = nil
+
+		recorder := deliver(newReceiver(t, config, http.NotFoundHandler()), unknownKey)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.Equal(t, ErrorUnknownKey, errorCode(recorder))
+	})
+
+	t.Run("missing audience", func(t *testing.T) {
+		config := receiverConfig()
+		config.Audience = ""
+
+		handler, err := NewReceiver(config, http.NotFoundHandler())
+
+		assert.Nil(t, handler)
+		assert.ErrorIs(t, err, ErrMissingAudience)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		newReceiver(t, receiverConfig(), http.NotFoundHandler()).ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader([]byte("{"))))
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, ErrorInvalidRequest, errorCode(recorder))
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		newReceiver(t, receiverConfig(), http.NotFoundHandler()).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/webhook", nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+		assert.Equal(t, http.MethodPost, recorder.Header().Get("Allow"))
+	})
+}
+
+// Should scope replays to the key issuer of the jti
+func TestReceiverReplaysPerIssuer(t *testing.T) {
+	assert := assert.New(t)
+
+	config := receiverConfig()
+	config.KeyIssuers = nil
+
+	handler := newReceiver(t, config, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	options := createOptions("psp.com")
+	options.SignOptions.ExpiresIn = "5m"
+	options.SignOptions.Audience = "merchant.com/webhook"
+	options.SignOptions.Jti = "shared-jti"
+
+	first, err := builder.CreateWebhookEvent(event, keys["secretKey"], options)
+	assert.Nil(err)
+
+	options.KeyIssuer = "other.com"
+
+	second, err := builder.CreateWebhookEvent(event, keys["secretKey"], options)
+	assert.Nil(err)
+
+	assert.Equal(http.StatusNoContent, deliver(handler, first).Code)
+	assert.Equal(http.StatusNoContent, deliver(handler, second).Code)
+	assert.Equal(http.StatusConflict, deliver(handler, first).Code)
+}
+
+// Should accept a redelivery after the next handler failed
+func TestReceiverForgetsFailedDeliveries(t *testing.T) {
+	assert := assert.New(t)
+
+	calls := 0
+
+	handler := newReceiver(t, receiverConfig(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		if calls == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_, _ = w.Write([]byte("ok"))
+	}))
+
+	token, err := sender("psp.com").Sign(event)
+	assert.Nil(err)
+
+	assert.Equal(http.StatusInternalServerError, deliver(handler, token).Code)
+	assert.Equal(http.StatusOK, deliver(handler, token).Code)
+	assert.Equal(http.StatusConflict, deliver(handler, token).Code)
+	assert.Equal(2, calls)
+}
+
+// Should deliver events from a sender to a receiver, retrying after a failure
+func TestSenderToReceiver(t *testing.T) {
+	assert := assert.New(t)
+
+	var events []protocol.WebhookEvent
+	failed := false
+
+	server := httptest.NewServer(newReceiver(t, receiverConfig(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !failed {
+			failed = true
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		received, _ := FromContext(r.Context())
+		events = append(events, received.Event)
+		w.WriteHeader(http.StatusNoContent)
+	})))
+	defer server.Close()
+
+	s := sender("psp.com")
+	s.HTTPClient = server.Client()
+
+	result, err := s.Send(context.Background(), server.URL, event)
+
+	assert.Nil(err)
+	assert.Equal(2, result.Attempts)
+	assert.Equal([]protocol.WebhookEvent{event}, events)
+}
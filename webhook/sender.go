@@ -0,0 +1,254 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/protocol"
+)
+
+// Default delivery settings of a Sender.
+const (
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = time.Minute
+	defaultExpiresIn      = "5m"
+)
+
+// Sender signs webhook events and delivers them, retrying failed deliveries.
+type Sender struct {
+	HTTPClient     *http.Client                        // Client used to deliver the events, http.DefaultClient when nil
+	Builder        protocol.PaymentInstructionsBuilder // Builder used to sign the events
+	SecretKey      string                              // Private key of the issuer signing the events
+	Audience       string                              // Audience (aud claim) of the event tokens, identifying the receiver
+	Options        protocol.QrCriptoCreateOptions      // Options used to create the event tokens, the jti is generated for each event
+	MaxAttempts    int                                 // Maximum number of delivery attempts, 5 when zero
+	InitialBackoff time.Duration                       // Wait before the first retry, doubled for each retry, 1s when zero
+	MaxBackoff     time.Duration                       // Maximum wait between attempts, 1m when zero
+}
+
+// Result describes a successful delivery.
+type Result struct {
+	Token    string // NASPIP token of the delivered event
+	Attempts int    // Number of attempts made
+	Status   int    // HTTP status code of the last response
+	Replayed bool   // Whether the receiver reported the event as already received
+}
+
+// DeliveryError is returned when an event could not be delivered.
+type DeliveryError struct {
+	Attempts int    // Number of attempts made
+	Status   int    // HTTP status code of the last response, zero if no response was received
+	Code     string // Error code of the last response, if any
+	Message  string // Error message of the last response, if any
+	Err      error  // Transport error of the last attempt, if any
+}
+
+// Error returns the error description.
+func (e *DeliveryError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("webhook delivery failed after %d attempts: %v", e.Attempts, e.Err)
+	}
+
+	return fmt.Sprintf("webhook delivery failed after %d attempts: status %d: %s: %s", e.Attempts, e.Status, e.Code, e.Message)
+}
+
+// Unwrap returns the transport error of the last attempt.
+func (e *DeliveryError) Unwrap() error {
+	return e.Err
+}
+
+// Sign creates the NASPIP token of an event for the Audience, with a new token identifier
+// (jti). The token expires after Options.SignOptions.ExpiresIn, 5 minutes when empty.
+//
+// Parameters:
+//   - event: The event to sign
+//
+// Returns:
+//   - The NASPIP token of the event
+//   - ErrMissingAudience if the Audience is empty, or an error if the event is invalid or
+//     cannot be signed
+func (s Sender) Sign(event protocol.WebhookEvent) (string, error) {
+	if s.Audience == "" {
+		return "", ErrMissingAudience
+	}
+
+	jti, err := newJti()
+
+	if err != nil {
+		return "", err
+	}
+
+	options := s.Options
+	options.SignOptions.Jti = jti
+	options.SignOptions.Audience = s.Audience
+
+	if options.SignOptions.ExpiresIn == "" {
+		options.SignOptions.ExpiresIn = defaultExpiresIn
+	}
+
+	return s.Builder.CreateWebhookEvent(event, s.SecretKey, options)
+}
+
+// Send signs an event and delivers it to the webhook URL. See Deliver.
+func (s Sender) Send(ctx context.Context, url string, event protocol.WebhookEvent) (*Result, error) {
+	token, err := s.Sign(event)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Deliver(ctx, url, token)
+}
+
+// Deliver POSTs a signed event to the webhook URL. Transport errors, 408, 429 and 5xx
+// responses are retried with exponential backoff, honouring the Retry-After header; other
+// responses are final. Every attempt sends the same token, and a receiver answering that the
+// event was already received (409 replayed_event) counts as a delivery, since a previous
+// attempt reached it.
+//
+// Parameters:
+//   - ctx: Context of the delivery, cancelling it stops the retries
+//   - url: The webhook URL
+//   - token: The NASPIP token of the event
+//
+// Returns:
+//   - The delivery result
+//   - A *DeliveryError if the event was not delivered, or the context error
+func (s Sender) Deliver(ctx context.Context, url string, token string) (*Result, error) {
+	body, err := json.Marshal(Delivery{Token: token})
+
+	if err != nil {
+		return nil, err
+	}
+
+	maxAttempts := s.MaxAttempts
+
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+
+	backoff := s.InitialBackoff
+
+	if backoff <= 0 {
+		backoff = defaultInitialBackoff
+	}
+
+	for attempt := 1; ; attempt++ {
+		result, failure, retryAfter := s.attempt(ctx, url, body)
+
+		if failure == nil {
+			result.Token = token
+			result.Attempts = attempt
+
+			return result, nil
+		}
+
+		failure.Attempts = attempt
+
+		if retryAfter < 0 || attempt >= maxAttempts {
+			return nil, failure
+		}
+
+		wait := max(backoff, retryAfter)
+		backoff *= 2
+
+		if err := sleep(ctx, min(wait, s.maxBackoff())); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// attempt makes one delivery attempt. It returns the result of a delivered event, or the
+// failure and the minimum wait before a retry, negative when the failure is final.
+func (s Sender) attempt(ctx context.Context, url string, body []byte) (*Result, *DeliveryError, time.Duration) {
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+
+	if err != nil {
+		return nil, &DeliveryError{Err: err}, -1
+	}
+
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Accept", "application/json")
+
+	client := s.HTTPClient
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	httpResponse, err := client.Do(httpRequest)
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, &DeliveryError{Err: ctx.Err()}, -1
+		}
+
+		return nil, &DeliveryError{Err: err}, 0
+	}
+
+	defer httpResponse.Body.Close()
+
+	responseBody, _ := io.ReadAll(io.LimitReader(httpResponse.Body, maxBodySize))
+
+	status := httpResponse.StatusCode
+
+	if status >= 200 && status < 300 {
+		return &Result{Status: status}, nil, 0
+	}
+
+	var errorResponse ErrorResponse
+
+	_ = json.Unmarshal(responseBody, &errorResponse)
+
+	if status == http.StatusConflict && errorResponse.Error.Code == ErrorReplayedEvent {
+		return &Result{Status: status, Replayed: true}, nil, 0
+	}
+
+	failure := &DeliveryError{Status: status, Code: errorResponse.Error.Code, Message: errorResponse.Error.Message}
+
+	if status == http.StatusRequestTimeout || status == http.StatusTooManyRequests || status >= 500 {
+		return nil, failure, retryAfter(httpResponse.Header.Get("Retry-After"))
+	}
+
+	return nil, failure, -1
+}
+
+// maxBackoff returns the maximum wait between attempts.
+func (s Sender) maxBackoff() time.Duration {
+	if s.MaxBackoff <= 0 {
+		return defaultMaxBackoff
+	}
+
+	return s.MaxBackoff
+}
+
+// retryAfter parses a Retry-After header given in seconds, zero when absent or invalid.
+func retryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(value)
+
+	if err != nil || seconds < 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// sleep waits for the duration or until the context is done.
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,184 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/internal/httpapi"
+	"github.com/fluxisus/naspip-go/v3/protocol"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Should sign events for the audience with a new jti and a default expiration
+func TestSign(t *testing.T) {
+	assert := assert.New(t)
+
+	s := sender("psp.com")
+
+	first, err := s.Sign(event)
+	assert.Nil(err)
+
+	second, err := s.Sign(event)
+	assert.Nil(err)
+
+	firstData, err := builder.Read(first, keys["publicKey"], protocol.QrCriptoReadOptions{})
+	assert.Nil(err)
+
+	secondData, err := builder.Read(second, keys["publicKey"], protocol.QrCriptoReadOptions{})
+	assert.Nil(err)
+
+	assert.NotEmpty(firstData.Payload.Jti)
+	assert.NotEqual(firstData.Payload.Jti, secondData.Payload.Jti)
+	assert.NotEmpty(firstData.Payload.Exp)
+	assert.Equal("merchant.com/webhook", firstData.Payload.Aud)
+
+	s.Audience = ""
+
+	_, err = s.Sign(event)
+	assert.ErrorIs(err, ErrMissingAudience)
+}
+
+// Should deliver events and retry transient failures
+func TestSend(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		code     string
+		attempts int
+		status   int
+		replayed bool
+		err      bool
+	}{
+		{name: "delivered", statuses: []int{http.StatusNoContent}, attempts: 1, status: http.StatusNoContent},
+		{name: "retried after server errors", statuses: []int{http.StatusBadGateway, http.StatusTooManyRequests, http.StatusOK}, attempts: 3, status: http.StatusOK},
+		{name: "replayed counts as delivered", statuses: []int{http.StatusConflict}, code: ErrorReplayedEvent, attempts: 1, status: http.StatusConflict, replayed: true},
+		{name: "client error is final", statuses: []int{http.StatusUnauthorized}, code: ErrorInvalidSignature, attempts: 1, status: http.StatusUnauthorized, err: true},
+		{name: "conflict without replay code is final", statuses: []int{http.StatusConflict}, attempts: 1, status: http.StatusConflict, err: true},
+		{name: "gives up after max attempts", statuses: []int{500, 500, 500, 500, 500, 500}, attempts: 3, status: 500, err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var calls atomic.Int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var delivery Delivery
+
+				assert.Nil(json.NewDecoder(r.Body).Decode(&delivery))
+				assert.NotEmpty(delivery.Token)
+
+				status := test.statuses[calls.Add(1)-1]
+
+				if status >= 300 {
+					httpapi.WriteError(w, status, test.code, "failure")
+					return
+				}
+
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+
+			s := sender("psp.com")
+			s.HTTPClient = server.Client()
+			s.MaxAttempts = 3
+
+			result, err := s.Send(context.Background(), server.URL, event)
+
+			if test.err {
+				var deliveryError *DeliveryError
+
+				assert.True(errors.As(err, &deliveryError))
+				assert.Equal(test.attempts, deliveryError.Attempts)
+				assert.Equal(test.status, deliveryError.Status)
+				assert.Nil(result)
+			} else {
+				assert.Nil(err)
+				assert.Equal(test.attempts, result.Attempts)
+				assert.Equal(test.status, result.Status)
+				assert.Equal(test.replayed, result.Replayed)
+				assert.NotEmpty(result.Token)
+			}
+
+			assert.Equal(int32(test.attempts), calls.Load())
+		})
+	}
+}
+
+// Should send the same token on every attempt
+func TestDeliverSameToken(t *testing.T) {
+	assert := assert.New(t)
+
+	tokens := []string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var delivery Delivery
+
+		_ = json.NewDecoder(r.Body).Decode(&delivery)
+		tokens = append(tokens, delivery.Token)
+
+		if len(tokens) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	s := sender("psp.com")
+	s.HTTPClient = server.Client()
+
+	result, err := s.Send(context.Background(), server.URL, event)
+
+	assert.Nil(err)
+	assert.Equal([]string{result.Token, result.Token}, tokens)
+}
+
+// Should report transport errors and stop when the context is cancelled
+func TestDeliverTransportError(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	s := sender("psp.com")
+	s.MaxAttempts = 2
+
+	_, err := s.Deliver(context.Background(), url, "token")
+
+	var deliveryError *DeliveryError
+
+	assert.True(errors.As(err, &deliveryError))
+	assert.Equal(2, deliveryError.Attempts)
+	assert.NotNil(deliveryError.Err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	s.MaxAttempts = 100
+	s.InitialBackoff = time.Hour
+	s.MaxBackoff = time.Hour
+
+	_, err = s.Deliver(ctx, url, "token")
+
+	assert.True(errors.Is(err, context.DeadlineExceeded))
+}
+
+// Should parse Retry-After delays given in seconds
+func TestRetryAfter(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(2*time.Second, retryAfter("2"))
+	assert.Equal(time.Duration(0), retryAfter(""))
+	assert.Equal(time.Duration(0), retryAfter("Wed, 21 Oct 2015 07:28:00 GMT"))
+}
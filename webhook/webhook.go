@@ -0,0 +1,170 @@
+// Package webhook delivers payment event notifications signed with NASPIP keys. A PSP signs
+// each protocol.WebhookEvent as a NASPIP token with its issuer key, so merchants verify
+// webhooks with the same key resolution as payment instructions instead of a shared secret.
+//
+// Sender signs events and POSTs them as a Delivery, retrying with exponential backoff.
+// NewReceiver is an http.Handler middleware that verifies the signature, the audience, the
+// freshness and the token identifier (jti) of the event against replays, and passes the verified event to
+// the next handler through the request context.
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/internal/httpapi"
+	"github.com/fluxisus/naspip-go/v3/protocol"
+)
+
+// maxBodySize is the maximum size in bytes of a delivery body.
+const maxBodySize = 64 << 10
+
+// Event types of the payment notifications.
+const (
+	EventInstructionCreated       = "instruction.created"        // An instruction was issued
+	EventInstructionPresented     = "instruction.presented"      // An instruction was shown to the payer
+	EventInstructionPartiallyPaid = "instruction.partially_paid" // Part of the requested amount was received
+	EventInstructionPaid          = "instruction.paid"           // The requested amount was received
+	EventInstructionExpired       = "instruction.expired"        // An instruction expired before being paid
+	EventInstructionCancelled     = "instruction.cancelled"      // An instruction was cancelled
+)
+
+// Error codes returned in ErrorResponse.
+const (
+	ErrorInvalidRequest    = "invalid_request"    // The request body is not a valid delivery
+	ErrorInvalidSignature  = "invalid_signature"  // The token signature or claims could not be verified
+	ErrorIssuerNotAllowed  = "issuer_not_allowed" // The token key issuer is not accepted
+	ErrorUnknownKey        = "unknown_key"        // The token key could not be resolved
+	ErrorStaleEvent        = "stale_event"        // The token was issued too long ago
+	ErrorReplayedEvent     = "replayed_event"     // The token was already received
+	ErrorMissingIdentifier = "missing_identifier" // The token has no jti to detect replays
+	ErrorAudienceMismatch  = "audience_mismatch"  // The token is not intended for this receiver
+	ErrorFutureEvent       = "future_event"       // The token is issued ahead of the receiver clock
+)
+
+// ErrMissingAudience is returned by Sender.Sign and NewReceiver when no audience is set.
+var ErrMissingAudience = errors.New("webhook audience is required")
+
+// Delivery is the body POSTed to the webhook endpoint.
+type Delivery struct {
+	Token string `json:"token"` // NASPIP token of the webhook event
+}
+
+// ErrorResponse is the body of error responses.
+type ErrorResponse = httpapi.ErrorResponse
+
+// ErrorDetail describes an error with a stable code and a human readable message.
+type ErrorDetail = httpapi.ErrorDetail
+
+// ReplayCache remembers the token identifiers of received events. The receiver records the jti
+// prefixed with the key issuer, since identifiers are only unique per issuer.
+type ReplayCache interface {
+	// Remember records a token identifier until the given time. It returns false if the
+	// identifier is already recorded.
+	Remember(jti string, until time.Time) bool
+
+	// Forget removes a token identifier, so that a failed delivery can be retried.
+	Forget(jti string)
+}
+
+// MemoryReplayCache is a ReplayCache kept in memory, for single-process receivers. It is safe
+// for concurrent use. Expired identifiers are removed as new ones are recorded.
+type MemoryReplayCache struct {
+	mu    sync.Mutex
+	clock protocol.Clock
+	seen  map[string]time.Time // Expiry of the recorded identifiers
+}
+
+// NewMemoryReplayCache creates an empty MemoryReplayCache.
+//
+// Parameters:
+//   - clock: Clock used to expire identifiers, protocol.SystemClock when nil
+//
+// Returns:
+//   - The replay cache
+func NewMemoryReplayCache(clock protocol.Clock) *MemoryReplayCache {
+	if clock == nil {
+		clock = protocol.SystemClock{}
+	}
+
+	return &MemoryReplayCache{clock: clock, seen: map[string]time.Time{}}
+}
+
+// Remember records a token identifier until the given time.
+func (c *MemoryReplayCache) Remember(jti string, until time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+
+	for id, expiry := range c.seen {
+		if !expiry.After(now) {
+			delete(c.seen, id)
+		}
+	}
+
+	if _, ok := c.seen[jti]; ok {
+		return false
+	}
+
+	c.seen[jti] = until
+
+	return true
+}
+
+// Forget removes a token identifier.
+func (c *MemoryReplayCache) Forget(jti string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.seen, jti)
+}
+
+// contextKey is the type of the request context keys of the package.
+type contextKey struct{}
+
+// eventKey is the request context key of the verified event.
+var eventKey = contextKey{}
+
+// Received is a verified webhook event, passed to the next handler of the receiver.
+type Received struct {
+	Event     protocol.WebhookEvent // The verified event
+	Jti       string                // Token identifier of the event
+	KeyIssuer string                // Issuer of the key that signed the event
+	KeyId     string                // Key that signed the event
+	Token     string                // NASPIP token of the event
+}
+
+// replayKey returns the identifier recorded in the ReplayCache, the jti scoped by the key issuer.
+func (r *Received) replayKey() string {
+	return r.KeyIssuer + ";" + r.Jti
+}
+
+// FromContext returns the verified event of a request handled by the receiver.
+//
+// Parameters:
+//   - ctx: The request context
+//
+// Returns:
+//   - The verified event
+//   - Whether the context carries an event
+func FromContext(ctx context.Context) (*Received, bool) {
+	received, ok := ctx.Value(eventKey).(*Received)
+
+	return received, ok
+}
+
+// newJti returns a random token identifier.
+func newJti() (string, error) {
+	bytes := make([]byte, 16)
+
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(bytes), nil
+}
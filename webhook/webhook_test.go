@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fluxisus/naspip-go/v3/paseto"
+	"github.com/fluxisus/naspip-go/v3/protocol"
+	"github.com/fluxisus/naspip-go/v3/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var keys = map[string]string{
+	"publicKey": "k4.public.sGVse4eAyt6ycfmkKl3Az7RxB34nklDPgKbNLvxVwlk",
+	"secretKey": "k4.secret.y4-gze54dwfLR0eyxiJL2mRicZr6SX2-xIn6kgo999iwZWx7h4DK3rJx-aQqXcDPtHEHfieSUM-Aps0u_FXCWQ",
+}
+
+var builder = protocol.PaymentInstructionsBuilder{PasetoHandler: paseto.PasetoV4Handler{}}
+
+var event = protocol.WebhookEvent{
+	EventType:     EventInstructionPaid,
+	InstructionId: "payment-id",
+	Status:        "paid",
+	OccurredAt:    time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC).UnixMilli(),
+}
+
+// fixedClock is a protocol.Clock returning a settable time
+type fixedClock struct {
+	now time.Time
+}
+
+func (c *fixedClock) Now() time.Time {
+	return c.now
+}
+
+// createOptions returns the options to sign tokens for the key issuer with the test key
+func createOptions(keyIssuer string) protocol.QrCriptoCreateOptions {
+	return protocol.QrCriptoCreateOptions{
+		SignOptions: paseto.PasetoSignOptions{
+			KeyId:     "key-1",
+			Assertion: []byte(keys["publicKey"]),
+		},
+		KeyIssuer:     keyIssuer,
+		KeyExpiration: time.Now().Add(1e9).Format(utils.RFC3339Mili),
+	}
+}
+
+// sender returns a sender signing with the test key
+func sender(keyIssuer string) Sender {
+	return Sender{
+		Builder:        builder,
+		SecretKey:      keys["secretKey"],
+		Audience:       "merchant.com/webhook",
+		Options:        createOptions(keyIssuer),
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+	}
+}
+
+// Should reject identifiers until they expire or are forgotten
+func TestMemoryReplayCache(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := &fixedClock{now: time.Now()}
+	cache := NewMemoryReplayCache(clock)
+
+	assert.True(cache.Remember("a", clock.now.Add(time.Minute)))
+	assert.False(cache.Remember("a", clock.now.Add(time.Minute)))
+	assert.True(cache.Remember("b", clock.now.Add(time.Minute)))
+
+	cache.Forget("b")
+
+	assert.True(cache.Remember("b", clock.now.Add(time.Minute)))
+
+	clock.now = clock.now.Add(time.Minute)
+
+	assert.True(cache.Remember("a", clock.now.Add(time.Minute)))
+}
+
+// Should only return events stored by the receiver
+func TestFromContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+
+	assert.False(t, ok)
+
+	received, ok := FromContext(context.WithValue(context.Background(), eventKey, &Received{Jti: "jti"}))
+
+	assert.True(t, ok)
+	assert.Equal(t, "jti", received.Jti)
+}